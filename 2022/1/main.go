@@ -1,11 +1,14 @@
-package main
+package day1
 
 import (
+	"context"
 	_ "embed"
-	"fmt"
+	"errors"
 	"sort"
 	"strconv"
 	"strings"
+
+	"github.com/rubinda/aoc"
 )
 
 type Elf struct {
@@ -14,15 +17,16 @@ type Elf struct {
 }
 
 //go:embed example.in
-var input string
+var example string
 
-func runChallenge(challengePart int) (int, []Elf) {
+// parseElves sums up the calories each elf is carrying. Elves are separated by an empty line.
+func parseElves(input string) ([]Elf, error) {
 	calories := strings.Split(input, "\n")
 	var elves []Elf
 	elfId := 0
 	elves = append(elves, Elf{id: elfId, caloriesCarrying: 0})
 
-	for _, v := range calories {
+	for i, v := range calories {
 		if v == "" {
 			elfId++
 			elves = append(elves, Elf{id: elfId, caloriesCarrying: 0})
@@ -30,27 +34,46 @@ func runChallenge(challengePart int) (int, []Elf) {
 		}
 		calories, err := strconv.Atoi(v)
 		if err != nil {
-			panic(err)
+			return nil, aoc.LineError(i, v, err)
 		}
 		elves[elfId].caloriesCarrying += calories
 	}
+	return elves, nil
+}
+
+func runChallenge(challengePart int, input string) (int, []Elf, error) {
+	elves, err := parseElves(input)
+	if err != nil {
+		return 0, nil, err
+	}
 	sort.Slice(elves, func(i, j int) bool {
 		return elves[i].caloriesCarrying > elves[j].caloriesCarrying
 	})
 
 	if challengePart == 1 {
-		return elves[0].caloriesCarrying, elves[:1]
+		return elves[0].caloriesCarrying, elves[:1], nil
 	} else {
+		if len(elves) < 3 {
+			return 0, nil, errors.New("need at least 3 elves")
+		}
 		total := 0
 		for _, v := range elves[:3] {
 			total += v.caloriesCarrying
 		}
-		return total, elves[:3]
+		return total, elves[:3], nil
 	}
 }
 
-func main() {
-	calories, elves := runChallenge(1)
-	fmt.Println(elves)
-	fmt.Printf("They carry %d calories\n ", calories)
+func init() {
+	aoc.Register(aoc.Puzzle{
+		Year:    2022,
+		Day:     1,
+		Title:   "Calorie Counting",
+		Parts:   2,
+		Example: example,
+		Solver: aoc.SolverFunc(func(ctx context.Context, part int, input string) (any, error) {
+			answer, _, err := runChallenge(part, input)
+			return answer, err
+		}),
+	})
 }
@@ -1,12 +1,22 @@
-package main
+package day1
 
-import "testing"
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rubinda/aoc"
+	"github.com/rubinda/aoc/internal/aoctest"
+)
 
 const expected1 = 24000
 const expected2 = 45000
 
 func TestChallenge1(t *testing.T) {
-	actual, _ := runChallenge(1)
+	actual, _, err := runChallenge(1, example)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if actual != expected1 {
 		t.Errorf("Wrong result! Expected: %d, actual: %d", expected1, actual)
@@ -14,15 +24,53 @@ func TestChallenge1(t *testing.T) {
 }
 
 func TestChallenge2(t *testing.T) {
-	actual, _ := runChallenge(2)
+	actual, _, err := runChallenge(2, example)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if actual != expected2 {
 		t.Errorf("Wrong result! Expected: %d, actual: %d", expected2, actual)
 	}
 }
 
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 2022, 1)
+}
+
+func TestParseError(t *testing.T) {
+	_, _, err := runChallenge(1, "1000\n2000\n\nlots")
+	var parseErr *aoc.ParseError
+	if !errors.As(err, &parseErr) || parseErr.Line != 4 {
+		t.Errorf("Expected parse error on line 4, got: %v", err)
+	}
+}
+
 func BenchmarkChallenge2(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		runChallenge(2)
+		runChallenge(2, example)
 	}
 }
+
+func FuzzParseElves(f *testing.F) {
+	f.Add(example)
+	f.Fuzz(func(t *testing.T, input string) {
+		var elves []Elf
+		if aoctest.Parses(t, func() (err error) {
+			elves, err = parseElves(input)
+			return err
+		}) != nil {
+			return
+		}
+		// Every empty line starts a new elf
+		empty := 0
+		for _, line := range strings.Split(input, "\n") {
+			if line == "" {
+				empty++
+			}
+		}
+		if len(elves) != empty+1 {
+			t.Errorf("Expected %d elves, got %d", empty+1, len(elves))
+		}
+	})
+}
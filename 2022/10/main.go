@@ -1,14 +1,22 @@
-package main
+package day10
 
 import (
+	"context"
 	_ "embed"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
+
+	"github.com/rubinda/aoc"
+	"github.com/rubinda/aoc/internal/tracing"
 )
 
 //go:embed example.in
-var input string
+var example string
+
+// tracer reports the register during cycles 20, 60, 100 and so on, where the signal is measured (see aoc run --trace).
+var tracer = tracing.For(2022, 10)
 
 // Represents a CPU instruction
 const (
@@ -99,7 +107,9 @@ func (cpu *CPU) Execute(op instruction) {
 		cpu.display.DrawPixel(cpu.cycle, cpu.register)
 		cpu.cycle++
 		if cpu.cycle%40 == 20 {
-			// fmt.Printf("R=%d, C=%d \n", cpu.register, cpu.cycle)
+			if tracer.Enabled(tracing.Debug) {
+				tracer.Debug("signal measured", tracing.F("cycle", cpu.cycle), tracing.F("register", cpu.register))
+			}
 			cpu.signalStrengh += cpu.register * cpu.cycle
 		}
 
@@ -130,39 +140,73 @@ type instruction struct {
 }
 
 // parseAssemblyLike creates instructions from the input files.
-func parseAssemblyLike(code string) []instruction {
-	lines := strings.Split(code, "\n")
-	insts := make([]instruction, len(lines))
-
-	for i, line := range lines {
+func parseAssemblyLike(code string) ([]instruction, error) {
+	return aoc.ParseLines(code, func(line string) (instruction, error) {
 		f := strings.Fields(line)
+		if len(f) == 0 {
+			return instruction{}, errors.New("missing command")
+		}
 		cmd := f[0]
+		cycles, ok := executionTimes[cmd]
+		if !ok {
+			return instruction{}, fmt.Errorf("unknown command %q", cmd)
+		}
 		var arg int
-		if len(f) > 1 {
-			arg, _ = strconv.Atoi(f[1])
+		switch {
+		case cmd == addx && len(f) != 2:
+			return instruction{}, errors.New("addx takes a single argument")
+		case cmd == noop && len(f) != 1:
+			return instruction{}, errors.New("noop takes no arguments")
+		case len(f) > 1:
+			var err error
+			if arg, err = strconv.Atoi(f[1]); err != nil {
+				return instruction{}, err
+			}
 		}
-		insts[i] = instruction{
+		return instruction{
 			command:  cmd,
 			argument: arg,
-			cycles:   executionTimes[cmd],
-		}
-	}
-	return insts
+			cycles:   cycles,
+		}, nil
+	})
 }
 
 // runChallenge returns the desired output for the days challenge.
 // May print additional information to stdout.
-func runChallenge() *CPU {
-	instructions := parseAssemblyLike(input)
+func runChallenge(input string) (*CPU, error) {
+	instructions, err := parseAssemblyLike(input)
+	if err != nil {
+		return nil, err
+	}
 	cpu := MakeCPU()
+	// The CRT draws a pixel every cycle, it can't keep up with longer programs
+	cycles := 0
+	for _, op := range instructions {
+		cycles += op.cycles
+	}
+	if screen := cpu.display.width * cpu.display.height; cycles > screen {
+		return nil, fmt.Errorf("program runs for %d cycles, the display only has %d pixels", cycles, screen)
+	}
 	cpu.RunCode(instructions)
-	return cpu
+	return cpu, nil
 }
 
-func main() {
-	cpu := runChallenge()
-	fmt.Println("====Part 1=====")
-	fmt.Println(cpu.signalStrengh)
-	fmt.Println("====Part 2=====")
-	fmt.Println(cpu.display.Output())
+func init() {
+	aoc.Register(aoc.Puzzle{
+		Year:    2022,
+		Day:     10,
+		Title:   "Cathode-Ray Tube",
+		Parts:   2,
+		Example: example,
+		Solver: aoc.SolverFunc(func(ctx context.Context, part int, input string) (any, error) {
+			cpu, err := runChallenge(input)
+			if err != nil {
+				return nil, err
+			}
+			if part == 1 {
+				return cpu.signalStrengh, nil
+			}
+			return cpu.display.Output(), nil
+		}),
+	})
 }
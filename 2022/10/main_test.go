@@ -1,6 +1,10 @@
-package main
+package day10
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/rubinda/aoc/internal/aoctest"
+)
 
 const (
 	expected1 = 13140
@@ -9,7 +13,10 @@ const (
 var expected2 = [][]string{{litPixel, litPixel, darkPixel, darkPixel, litPixel, litPixel, darkPixel, darkPixel, litPixel, litPixel, darkPixel, darkPixel, litPixel, litPixel, darkPixel, darkPixel, litPixel, litPixel, darkPixel, darkPixel, litPixel, litPixel, darkPixel, darkPixel, litPixel, litPixel, darkPixel, darkPixel, litPixel, litPixel, darkPixel, darkPixel, litPixel, litPixel, darkPixel, darkPixel, litPixel, litPixel, darkPixel, darkPixel}, {litPixel, litPixel, litPixel, darkPixel, darkPixel, darkPixel, litPixel, litPixel, litPixel, darkPixel, darkPixel, darkPixel, litPixel, litPixel, litPixel, darkPixel, darkPixel, darkPixel, litPixel, litPixel, litPixel, darkPixel, darkPixel, darkPixel, litPixel, litPixel, litPixel, darkPixel, darkPixel, darkPixel, litPixel, litPixel, litPixel, darkPixel, darkPixel, darkPixel, litPixel, litPixel, litPixel, darkPixel}, {litPixel, litPixel, litPixel, litPixel, darkPixel, darkPixel, darkPixel, darkPixel, litPixel, litPixel, litPixel, litPixel, darkPixel, darkPixel, darkPixel, darkPixel, litPixel, litPixel, litPixel, litPixel, darkPixel, darkPixel, darkPixel, darkPixel, litPixel, litPixel, litPixel, litPixel, darkPixel, darkPixel, darkPixel, darkPixel, litPixel, litPixel, litPixel, litPixel, darkPixel, darkPixel, darkPixel, darkPixel}, {litPixel, litPixel, litPixel, litPixel, litPixel, darkPixel, darkPixel, darkPixel, darkPixel, darkPixel, litPixel, litPixel, litPixel, litPixel, litPixel, darkPixel, darkPixel, darkPixel, darkPixel, darkPixel, litPixel, litPixel, litPixel, litPixel, litPixel, darkPixel, darkPixel, darkPixel, darkPixel, darkPixel, litPixel, litPixel, litPixel, litPixel, litPixel, darkPixel, darkPixel, darkPixel, darkPixel, darkPixel}, {litPixel, litPixel, litPixel, litPixel, litPixel, litPixel, darkPixel, darkPixel, darkPixel, darkPixel, darkPixel, darkPixel, litPixel, litPixel, litPixel, litPixel, litPixel, litPixel, darkPixel, darkPixel, darkPixel, darkPixel, darkPixel, darkPixel, litPixel, litPixel, litPixel, litPixel, litPixel, litPixel, darkPixel, darkPixel, darkPixel, darkPixel, darkPixel, darkPixel, litPixel, litPixel, litPixel, litPixel}, {litPixel, litPixel, litPixel, litPixel, litPixel, litPixel, litPixel, darkPixel, darkPixel, darkPixel, darkPixel, darkPixel, darkPixel, darkPixel, litPixel, litPixel, litPixel, litPixel, litPixel, litPixel, litPixel, darkPixel, darkPixel, darkPixel, darkPixel, darkPixel, darkPixel, darkPixel, litPixel, litPixel, litPixel, litPixel, litPixel, litPixel, litPixel, darkPixel, darkPixel, darkPixel, darkPixel, darkPixel}}
 
 func TestChallenge1(t *testing.T) {
-	cpu := runChallenge()
+	cpu, err := runChallenge(example)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if cpu.signalStrengh != expected1 {
 		t.Errorf("Wrong result! Expected: %v, actual: %v", expected1, cpu.signalStrengh)
@@ -17,7 +24,10 @@ func TestChallenge1(t *testing.T) {
 }
 
 func TestChallenge2(t *testing.T) {
-	cpu := runChallenge()
+	cpu, err := runChallenge(example)
+	if err != nil {
+		t.Fatal(err)
+	}
 	cpuExpected := MakeCPU()
 	cpuExpected.display.screen = expected2
 	for y := 0; y < cpu.display.height; y++ {
@@ -29,8 +39,30 @@ func TestChallenge2(t *testing.T) {
 	}
 }
 
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 2022, 10)
+}
+
 func Benchmark1_2(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		runChallenge()
+		runChallenge(example)
 	}
 }
+
+func FuzzParseAssemblyLike(f *testing.F) {
+	f.Add(example)
+	f.Fuzz(func(t *testing.T, input string) {
+		var instructions []instruction
+		if aoctest.Parses(t, func() (err error) {
+			instructions, err = parseAssemblyLike(input)
+			return err
+		}) != nil {
+			return
+		}
+		for _, inst := range instructions {
+			if inst.cycles != executionTimes[inst.command] || inst.cycles < 1 {
+				t.Errorf("Instruction %+v takes the wrong number of cycles", inst)
+			}
+		}
+	})
+}
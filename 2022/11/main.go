@@ -1,22 +1,19 @@
-package main
+package day11
 
 import (
+	"context"
 	_ "embed"
+	"errors"
 	"fmt"
 	"sort"
 	"strconv"
 	"strings"
+
+	"github.com/rubinda/aoc"
 )
 
 //go:embed example.in
-var input string
-
-// check panics if error is not nil.
-func check(e error) {
-	if e != nil {
-		panic(e)
-	}
-}
+var example string
 
 // add adds given integers.
 func add(a, b int) int {
@@ -78,10 +75,12 @@ type Monkey struct {
 	itemsInspected int
 
 	throwDivisor int
+	// catchers are the monkeys items can be thrown to.
+	catchers [2]int
 }
 
 // SpotMonkey parses a monkey desc into a monkey struct.
-func SpotMonkey(monkeyDesc string) *Monkey {
+func SpotMonkey(monkeyDesc string) (*Monkey, error) {
 	// Use strings.NewReplacer and fmt.Sscanf
 	monkey := &Monkey{}
 	replacer := strings.NewReplacer(", ", ",", "* old", "^ 2")
@@ -96,14 +95,21 @@ func SpotMonkey(monkeyDesc string) *Monkey {
 		 If false: throw to monkey %d`,
 		&monkey.id, &itemArr, &opSign, &opConstant, &monkey.throwDivisor, &catcher1, &catcher2,
 	)
-	check(err)
+	if err != nil {
+		return nil, err
+	}
+	if monkey.throwDivisor <= 0 {
+		return nil, fmt.Errorf("can't test if divisible by %d", monkey.throwDivisor)
+	}
 
 	// Parse integers from string items
 	items := strings.Split(itemArr, ",")
 	monkey.items = make([]int, len(items))
 	for i := range items {
 		monkey.items[i], err = strconv.Atoi(items[i])
-		check(err)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Worry level increase after each inspection
@@ -116,7 +122,7 @@ func SpotMonkey(monkeyDesc string) *Monkey {
 	case "^":
 		mathOp = pow
 	default:
-		panic("Unexpected sign [" + opSign + "] for worry increase function")
+		return nil, fmt.Errorf("unexpected sign [%s] for worry increase function", opSign)
 	}
 	monkey.worryIncrease = func(worryLevel int) int {
 		return mathOp(worryLevel, opConstant)
@@ -129,8 +135,9 @@ func SpotMonkey(monkeyDesc string) *Monkey {
 		}
 		return catcher2
 	}
+	monkey.catchers = [2]int{catcher1, catcher2}
 
-	return monkey
+	return monkey, nil
 }
 
 // InspectItem causes monkey to inspect the first item in its possesion increasing worry level for that item.
@@ -154,22 +161,40 @@ func (m *Monkey) ThrowFirstItem(recipient *Monkey) {
 }
 
 // ParseInput takes an input string (monkey description) and converts it to objects.
-func ParseInput(inputDesc string) ([]*Monkey, []int) {
-	descriptions := strings.Split(input, "\n\n")
-	monkeys := make([]*Monkey, len(descriptions))
-	divisors := make([]int, len(descriptions))
-	for _, desc := range descriptions {
-		monkey := SpotMonkey(desc)
+func ParseInput(inputDesc string) ([]*Monkey, []int, error) {
+	spotted, err := aoc.ParseBlocks(inputDesc, SpotMonkey)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(spotted) < 2 {
+		return nil, nil, errors.New("monkey business needs at least 2 monkeys")
+	}
+	monkeys := make([]*Monkey, len(spotted))
+	divisors := make([]int, len(spotted))
+	for _, monkey := range spotted {
+		if monkey.id < 0 || monkey.id >= len(monkeys) || monkeys[monkey.id] != nil {
+			return nil, nil, fmt.Errorf("monkey %d is out of order", monkey.id)
+		}
 		monkeys[monkey.id] = monkey
 		divisors[monkey.id] = monkey.throwDivisor
 	}
-	return monkeys, divisors
+	for _, monkey := range monkeys {
+		for _, catcher := range monkey.catchers {
+			if catcher < 0 || catcher >= len(monkeys) || catcher == monkey.id {
+				return nil, nil, fmt.Errorf("monkey %d can't throw to monkey %d", monkey.id, catcher)
+			}
+		}
+	}
+	return monkeys, divisors, nil
 }
 
 // runChallenge returns the desired output for the days challenge.
 // May print additional information to stdout.
-func runChallenge(challengePart int) int {
-	monkeys, divisors := ParseInput(input)
+func runChallenge(ctx context.Context, challengePart int, input string) (int, error) {
+	monkeys, divisors, err := ParseInput(input)
+	if err != nil {
+		return 0, err
+	}
 	// Part 1 related
 	reduceFunc := func(worryLevel int) int {
 		return worryLevel / 3
@@ -184,6 +209,9 @@ func runChallenge(challengePart int) int {
 	}
 
 	for i := 0; i < rounds; i++ {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
 		for _, monkey := range monkeys {
 			for range monkey.items {
 				monkey.InspectItem(reduceFunc)
@@ -193,10 +221,18 @@ func runChallenge(challengePart int) int {
 		}
 	}
 	sort.Slice(monkeys, func(i, j int) bool { return monkeys[i].itemsInspected > monkeys[j].itemsInspected })
-	return monkeys[0].itemsInspected * monkeys[1].itemsInspected
+	return monkeys[0].itemsInspected * monkeys[1].itemsInspected, nil
 }
 
-func main() {
-	fmt.Printf("==== Part 1 ==== \n%d\n", runChallenge(1))
-	fmt.Printf("==== Part 2 ==== \n%d\n", runChallenge(2))
+func init() {
+	aoc.Register(aoc.Puzzle{
+		Year:    2022,
+		Day:     11,
+		Title:   "Monkey in the Middle",
+		Parts:   2,
+		Example: example,
+		Solver: aoc.SolverFunc(func(ctx context.Context, part int, input string) (any, error) {
+			return runChallenge(ctx, part, input)
+		}),
+	})
 }
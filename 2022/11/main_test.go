@@ -1,7 +1,10 @@
-package main
+package day11
 
 import (
+	"context"
 	"testing"
+
+	"github.com/rubinda/aoc/internal/aoctest"
 )
 
 const (
@@ -10,7 +13,10 @@ const (
 )
 
 func TestChallenge1(t *testing.T) {
-	actual := runChallenge(1)
+	actual, err := runChallenge(context.Background(), 1, example)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if actual != expected1 {
 		t.Errorf("Wrong result! Expected: %v, actual: %v", expected1, actual)
@@ -18,20 +24,54 @@ func TestChallenge1(t *testing.T) {
 }
 
 func TestChallenge2(t *testing.T) {
-	actual := runChallenge(2)
+	actual, err := runChallenge(context.Background(), 2, example)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if actual != expected2 {
 		t.Errorf("Wrong result! Expected: %v, actual: %v", expected2, actual)
 	}
 }
 
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 2022, 11)
+}
+
 func Benchmark1(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		runChallenge(1)
+		runChallenge(context.Background(), 1, example)
 	}
 }
 func Benchmark2(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		runChallenge(2)
+		runChallenge(context.Background(), 2, example)
 	}
 }
+
+func FuzzParseInput(f *testing.F) {
+	f.Add(example)
+	f.Fuzz(func(t *testing.T, input string) {
+		var monkeys []*Monkey
+		var divisors []int
+		if aoctest.Parses(t, func() (err error) {
+			monkeys, divisors, err = ParseInput(input)
+			return err
+		}) != nil {
+			return
+		}
+		if len(monkeys) != len(divisors) {
+			t.Fatalf("%d monkeys with %d divisors", len(monkeys), len(divisors))
+		}
+		for i, m := range monkeys {
+			if m.id != i || divisors[i] < 1 {
+				t.Errorf("Monkey %d has id %d and divisor %d", i, m.id, divisors[i])
+			}
+			for _, worry := range []int{0, m.throwDivisor} {
+				if catcher := m.throwRecipient(worry); catcher < 0 || catcher >= len(monkeys) || catcher == i {
+					t.Errorf("Monkey %d throws to monkey %d", i, catcher)
+				}
+			}
+		}
+	})
+}
@@ -1,10 +1,15 @@
-package main
+package day12
 
 import (
+	"context"
 	_ "embed"
+	"errors"
 	"fmt"
-	"math"
 	"strings"
+
+	"github.com/rubinda/aoc"
+	"github.com/rubinda/aoc/internal/graph"
+	"github.com/rubinda/aoc/internal/grid"
 )
 
 const (
@@ -24,136 +29,108 @@ func convertToWeight(heightMarker string) int {
 }
 
 // canTravel returns true if Edge from source -> destionation exists.
-func canTravel(source, destination *Node, challengePart int) bool {
+func canTravel(source, destination string, challengePart int) bool {
 	if challengePart == 2 {
-		return float64(source.Weight)-float64(destination.Weight) <= 1
-	}
-	return float64(source.Weight)-float64(destination.Weight) >= -1
-}
-
-// ensureNode creates a new Node for given point if it doesn't exist yet.
-func ensureNode(g *Graph, nodes map[Point]*Node, p Point, heightMarker string) {
-	if _, found := nodes[p]; !found {
-		n := &Node{HeightMarker: heightMarker, Weight: convertToWeight(heightMarker), Coordinates: p}
-		nodes[p] = n
-		g.AddNode(n)
+		return convertToWeight(source)-convertToWeight(destination) <= 1
 	}
+	return convertToWeight(source)-convertToWeight(destination) >= -1
 }
 
 // CreateGraph instantiates a graph from challenge data.
-func CreateGraph(data [][]string, challengePart int) (graph *Graph, startNode, endNode *Node) {
-	graph = NewGraph()
-	height := len(data)
-	width := len(data[0])
-	nodes := make(map[Point]*Node)
-	for y, row := range data {
-		for x, heightMarker := range row {
-			p := Point{x, y}
-			ensureNode(graph, nodes, p, heightMarker)
-			for _, neighbour := range p.Neighbours(width, height) {
-				ensureNode(graph, nodes, neighbour, data[neighbour.y][neighbour.x])
-				if canTravel(nodes[p], nodes[neighbour], challengePart) {
-					graph.AddEdge(nodes[p], nodes[neighbour], 1)
-				}
+// In part 2 the edges are reversed so the search can start from the end.
+func CreateGraph(dem *grid.Grid[string], challengePart int) (g *graph.Adjacency[grid.Point], start, end grid.Point) {
+	g = graph.NewAdjacency[grid.Point]()
+	dem.Each(func(p grid.Point, heightMarker string) {
+		g.AddNode(p)
+		for _, neighbour := range dem.Neighbours(p, grid.Directions4) {
+			if canTravel(heightMarker, dem.Get(neighbour), challengePart) {
+				g.AddEdge(p, neighbour, 1)
 			}
-			if heightMarker == startingMarker {
-				startNode = nodes[p]
-			} else if heightMarker == endMarker {
-				endNode = nodes[p]
-			}
-		}
-	}
-	return
-}
-
-// ShortestPath find the shortest path between source and sink (Dijkstra).
-// Returns pathing map and distance map.
-func ShortestPath(source *Node, sink *Node, g *Graph) (map[*Node]*Node, int, map[*Node]int) {
-	visited := make(map[*Node]bool)
-	dist := make(map[*Node]int)
-	prev := make(map[*Node]*Node)
-
-	pq := NewMinimumPriorityQueue()
-	start := Vertex{
-		Node:     source,
-		Priority: 0,
-	}
-	for _, n := range g.Nodes {
-		dist[n] = math.MaxInt64
-	}
-	dist[source] = start.Priority
-
-	pq.Enqueue(start)
-	for !pq.IsEmpty() {
-		v := pq.Dequeue()
-
-		if visited[v.Node] {
-			continue
 		}
-		visited[v.Node] = true
-
-		edges := g.Edges[v.Node]
-
-		for _, edge := range edges {
-			currentDistance := dist[v.Node] + edge.Weight
-			if currentDistance < dist[edge.Node] {
-				dist[edge.Node] = currentDistance
-				prev[edge.Node] = v.Node
-				closer := Vertex{
-					Node:     edge.Node,
-					Priority: dist[edge.Node],
-				}
-				pq.Enqueue(closer)
-			}
-
+		if heightMarker == startingMarker {
+			start = p
+		} else if heightMarker == endMarker {
+			end = p
 		}
-	}
-	return prev, dist[sink], dist
+	})
+	return
 }
 
 //go:embed example.in
-var input string
+var example string
 
 // parseInput reads the input string and returns DEM-like grid.
-func parseInput() [][]string {
+func parseInput(input string) (*grid.Grid[string], error) {
+	// Shorter lines would leave holes in the map
 	lines := strings.Split(input, "\n")
-	dem := make([][]string, len(lines))
 	for y, line := range lines {
-		dem[y] = make([]string, len(line))
-		for x, c := range line {
-			dem[y][x] = string(c)
+		if len(line) != len(lines[0]) {
+			return nil, aoc.LineError(y, line, fmt.Errorf("expected %d heights in a line, got %d", len(lines[0]), len(line)))
+		}
+	}
+	starts, ends := 0, 0
+	dem, err := grid.Parse(input, func(_ grid.Point, r rune) (string, error) {
+		heightMarker := string(r)
+		switch {
+		case heightMarker == startingMarker:
+			starts++
+		case heightMarker == endMarker:
+			ends++
+		case r < 'a' || r > 'z':
+			return "", fmt.Errorf("unknown height marker %q", r)
 		}
+		return heightMarker, nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	return dem
+	if starts != 1 || ends != 1 {
+		return nil, errors.New("heightmap needs exactly one start and one end")
+	}
+	return dem, nil
 }
 
 // runChallenge returns the desired output for the day's challenge.
-func runChallenge(challengePart int) int {
-	result := -1
-	data := parseInput()
-	graph, startNode, endNode := CreateGraph(data, challengePart)
+func runChallenge(ctx context.Context, challengePart int, input string) (int, error) {
+	dem, err := parseInput(input)
+	if err != nil {
+		return 0, err
+	}
+	g, start, end := CreateGraph(dem, challengePart)
+	var search *graph.Result[grid.Point]
 	if challengePart == 1 {
-		_, result, _ = ShortestPath(startNode, endNode, graph)
-	} else if challengePart == 2 {
-		startNode = endNode
-		_, _, distances := ShortestPath(endNode, nil, graph)
-		// Find which Node that fulfills elevation requirement is closest to endNode
-		var closest *Node
+		search, err = graph.Dijkstra[grid.Point](ctx, g, func(p grid.Point) bool {
+			return p == end
+		}, start)
+	} else {
+		// Find which point that fulfills elevation requirement is closest to the end
 		wantedElevation := convertToWeight("a")
-		for _, n := range graph.Nodes {
-			if n.Weight != wantedElevation {
-				continue
-			}
-			if closest == nil || distances[n] < distances[closest] {
-				closest = n
-			}
+		search, err = graph.Dijkstra[grid.Point](ctx, g, func(p grid.Point) bool {
+			return convertToWeight(dem.Get(p)) == wantedElevation
+		}, end)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if !search.Found {
+		if challengePart == 1 {
+			return 0, errors.New("no path from the start to the best signal")
 		}
-		result = distances[closest]
+		return 0, errors.New("no path from elevation a to the best signal")
 	}
-
-	return result
+	distance, _ := search.Distance(search.Goal)
+	return distance, nil
 }
 
-func main() {
-	fmt.Println(runChallenge(2))
+func init() {
+	aoc.Register(aoc.Puzzle{
+		Year:    2022,
+		Day:     12,
+		Title:   "Hill Climbing Algorithm",
+		Parts:   2,
+		Example: example,
+		Solver: aoc.SolverFunc(func(ctx context.Context, part int, input string) (any, error) {
+			return runChallenge(ctx, part, input)
+		}),
+	})
 }
@@ -1,7 +1,14 @@
-package main
+package day12
 
 import (
+	"context"
+	"math/rand"
+	"strings"
 	"testing"
+
+	"github.com/rubinda/aoc/internal/aoctest"
+	"github.com/rubinda/aoc/internal/graph"
+	"github.com/rubinda/aoc/internal/grid"
 )
 
 const (
@@ -10,7 +17,10 @@ const (
 )
 
 func TestChallenge1(t *testing.T) {
-	actual := runChallenge(1)
+	actual, err := runChallenge(context.Background(), 1, example)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if actual != expected1 {
 		t.Errorf("Wrong result! Expected: %v, actual: %v", expected1, actual)
@@ -18,20 +28,112 @@ func TestChallenge1(t *testing.T) {
 }
 
 func TestChallenge2(t *testing.T) {
-	actual := runChallenge(2)
+	actual, err := runChallenge(context.Background(), 2, example)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if actual != expected2 {
 		t.Errorf("Wrong result! Expected: %v, actual: %v", expected2, actual)
 	}
 }
 
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 2022, 12)
+}
+
 func Benchmark1(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		runChallenge(1)
+		runChallenge(context.Background(), 1, example)
 	}
 }
 func Benchmark2(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		runChallenge(2)
+		runChallenge(context.Background(), 2, example)
+	}
+}
+
+// generateHeightmap returns a deterministic w*h heightmap with the start in the upper left and the end in
+// the lower right corner. Elevation slowly rises towards the end, shallow random dips keep the search from being trivial.
+func generateHeightmap(w, h int) string {
+	r := rand.New(rand.NewSource(12))
+	var sb strings.Builder
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			elevation := (x + y) * 26 / (w + h)
+			if r.Intn(8) == 0 && elevation > 0 {
+				elevation--
+			}
+			switch {
+			case x == 0 && y == 0:
+				sb.WriteString(startingMarker)
+			case x == w-1 && y == h-1:
+				sb.WriteString(endMarker)
+			default:
+				sb.WriteByte(byte('a' + elevation))
+			}
+		}
+		if y < h-1 {
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
+
+func BenchmarkLargeHeightmap1(b *testing.B) {
+	heightmap := generateHeightmap(200, 200)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		runChallenge(context.Background(), 1, heightmap)
+	}
+}
+
+func BenchmarkLargeHeightmap2(b *testing.B) {
+	heightmap := generateHeightmap(200, 200)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		runChallenge(context.Background(), 2, heightmap)
+	}
+}
+
+func BenchmarkLargeShortestPath(b *testing.B) {
+	dem, err := parseInput(generateHeightmap(500, 500))
+	if err != nil {
+		b.Fatal(err)
+	}
+	g, start, end := CreateGraph(dem, 1)
+	isEnd := func(p grid.Point) bool { return p == end }
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		graph.Dijkstra[grid.Point](context.Background(), g, isEnd, start)
+	}
+}
+
+func FuzzParseInput(f *testing.F) {
+	f.Add(example)
+	f.Fuzz(func(t *testing.T, input string) {
+		var dem *grid.Grid[string]
+		if aoctest.Parses(t, func() (err error) {
+			dem, err = parseInput(input)
+			return err
+		}) != nil {
+			return
+		}
+		markers := map[string]int{}
+		dem.Each(func(_ grid.Point, marker string) {
+			markers[marker]++
+		})
+		if markers[startingMarker] != 1 || markers[endMarker] != 1 {
+			t.Errorf("Heightmap with %d starts and %d ends", markers[startingMarker], markers[endMarker])
+		}
+	})
+}
+
+func TestNoPath(t *testing.T) {
+	// The best signal is too high to climb onto
+	for part := 1; part <= 2; part++ {
+		if actual, err := runChallenge(context.Background(), part, "SaE"); err == nil {
+			t.Errorf("Part %d: expected an error without a path, got %v", part, actual)
+		}
 	}
 }
@@ -1,12 +1,17 @@
-package main
+package day13
 
 import (
+	"context"
 	_ "embed"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sort"
 	"strconv"
 	"strings"
+
+	"github.com/rubinda/aoc"
+	"github.com/rubinda/aoc/internal/tracing"
 )
 
 // Represents compare function results.
@@ -21,6 +26,9 @@ const (
 	specialPacket2 = "[[6]]"
 )
 
+// tracer reports the packets compared and their sorted order (see aoc run --trace).
+var tracer = tracing.For(2022, 13)
+
 // comparisonString converts numeric comparison result to text.
 var comparisonString = map[int]string{
 	Equal:       "CONT",
@@ -28,19 +36,14 @@ var comparisonString = map[int]string{
 	GreaterThan: "FAIL",
 }
 
-// check panics if error is not nil
-func check(err error) {
-	if err != nil {
-		panic(err)
-	}
-}
-
 // messageItem represents an item in a message (hack to get array of strings from JSON unmarshal regardless of item type).
 type messageItem string
 
 // UnmarshalJSON provides a method to convert JSON data to messgeItem type.
 func (m *messageItem) UnmarshalJSON(data []byte) error {
 	if n := len(data); n > 1 && data[0] == '"' && data[n-1] == '"' {
+		// Strings aren't part of the packet format, they're rejected in checkPacket
+
 		return json.Unmarshal(data, (*string)(m))
 	}
 	*m = messageItem(data)
@@ -48,92 +51,150 @@ func (m *messageItem) UnmarshalJSON(data []byte) error {
 }
 
 func (m messageItem) containsArray() bool {
-	return string(m[0]) == "["
+	return len(m) > 0 && m[0] == '['
 }
 
 // compareAsInt tries to convert message items to integers before comparison.
-func compareAsInt(left, right messageItem) int {
+func compareAsInt(left, right messageItem) (int, error) {
 	aI, err := strconv.Atoi(string(left))
-	check(err)
+	if err != nil {
+		return Equal, err
+	}
 	bI, err := strconv.Atoi(string(right))
-	check(err)
+	if err != nil {
+		return Equal, err
+	}
 	if aI > bI {
-		return GreaterThan
+		return GreaterThan, nil
 	} else if aI < bI {
-		return LessThan
+		return LessThan, nil
 	}
-	return Equal
+	return Equal, nil
 }
 
 // arrayFrom parses one level of an array described in a valid JSON string
 // e.g. "[[1, 2, 3], 0, [4, 5]]" => ["[1, 2, 3]", "0", "[4, 5]"]
 // e.g. "[[[4], 5, 6], 9]" => ["[[4], 5, 6]", "9"]
-func arrayFrom(arrayDesc string) []messageItem {
+func arrayFrom(arrayDesc string) ([]messageItem, error) {
 	if _, err := strconv.Atoi(arrayDesc); err == nil {
 		mi := messageItem(arrayDesc)
-		return []messageItem{mi}
+		return []messageItem{mi}, nil
 	}
 	var a []messageItem
-	err := json.Unmarshal([]byte(arrayDesc), &a)
+	if err := json.Unmarshal([]byte(arrayDesc), &a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// checkPacket makes sure a packet only holds lists and integers.
+func checkPacket(packet string) error {
+	if !strings.HasPrefix(packet, "[") {
+		return errors.New("packets have to be lists")
+	}
+	items, err := arrayFrom(packet)
 	if err != nil {
-		panic(err)
+		return err
 	}
-	return a
+	for _, item := range items {
+		if item.containsArray() {
+			if err := checkPacket(string(item)); err != nil {
+				return err
+			}
+		} else if _, err := strconv.Atoi(string(item)); err != nil {
+			return fmt.Errorf("%s is not an integer", item)
+		}
+	}
+	return nil
 }
 
 // Compare returns the order of 2 packets from the challenge input.
-func Compare(packet1, packet2 string) int {
-	left := arrayFrom(packet1)
-	right := arrayFrom(packet2)
+func Compare(packet1, packet2 string) (int, error) {
+	left, err := arrayFrom(packet1)
+	if err != nil {
+		return Equal, err
+	}
+	right, err := arrayFrom(packet2)
+	if err != nil {
+		return Equal, err
+	}
 	// Current item in left and right array
 	i := 0
 	for i < len(left) && i < len(right) {
-		// fmt.Printf(">Comparing {%v} vs {%v} \n", left[i], right[i])
+		if tracer.Enabled(tracing.Debug) {
+			tracer.Debug("comparing packets", tracing.F("left", string(left[i])), tracing.F("right", string(right[i])))
+		}
 		if left[i].containsArray() || right[i].containsArray() {
-			// fmt.Printf(">>Going recursive on %v and %v \n", left[i], right[i])
-			result := Compare(string(left[i]), string(right[i]))
-			// fmt.Printf(">>  %v \n", comparisonString[result])
+			result, err := Compare(string(left[i]), string(right[i]))
+			if err != nil {
+				return Equal, err
+			}
 			if result != Equal {
-				// fmt.Println(">>>Returning from recursive ", result, EQUAL)
-				return result
+				return result, nil
 			}
-			// fmt.Println(">>>Recursion says continue ", left[i], right[i], len(left), len(right))
 		} else {
-			if r := compareAsInt(left[i], right[i]); r != Equal {
-				// fmt.Printf("[%s] because %s", comparisonString[r], string(left[i]))
-				return r
+			r, err := compareAsInt(left[i], right[i])
+			if err != nil {
+				return Equal, err
+			}
+			if r != Equal {
+				if tracer.Enabled(tracing.Debug) {
+					tracer.Debug("packets ordered", tracing.F("result", comparisonString[r]), tracing.F("left", string(left[i])), tracing.F("right", string(right[i])))
+				}
+				return r, nil
 			}
 		}
 		i++
 	}
 	// No comparison between characters was able to resolve order and both reached end of list
 	if i == len(left) && i == len(right) {
-		return Equal
+		return Equal, nil
 	}
 	// Left reached end of list (but right didn't beacuse it would have already returned)
 	if i == len(left) {
-		return LessThan
+		return LessThan, nil
 	}
 	// Right reached end of list and left didn't
-	return GreaterThan
+	return GreaterThan, nil
+}
+
+// parsePacketPairs reads pairs of packets separated by an empty line.
+func parsePacketPairs(input string) ([][2]string, error) {
+	return aoc.ParseBlocks(input, func(doubleLine string) ([2]string, error) {
+		pair := strings.Split(doubleLine, "\n")
+		if len(pair) != 2 {
+			return [2]string{}, fmt.Errorf("expected a pair of packets, got %d", len(pair))
+		}
+		for _, packet := range pair {
+			if err := checkPacket(packet); err != nil {
+				return [2]string{}, fmt.Errorf("packet %s: %w", packet, err)
+			}
+		}
+		return [2]string{pair[0], pair[1]}, nil
+	})
 }
 
 //go:embed example.in
-var input string
+var example string
 
 // runChallenge returns the desired output for the day's challenge.
-func runChallenge(challengePart int) int {
-	packetPairs := strings.Split(input, "\n\n")
+func runChallenge(challengePart int, input string) (int, error) {
+	packetPairs, err := parsePacketPairs(input)
+	if err != nil {
+		return 0, err
+	}
 	packets := make([]string, len(packetPairs)*2)
 
 	alreadySortedPairs := 0
-	for i, doubleLine := range packetPairs {
-		pair := strings.Split(doubleLine, "\n")
-		// fmt.Printf("==== Pair %d ==== \n", i+1)
-		// fmt.Printf("0: %v \n", pair[0])
-		// fmt.Printf("1: %v \n", pair[1])
+	for i, pair := range packetPairs {
 		if challengePart == 1 {
-			result := Compare(pair[0], pair[1])
+			result, err := Compare(pair[0], pair[1])
+			if err != nil {
+				return 0, err
+			}
+			if tracer.Enabled(tracing.Debug) {
+				tracer.Debug("pair compared", tracing.F("pair", i+1), tracing.F("result", comparisonString[result]), tracing.F("left", pair[0]), tracing.F("right", pair[1]))
+			}
 			if result == LessThan {
 				alreadySortedPairs += i + 1
 			}
@@ -142,17 +203,18 @@ func runChallenge(challengePart int) int {
 		packets[i*2+1] = pair[1]
 	}
 	if challengePart == 1 {
-		return alreadySortedPairs
+		return alreadySortedPairs, nil
 	}
 	if challengePart == 2 {
 		packets = append(packets, specialPacket1, specialPacket2)
+		// Packets were checked while parsing, so comparing them can't fail
 		sort.Slice(packets, func(i, j int) bool {
-			return Compare(packets[i], packets[j]) == LessThan
+			result, _ := Compare(packets[i], packets[j])
+			return result == LessThan
 		})
 		// Find positions of special packets (starting count with 1)
 		packet1 := -1
 		packet2 := -1
-		// fmt.Printf("==== SORTED PACKETS (ascending) ====\n")
 		for i, s := range packets {
 			if s == specialPacket1 {
 				packet1 = i + 1
@@ -160,13 +222,25 @@ func runChallenge(challengePart int) int {
 				packet2 = i + 1
 				break
 			}
-			// fmt.Println(s)
+			if tracer.Enabled(tracing.Debug) {
+				tracer.Debug("sorted packet", tracing.F("position", i+1), tracing.F("packet", s))
+			}
 		}
-		return packet1 * packet2
+		tracer.Info("divider packets found", tracing.F("first", packet1), tracing.F("second", packet2))
+		return packet1 * packet2, nil
 	}
-	return -1
+	return -1, nil
 }
 
-func main() {
-	fmt.Println(runChallenge(1))
+func init() {
+	aoc.Register(aoc.Puzzle{
+		Year:    2022,
+		Day:     13,
+		Title:   "Distress Signal",
+		Parts:   2,
+		Example: example,
+		Solver: aoc.SolverFunc(func(ctx context.Context, part int, input string) (any, error) {
+			return runChallenge(part, input)
+		}),
+	})
 }
@@ -1,7 +1,9 @@
-package main
+package day13
 
 import (
 	"testing"
+
+	"github.com/rubinda/aoc/internal/aoctest"
 )
 
 const (
@@ -10,7 +12,10 @@ const (
 )
 
 func TestChallenge1(t *testing.T) {
-	actual := runChallenge(1)
+	actual, err := runChallenge(1, example)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if actual != expected1 {
 		t.Errorf("Wrong result! Expected: %v, actual: %v", expected1, actual)
@@ -18,20 +23,54 @@ func TestChallenge1(t *testing.T) {
 }
 
 func TestChallenge2(t *testing.T) {
-	actual := runChallenge(2)
+	actual, err := runChallenge(2, example)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if actual != expected2 {
 		t.Errorf("Wrong result! Expected: %v, actual: %v", expected2, actual)
 	}
 }
 
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 2022, 13)
+}
+
 func Benchmark1(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		runChallenge(1)
+		runChallenge(1, example)
 	}
 }
 func Benchmark2(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		runChallenge(2)
+		runChallenge(2, example)
 	}
 }
+
+func FuzzParsePacketPairs(f *testing.F) {
+	f.Add(example)
+	f.Fuzz(func(t *testing.T, input string) {
+		var pairs [][2]string
+		if aoctest.Parses(t, func() (err error) {
+			pairs, err = parsePacketPairs(input)
+			return err
+		}) != nil {
+			return
+		}
+		// Checked packets always compare, in both orders with opposite results
+		for _, pair := range pairs {
+			order, err := Compare(pair[0], pair[1])
+			if err != nil {
+				t.Fatal(err)
+			}
+			reverse, err := Compare(pair[1], pair[0])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if order != -reverse {
+				t.Errorf("Comparing %s and %s gives %d, the other way %d", pair[0], pair[1], order, reverse)
+			}
+		}
+	})
+}
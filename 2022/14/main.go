@@ -1,18 +1,29 @@
-package main
+package day14
 
 import (
+	"context"
 	_ "embed"
+	"errors"
 	"fmt"
+	"image"
+	"image/color"
 	"strconv"
 	"strings"
+
+	"github.com/rubinda/aoc"
+	"github.com/rubinda/aoc/internal/grid"
+	"github.com/rubinda/aoc/internal/tracing"
+	"github.com/rubinda/aoc/internal/viz"
 )
 
 var (
 	//go:embed example.in
-	input string
+	example string
+	// tracer reports the grains of sand settled and the final sandbox (see aoc run --trace).
+	tracer = tracing.For(2022, 14)
 
-	// SandSource is the point where sand starts flowing in. NEEDS OFFSET!
-	SandSource = Point{500, 0}
+	// SandSource is the point where sand starts flowing in.
+	SandSource = grid.Point{X: 500, Y: 0}
 	// MaterialSymbols contains string representations of materials that can occupy a space.
 	MaterialSymbols = map[int]string{
 		Void:       ".",
@@ -20,15 +31,22 @@ var (
 		Rock:       "#",
 		Sand:       "*",
 	}
+	// MaterialColors contains the colors of materials in a visualization.
+	MaterialColors = color.Palette{
+		Void:       color.RGBA{0x1a, 0x1a, 0x2e, 0xff},
+		SandSupply: color.RGBA{0xe9, 0x45, 0x60, 0xff},
+		Rock:       color.RGBA{0x80, 0x80, 0x80, 0xff},
+		Sand:       color.RGBA{0xf4, 0xd3, 0x5e, 0xff},
+	}
+	// recorder records the sandbox after every grain of sand (see aoc viz).
+	recorder = viz.For(2022, 14, MaterialColors)
 )
 
-// Relative directions based on (0, 0) being the upper left corner.
-var (
-	relativeDownRight  = Point{x: 1, y: 1}
-	relativeDown       = Point{x: 0, y: 1}
-	relativeDownLeft   = Point{x: -1, y: 1}
-	sandFlowDirections = []Point{relativeDown, relativeDownLeft, relativeDownRight}
-)
+// maxCoordinate limits the wall coordinates, so far away walls don't make a sandbox that exhausts the memory.
+const maxCoordinate = 1000
+
+// sandFlowDirections are the moves a grain of sand tries in order.
+var sandFlowDirections = []grid.Point{grid.Down, grid.DownLeft, grid.DownRight}
 
 // Represents possible type of Material occupying a point in space.
 const (
@@ -38,51 +56,37 @@ const (
 	Sand
 )
 
-// Point represents coordinates.
-type Point struct {
-	x int
-	y int
-}
-
-// add sums coordinates of points.
-func (p Point) add(d Point) Point {
-	return Point{p.x + d.x, p.y + d.y}
-}
-
-// check panics on non nil error.
-func check(err error) {
-	if err != nil {
-		panic(err)
-	}
-}
-
 // Sandbox holds space which can be filled with material.
 type Sandbox struct {
-	space      [][]int
+	space      *grid.Grid[int]
 	hasBottom  bool
 	bottom     int
-	width      int
-	offsetX    int
-	sandSource Point
+	sandSource grid.Point
 }
 
 // isOccupied returns true if given point is already occupied with non-Void material.
-func (s *Sandbox) isOccupied(p Point) bool {
-	return s.space[p.y][p.x] != Void
+func (s *Sandbox) isOccupied(p grid.Point) bool {
+	return s.space.Get(p) != Void
+}
+
+// onEdge returns true if the point lies on the border of the sandbox.
+func (s *Sandbox) onEdge(p grid.Point) bool {
+	bounds := s.space.Bounds()
+	return p.X == bounds.Min.X || p.Y == bounds.Min.Y || p.X == bounds.Max.X || p.Y == bounds.Max.Y
 }
 
 // moveGrainOfSand tries to move a sandcorn one space down
-func (s *Sandbox) moveGrainOfSand(currentPos Point) (Point, bool) {
-	if currentPos.y+1 == len(s.space) {
+func (s *Sandbox) moveGrainOfSand(currentPos grid.Point) (grid.Point, bool) {
+	if currentPos.Y == s.space.Bounds().Max.Y {
 		return currentPos, false
 	}
 
 	for _, dir := range sandFlowDirections {
-		newPos := currentPos.add(dir)
+		newPos := currentPos.Add(dir)
 		if !s.isOccupied(newPos) {
 			return newPos, true
 		}
-		if newPos.x-1 < 0 || newPos.y-1 < 0 || newPos.x+1 == len(s.space[0]) || newPos.y+1 == len(s.space) {
+		if s.onEdge(newPos) {
 			return currentPos, false
 		}
 	}
@@ -93,50 +97,49 @@ func (s *Sandbox) moveGrainOfSand(currentPos Point) (Point, bool) {
 func (s *Sandbox) SpawnGrainOfSand() bool {
 	sandPos, canMove := s.moveGrainOfSand(s.sandSource)
 	if !canMove {
-		fmt.Println("STOP! Can't spawn more sand, source is blocked!")
+		// Can't spawn more sand, source is blocked!
 		return false
 	}
 
 	for canMove {
 		sandPos, canMove = s.moveGrainOfSand(sandPos)
 	}
-	if !s.hasBottom && sandPos.y == s.bottom {
+	if !s.hasBottom && sandPos.Y == s.bottom {
 		// Sand reached the bottom of the void!
-		fmt.Println("STOP! Sand started flowing onto bottom")
 		return false
 	}
 	if !canMove {
 		// Sand has settled
-		s.space[sandPos.y][sandPos.x] = Sand
+		s.space.Set(sandPos, Sand)
 		return true
 	}
 	return false
 }
 
 // DrawWall occupies space from given extremes in a straight line. Panics if non straight line given.
-func (s *Sandbox) DrawWall(wallStart, wallEnd Point) {
-	if wallStart.x == wallEnd.x {
+func (s *Sandbox) DrawWall(wallStart, wallEnd grid.Point) {
+	if wallStart.X == wallEnd.X {
 		// vertical wall on y
-		if wallStart.y < wallEnd.y {
-			for y := wallStart.y; y <= wallEnd.y; y++ {
-				s.space[y][wallStart.x] = Rock
+		if wallStart.Y < wallEnd.Y {
+			for y := wallStart.Y; y <= wallEnd.Y; y++ {
+				s.space.Set(grid.Point{X: wallStart.X, Y: y}, Rock)
 			}
 		} else {
-			for wallStart.y >= wallEnd.y {
-				s.space[wallStart.y][wallStart.x] = Rock
-				wallStart.y--
+			for wallStart.Y >= wallEnd.Y {
+				s.space.Set(wallStart, Rock)
+				wallStart.Y--
 			}
 		}
-	} else if wallStart.y == wallEnd.y {
+	} else if wallStart.Y == wallEnd.Y {
 		// horizontal wall on x
-		if wallStart.x < wallEnd.x {
-			for x := wallStart.x; x <= wallEnd.x; x++ {
-				s.space[wallStart.y][x] = Rock
+		if wallStart.X < wallEnd.X {
+			for x := wallStart.X; x <= wallEnd.X; x++ {
+				s.space.Set(grid.Point{X: x, Y: wallStart.Y}, Rock)
 			}
 		} else {
-			for wallStart.x >= wallEnd.x {
-				s.space[wallStart.y][wallStart.x] = Rock
-				wallStart.x--
+			for wallStart.X >= wallEnd.X {
+				s.space.Set(wallStart, Rock)
+				wallStart.X--
 			}
 		}
 	} else {
@@ -146,111 +149,157 @@ func (s *Sandbox) DrawWall(wallStart, wallEnd Point) {
 
 // Output formats the sandbox into ASCII art.
 func (s *Sandbox) Output() string {
-	output := ""
-	for y := range s.space {
-		for x := range s.space[y] {
-			output += MaterialSymbols[s.space[y][x]]
-		}
-		output += "\n"
-	}
-	return output
+	return s.space.Render(func(_ grid.Point, material int) string {
+		return MaterialSymbols[material]
+	})
+}
+
+// Frame draws the sandbox with a color per material.
+func (s *Sandbox) Frame() *image.Paletted {
+	return viz.GridFrame(s.space, MaterialColors, func(_ grid.Point, material int) uint8 {
+		return uint8(material)
+	})
 }
 
-// praseWallEdge returns coordinates from comma delimited value (e.g. "498,6" -> Point{498, 6, Rock}).
-func parseWallEdge(wallEdgeDesc string) Point {
-	coords := strings.Split(wallEdgeDesc, ",")
-	x, err := strconv.Atoi(coords[0])
-	check(err)
-	y, err := strconv.Atoi(coords[1])
-	check(err)
+// praseWallEdge returns coordinates from comma delimited value (e.g. "498,6" -> Point{498, 6}).
+func parseWallEdge(wallEdgeDesc string) (grid.Point, error) {
+	xDesc, yDesc, found := strings.Cut(wallEdgeDesc, ",")
+	if !found {
+		return grid.Point{}, fmt.Errorf("expected x,y coordinates, got %q", wallEdgeDesc)
+	}
+	x, err := strconv.Atoi(xDesc)
+	if err != nil {
+		return grid.Point{}, err
+	}
+	y, err := strconv.Atoi(yDesc)
+	if err != nil {
+		return grid.Point{}, err
+	}
+	if y < SandSource.Y {
+		return grid.Point{}, fmt.Errorf("wall at %v is above the sand source", grid.Point{X: x, Y: y})
+	}
+	if x < 0 || x > maxCoordinate || y > maxCoordinate {
+		return grid.Point{}, fmt.Errorf("wall at %v is outside of the cave (0-%d)", grid.Point{X: x, Y: y}, maxCoordinate)
+	}
+
+	return grid.Point{X: x, Y: y}, nil
+}
 
-	return Point{x, y}
+// parseWall returns the straight wall segments of a wall path as pairs of start and end points.
+func parseWall(wallDesc string) ([]grid.Point, error) {
+	edges := strings.Split(wallDesc, " -> ")
+	wallStart, err := parseWallEdge(edges[0])
+	if err != nil {
+		return nil, err
+	}
+	wallPoints := make([]grid.Point, 0, 2*len(edges))
+	for i := 1; i < len(edges); i++ {
+		wallEnd, err := parseWallEdge(edges[i])
+		if err != nil {
+			return nil, err
+		}
+		if wallStart.X != wallEnd.X && wallStart.Y != wallEnd.Y {
+			return nil, fmt.Errorf("diagonal wall from %v to %v", wallStart, wallEnd)
+		}
+		wallPoints = append(wallPoints, wallStart, wallEnd)
+		wallStart = wallEnd
+	}
+	return wallPoints, nil
 }
 
 // InitSandbox creates a new sandbox from wall descriptions. Tries to create the optimal sandbox size.
-func InitSandbox(sandBoxDesc string, hasBottom bool) *Sandbox {
+func InitSandbox(sandBoxDesc string, hasBottom bool) (*Sandbox, error) {
 	sandbox := &Sandbox{}
 	sandbox.hasBottom = hasBottom
 
 	// Parse wall instructions first so we can make the optimal sized sandbox
-	wallPoints := make([]Point, 0)
-	walls := strings.Split(sandBoxDesc, "\n")
-	minX := -1
+	walls, err := aoc.ParseLines(sandBoxDesc, parseWall)
+	if err != nil {
+		return nil, err
+	}
+	wallPoints := make([]grid.Point, 0)
 	for _, wall := range walls {
-		edges := strings.Split(wall, " -> ")
-		wallStart := parseWallEdge(edges[0])
-		for i := 1; i < len(edges); i++ {
-			// Find bottom -> helps calculate the optimal sandbox size
-			if wallStart.y > sandbox.bottom {
-				sandbox.bottom = wallStart.y
-			}
-			if wallStart.x > sandbox.width {
-				sandbox.width = wallStart.x
-			}
-			if minX == -1 || minX > wallStart.x {
-				minX = wallStart.x
-			}
-			wallEnd := parseWallEdge(edges[i])
-			wallPoints = append(wallPoints, wallStart, wallEnd)
-			wallStart = wallEnd
-		}
+		wallPoints = append(wallPoints, wall...)
+	}
+	if len(wallPoints) == 0 {
+		return nil, errors.New("no walls to catch the sand")
 	}
+	// Find extremes -> helps calculate the optimal sandbox size
+	walled := grid.BoundingBox(wallPoints...)
 	// As per instrcutions, bottom is 2 spaces lower than lowest wall
-	sandbox.bottom += 2
-	// Minimum width to pad the leftmost and rightmost wall with 1 Void space
-	sandbox.width -= (minX - 3)
-
-	if sandbox.hasBottom && sandbox.width < (2*sandbox.bottom+1) {
+	sandbox.bottom = walled.Max.Y + 2
+	// Pad the leftmost and rightmost wall with 1 Void space
+	bounds := grid.Rect{
+		Min: grid.Point{X: walled.Min.X - 1, Y: 0},
+		Max: grid.Point{X: walled.Max.X + 1, Y: sandbox.bottom},
+	}
+	if sandbox.hasBottom {
 		// Since sand flows into a triangle, optimal width = 2N+1 (see how to draw triangles with ASCII)
-		sandbox.width = 2*sandbox.bottom + 1
-		sandbox.offsetX = 500 - (sandbox.width / 2)
-	} else {
-		// Offset is such that leftmost wall is padded by 1
-		sandbox.offsetX = minX - 1
+		bounds = bounds.Extend(grid.Point{X: SandSource.X - sandbox.bottom, Y: sandbox.bottom})
+		bounds = bounds.Extend(grid.Point{X: SandSource.X + sandbox.bottom, Y: sandbox.bottom})
 	}
-	// Create sandbox with size that fits all sand to up to sink (or walls)
-	sandbox.space = make([][]int, sandbox.bottom+1)
-	for i := range sandbox.space {
-		sandbox.space[i] = make([]int, sandbox.width)
+	if !bounds.Contains(SandSource) {
+		return nil, fmt.Errorf("walls between x=%d and x=%d don't reach the sand source %v", walled.Min.X, walled.Max.X, SandSource)
 	}
-	// Calculate the offset for X coordinates (so source is ~middle of sandbox) and mark the sand source
-	offsetPoint := Point{-sandbox.offsetX, 0}
-	sandbox.sandSource = SandSource.add(offsetPoint)
-	sandbox.space[SandSource.y][SandSource.x-sandbox.offsetX] = SandSupply
+	// Create sandbox with size that fits all sand to up to sink (or walls) and mark the sand source
+	sandbox.space = grid.NewDense[int](bounds)
+	sandbox.sandSource = SandSource
+	sandbox.space.Set(SandSource, SandSupply)
 	// Draw walls into sandbox
-
 	for i := 1; i < len(wallPoints); i += 2 {
-		sandbox.DrawWall(wallPoints[i-1].add(offsetPoint), wallPoints[i].add(offsetPoint))
+		sandbox.DrawWall(wallPoints[i-1], wallPoints[i])
+	}
+	if sandbox.space.Get(SandSource) != SandSupply {
+		return nil, fmt.Errorf("walls block the sand source %v", SandSource)
 	}
 	// Draw bottom
 	if sandbox.hasBottom {
-		sandbox.DrawWall(Point{0, sandbox.bottom}, Point{len(sandbox.space[0]) - 1, sandbox.bottom})
+		sandbox.DrawWall(grid.Point{X: bounds.Min.X, Y: sandbox.bottom}, grid.Point{X: bounds.Max.X, Y: sandbox.bottom})
 	}
-	return sandbox
+	return sandbox, nil
 }
 
 // runChallenge returns the desired output for the day's challenge.
-func runChallenge(challengePart int) int {
+func runChallenge(ctx context.Context, challengePart int, input string) (int, error) {
 	hasBottom := false
 	if (challengePart) == 2 {
 		hasBottom = true
 	}
-	sandbox := InitSandbox(input, hasBottom)
+	sandbox, err := InitSandbox(input, hasBottom)
+	if err != nil {
+		return 0, err
+	}
 	canSpawnMore := sandbox.SpawnGrainOfSand()
 	cornsSpawned := 0
 	for canSpawnMore {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
 		cornsSpawned++
+		recorder.Step(sandbox)
 		canSpawnMore = sandbox.SpawnGrainOfSand()
 	}
-	// fmt.Println(sandbox.Output())
+	tracer.Info("sand stopped", tracing.F("grains", cornsSpawned))
+	if tracer.Enabled(tracing.Debug) {
+		tracer.Debug("sandbox", tracing.F("space", sandbox.Output()))
+	}
 	if challengePart == 2 {
 		// I like the idea that sand source stays where it originally was, but challenge wants it to change into sand.
 		// So add 1 to get proper result.
-		return cornsSpawned + 1
+		return cornsSpawned + 1, nil
 	}
-	return cornsSpawned
+	return cornsSpawned, nil
 }
 
-func main() {
-	fmt.Println("Grains of sand produced: ", runChallenge(2))
+func init() {
+	aoc.Register(aoc.Puzzle{
+		Year:    2022,
+		Day:     14,
+		Title:   "Regolith Reservoir",
+		Parts:   2,
+		Example: example,
+		Solver: aoc.SolverFunc(func(ctx context.Context, part int, input string) (any, error) {
+			return runChallenge(ctx, part, input)
+		}),
+	})
 }
@@ -1,7 +1,10 @@
-package main
+package day14
 
 import (
+	"context"
 	"testing"
+
+	"github.com/rubinda/aoc/internal/aoctest"
 )
 
 const (
@@ -10,7 +13,10 @@ const (
 )
 
 func TestChallenge1(t *testing.T) {
-	actual := runChallenge(1)
+	actual, err := runChallenge(context.Background(), 1, example)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if actual != expected1 {
 		t.Errorf("Wrong result! Expected: %v, actual: %v", expected1, actual)
@@ -18,20 +24,45 @@ func TestChallenge1(t *testing.T) {
 }
 
 func TestChallenge2(t *testing.T) {
-	actual := runChallenge(2)
+	actual, err := runChallenge(context.Background(), 2, example)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if actual != expected2 {
 		t.Errorf("Wrong result! Expected: %v, actual: %v", expected2, actual)
 	}
 }
 
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 2022, 14)
+}
+
 func Benchmark1(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		runChallenge(1)
+		runChallenge(context.Background(), 1, example)
 	}
 }
 func Benchmark2(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		runChallenge(2)
+		runChallenge(context.Background(), 2, example)
 	}
 }
+
+func FuzzInitSandbox(f *testing.F) {
+	f.Add(example)
+	f.Fuzz(func(t *testing.T, input string) {
+		for _, hasBottom := range []bool{false, true} {
+			var sandbox *Sandbox
+			if aoctest.Parses(t, func() (err error) {
+				sandbox, err = InitSandbox(input, hasBottom)
+				return err
+			}) != nil {
+				continue
+			}
+			if !sandbox.space.Bounds().Contains(SandSource) || sandbox.space.Get(SandSource) != SandSupply {
+				t.Errorf("Sand source %v missing from the sandbox %v", SandSource, sandbox.space.Bounds())
+			}
+		}
+	})
+}
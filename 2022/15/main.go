@@ -1,187 +1,207 @@
-package main
+package day15
 
 import (
+	"context"
 	_ "embed"
 	"fmt"
 	"math"
-	"strings"
-)
+	"sort"
 
-var (
-	//go:embed example.in
-	input string
+	"github.com/rubinda/aoc"
+	"github.com/rubinda/aoc/internal/grid"
+	"github.com/rubinda/aoc/internal/tracing"
 )
 
-// Relative directions based on (0,0) being the upper left corner
 var (
-	relativeUpRight   = Point{1, -1}
-	relativeDownRight = Point{1, 1}
-	relativeDownLeft  = Point{-1, 1}
-	relativeUpLeft    = Point{-1, -1}
-
-	diamondDirections = []Point{relativeUpRight, relativeDownRight, relativeDownLeft, relativeUpLeft}
+	//go:embed example.in
+	example string
+	// tracer reports the coverage of the scanned rows and the distress beacon (see aoc run --trace).
+	tracer = tracing.For(2022, 15)
 )
 
-// Challenge constants (can differ from example and challenge)
+// Challenge constants (differ between example and challenge)
 const (
-	challenge1Y               = 10 // 2000000
+	example1Y                 = 10
+	challenge1Y               = 2000000
 	challenge2SearchMin       = 0
-	challenge2SearchMax       = 20 // 4000000
+	example2SearchMax         = 20
+	challenge2SearchMax       = 4000000
 	tuningFrequencyMultiplier = 4000000
 )
 
-// max returns the highest value from given.
-func max(items ...int) int {
-	highest := items[0]
-	for i := 1; i < len(items); i++ {
-		if items[i] > highest {
-			highest = items[i]
-		}
-	}
-	return highest
-}
-
-// min returns the lowest value from given.
-func min(items ...int) int {
-	lowest := items[0]
-	for i := 1; i < len(items); i++ {
-		if items[i] < lowest {
-			lowest = items[i]
-		}
-	}
-	return lowest
-}
-
-// Point represents a point in a coordinate system
-type Point struct {
-	x int
-	y int
-}
-
-// DistanceTo returns the Manhattan distance between given points.
-func (a Point) DistanceTo(b Point) int {
-	return int(math.Abs(float64(a.x)-float64(b.x)) + math.Abs(float64(a.y)-float64(b.y)))
-}
-
-// add sums each coordinate of points.
-func (a Point) add(b Point) Point {
-	return Point{a.x + b.x, a.y + b.y}
-}
-
 // Sensor represents a cave object that can detect the closest beacon.
 type Sensor struct {
-	Location         Point
-	ClosestBeacon    Point
+	Location         grid.Point
+	ClosestBeacon    grid.Point
 	DistanceToBeacon int
 }
 
 // NewSensor parses a challenge input line into a sensor.
-func NewSensor(sensorDesc string) Sensor {
+func NewSensor(sensorDesc string) (Sensor, error) {
 	// e.g. sensorDesc = "Sensor at x=2, y=18: closest beacon is at x=-2, y=15"
 	sensor := Sensor{}
-	sensor.ClosestBeacon = Point{}
-	sensor.Location = Point{}
-	fmt.Sscanf(sensorDesc, `Sensor at x=%d, y=%d: closest beacon is at x=%d, y=%d`, &sensor.Location.x, &sensor.Location.y, &sensor.ClosestBeacon.x, &sensor.ClosestBeacon.y)
-	sensor.DistanceToBeacon = sensor.Location.DistanceTo(sensor.ClosestBeacon)
-	return sensor
+	_, err := fmt.Sscanf(sensorDesc, `Sensor at x=%d, y=%d: closest beacon is at x=%d, y=%d`, &sensor.Location.X, &sensor.Location.Y, &sensor.ClosestBeacon.X, &sensor.ClosestBeacon.Y)
+	if err != nil {
+		return Sensor{}, err
+	}
+	sensor.DistanceToBeacon = sensor.Location.Manhattan(sensor.ClosestBeacon)
+	return sensor, nil
 }
 
 // HasCoverageOver returns if sensor has coverage over point - distance is <= to closestBeacon.
-func (s Sensor) HasCoverageOver(p Point) bool {
-	return s.Location.DistanceTo(p) <= s.DistanceToBeacon
+func (s Sensor) HasCoverageOver(p grid.Point) bool {
+	return s.Location.Manhattan(p) <= s.DistanceToBeacon
 }
 
 // Cave represents an imaginary 2D overview of a cave that contains sensors and beacons.
 type Cave struct {
 	Sensors []Sensor
-	// ShallowestY represents lowest Y at least one sensor can cover
-	ShallowestY int
-	// Width represents highest Y at least one sensor can cover
-	Depth int
-	// ShallowestX represents lowest X at least one sensor can cover
-	ShallowestX int
-	// Width represents highest X at least one sensor can cover
-	Width int
+	// Coverage is the bounding box of all points at least one sensor can cover
+	Coverage grid.Rect
 	// IsOccupied contains a map of occupied points
-	IsOccupied map[Point]bool
+	IsOccupied map[grid.Point]bool
 }
 
 // ParseCave returns a cave with sensors and beacons from the challenge input.
-func ParseCave(challengeInput string) Cave {
+func ParseCave(challengeInput string) (Cave, error) {
 	cave := Cave{}
-	lines := strings.Split(challengeInput, "\n")
-	cave.Sensors = make([]Sensor, len(lines))
-	cave.IsOccupied = make(map[Point]bool)
-
-	var initX, initY bool
-	for i := range lines {
-		s := NewSensor(lines[i])
-		cave.Sensors[i] = s
+	sensors, err := aoc.ParseLines(challengeInput, NewSensor)
+	if err != nil {
+		return Cave{}, err
+	}
+	cave.Sensors = sensors
+	cave.IsOccupied = make(map[grid.Point]bool)
+
+	cave.Coverage = grid.BoundingBox()
+	for _, s := range cave.Sensors {
 		cave.IsOccupied[s.Location] = true
 		cave.IsOccupied[s.ClosestBeacon] = true
-		maxX := max(s.ClosestBeacon.x, s.Location.x, s.Location.x+s.DistanceToBeacon)
-		minX := min(s.ClosestBeacon.x, s.Location.x, s.Location.x-s.DistanceToBeacon)
-		maxY := max(s.ClosestBeacon.y, s.Location.y, s.Location.y+s.DistanceToBeacon)
-		minY := min(s.ClosestBeacon.y, s.Location.y, s.Location.y-s.DistanceToBeacon)
-		if maxX > cave.Width {
-			cave.Width = maxX
+		// The sensor covers a diamond, its bounding box is the sensor's location padded by distance to the beacon
+		cave.Coverage = cave.Coverage.
+			Extend(s.Location.Sub(grid.Point{X: s.DistanceToBeacon, Y: s.DistanceToBeacon})).
+			Extend(s.Location.Add(grid.Point{X: s.DistanceToBeacon, Y: s.DistanceToBeacon}))
+	}
+	return cave, nil
+}
+
+// IsExample returns true if all sensors are inside the example search area.
+// The example asks about a different row and search area than the challenge input.
+func (c Cave) IsExample() bool {
+	for _, s := range c.Sensors {
+		if s.Location.X > example2SearchMax || s.Location.Y > example2SearchMax {
+			return false
 		}
-		if minX < cave.ShallowestX || !initX {
-			cave.ShallowestX = minX
-			initX = true
+	}
+	return true
+}
+
+// BeaconlessOnRow returns the number of positions in row y where a beacon can't be: the ones covered by sensors,
+// except where there already is a sensor or beacon. The covered ranges of the row are merged instead of checking
+// every position.
+func (c Cave) BeaconlessOnRow(y int) int {
+	covered := make([]grid.Rect, 0, len(c.Sensors))
+	for _, s := range c.Sensors {
+		reach := s.DistanceToBeacon - s.Location.Manhattan(grid.Point{X: s.Location.X, Y: y})
+		if reach >= 0 {
+			covered = append(covered, grid.Rect{
+				Min: grid.Point{X: s.Location.X - reach, Y: y},
+				Max: grid.Point{X: s.Location.X + reach, Y: y},
+			})
 		}
-		if maxY > cave.Depth {
-			cave.Depth = maxY
+	}
+	sort.Slice(covered, func(i, j int) bool {
+		return covered[i].Min.X < covered[j].Min.X
+	})
+	beaconless := 0
+	end := math.MinInt
+	for _, r := range covered {
+		if r.Min.X > end {
+			end = r.Min.X - 1
 		}
-		if minY < cave.ShallowestY || !initY {
-			cave.ShallowestY = minY
-			initY = true
+		if r.Max.X > end {
+			beaconless += r.Max.X - end
+			end = r.Max.X
 		}
+	}
+	// Sensors and beacons are always covered (by the sensor itself or the one that detects the beacon)
+	for p := range c.IsOccupied {
+		if p.Y == y {
+			beaconless--
+		}
+	}
+	tracer.Info("row covered", tracing.F("y", y), tracing.F("ranges", len(covered)), tracing.F("beaconless", beaconless))
+	return beaconless
+}
 
+// ScanBeaconless returns the same as BeaconlessOnRow by checking every position of row scanY inside the coverage.
+func (c Cave) ScanBeaconless(scanY int) int {
+	definitelyBeaconless := 0
+	occuppied := 0
+	noCoverage := 0
+	for x := c.Coverage.Min.X; x <= c.Coverage.Max.X; x++ {
+		cavePoint := grid.Point{X: x, Y: scanY}
+		if _, isOccupied := c.IsOccupied[cavePoint]; isOccupied {
+			occuppied++
+			continue
+		}
+		hasCoverage := false
+		for _, s := range c.Sensors {
+			if s.HasCoverageOver(cavePoint) {
+				hasCoverage = true
+				break
+			}
+		}
+		if hasCoverage {
+			definitelyBeaconless++
+		} else {
+			noCoverage++
+		}
 	}
-	return cave
+	tracer.Info("row scanned", tracing.F("y", scanY), tracing.F("uncovered", noCoverage), tracing.F("beaconless", definitelyBeaconless), tracing.F("occupied", occuppied))
+	return definitelyBeaconless
 }
 
 // runChallenge returns the desired output for the day's challenge.
-func runChallenge(challengePart int) int {
-	cave := ParseCave(input)
+func runChallenge(ctx context.Context, challengePart int, input string) (int, error) {
+	return solve(ctx, challengePart, input, Cave.BeaconlessOnRow)
+}
+
+// runScan returns the same as runChallenge, but scans the whole row in part 1.
+func runScan(ctx context.Context, challengePart int, input string) (int, error) {
+	return solve(ctx, challengePart, input, Cave.ScanBeaconless)
+}
+
+// solve returns the output for the day's challenge, counting positions without a beacon in part 1 with beaconless.
+func solve(ctx context.Context, challengePart int, input string, beaconless func(c Cave, y int) int) (int, error) {
+	cave, err := ParseCave(input)
+	if err != nil {
+		return 0, err
+	}
+	scanY, searchMax := challenge1Y, challenge2SearchMax
+	if cave.IsExample() {
+		scanY, searchMax = example1Y, example2SearchMax
+	}
 	if challengePart == 1 {
-		definitelyBeaconless := 0
-		occuppied := 0
-		noCoverage := 0
-		for x := cave.ShallowestX; x <= cave.Depth; x++ {
-			cavePoint := Point{x, challenge1Y}
-			if _, isOccupied := cave.IsOccupied[cavePoint]; isOccupied {
-				occuppied++
-				continue
-			}
-			hasCoverage := false
-			for _, s := range cave.Sensors {
-				if s.HasCoverageOver(cavePoint) {
-					hasCoverage = true
-					break
-				}
-			}
-			if hasCoverage {
-				definitelyBeaconless++
-			} else {
-				noCoverage++
-			}
-		}
-		// fmt.Printf("=== Depth %d ===\nNo coverage: %d \n  No beacon: %d \n   Occupied: %d\n", challenge1Y, noCoverage, definitelyBeaconless, occuppied)
-		return definitelyBeaconless
+		return beaconless(cave, scanY), nil
 	} else if challengePart == 2 {
 		covered := 0
 		occuppied := 0
+		searchArea := grid.Rect{
+			Min: grid.Point{X: challenge2SearchMin, Y: challenge2SearchMin},
+			Max: grid.Point{X: searchMax, Y: searchMax},
+		}
 		for sI, sensor := range cave.Sensors {
+			if err := ctx.Err(); err != nil {
+				return 0, err
+			}
 			// Number of steps to take on expanded perimeter
 			steps := 2*sensor.DistanceToBeacon + 3
 			// Down left from leftmost edge (so we can call perimeter.add at beginning)
-			perimeterPoint := sensor.Location.add(Point{-(sensor.DistanceToBeacon + 2), 1})
-			for _, dir := range diamondDirections {
+			perimeterPoint := sensor.Location.Add(grid.Point{X: -(sensor.DistanceToBeacon + 2), Y: 1})
+			for _, dir := range grid.Diagonals {
 				for i := 0; i < steps; i++ {
-					perimeterPoint = perimeterPoint.add(dir)
-					if perimeterPoint.x < challenge2SearchMin || perimeterPoint.y < challenge2SearchMin || perimeterPoint.x > challenge2SearchMax || perimeterPoint.y > challenge2SearchMax {
+					perimeterPoint = perimeterPoint.Add(dir)
+					if !searchArea.Contains(perimeterPoint) {
 						// Ship perimiter position if it's out of search area
 						continue
 					}
@@ -203,9 +223,9 @@ func runChallenge(challengePart int) int {
 					}
 					if !hasCoverage {
 						// Point has no coverage! since challenge requires only one such point the search is done
-						frequency := perimeterPoint.x*tuningFrequencyMultiplier + perimeterPoint.y
-						// fmt.Printf("  Covered: %d \n Occupied: %d\n", covered, occuppied)
-						return frequency
+						frequency := perimeterPoint.X*tuningFrequencyMultiplier + perimeterPoint.Y
+						tracer.Info("distress beacon found", tracing.F("x", perimeterPoint.X), tracing.F("y", perimeterPoint.Y), tracing.F("covered", covered), tracing.F("occupied", occuppied))
+						return frequency, nil
 					} else {
 						covered++
 					}
@@ -213,9 +233,23 @@ func runChallenge(challengePart int) int {
 			}
 		}
 	}
-	return -1
+	return -1, nil
 }
 
-func main() {
-	fmt.Println(runChallenge(2))
+func init() {
+	aoc.Register(aoc.Puzzle{
+		Year:    2022,
+		Day:     15,
+		Title:   "Beacon Exclusion Zone",
+		Parts:   2,
+		Example: example,
+		Solver: aoc.SolverFunc(func(ctx context.Context, part int, input string) (any, error) {
+			return runChallenge(ctx, part, input)
+		}),
+		Implementations: map[string]aoc.Solver{
+			"scan": aoc.SolverFunc(func(ctx context.Context, part int, input string) (any, error) {
+				return runScan(ctx, part, input)
+			}),
+		},
+	})
 }
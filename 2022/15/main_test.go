@@ -1,7 +1,11 @@
-package main
+package day15
 
 import (
+	"context"
 	"testing"
+
+	_ "github.com/rubinda/aoc/gen/2022"
+	"github.com/rubinda/aoc/internal/aoctest"
 )
 
 const (
@@ -10,7 +14,10 @@ const (
 )
 
 func TestChallenge1(t *testing.T) {
-	actual := runChallenge(1)
+	actual, err := runChallenge(context.Background(), 1, example)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if actual != expected1 {
 		t.Errorf("Wrong result! Expected: %v, actual: %v", expected1, actual)
@@ -18,20 +25,49 @@ func TestChallenge1(t *testing.T) {
 }
 
 func TestChallenge2(t *testing.T) {
-	actual := runChallenge(2)
+	actual, err := runChallenge(context.Background(), 2, example)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if actual != expected2 {
 		t.Errorf("Wrong result! Expected: %v, actual: %v", expected2, actual)
 	}
 }
 
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 2022, 15)
+}
+
+func TestImplementations(t *testing.T) {
+	aoctest.Differential(t, 2022, 15, 3, 0.01, 0.1)
+}
+
 func Benchmark1(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		runChallenge(1)
+		runChallenge(context.Background(), 1, example)
 	}
 }
 func Benchmark2(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		runChallenge(2)
+		runChallenge(context.Background(), 2, example)
 	}
 }
+
+func FuzzParseCave(f *testing.F) {
+	f.Add(example)
+	f.Fuzz(func(t *testing.T, input string) {
+		var cave Cave
+		if aoctest.Parses(t, func() (err error) {
+			cave, err = ParseCave(input)
+			return err
+		}) != nil {
+			return
+		}
+		for _, s := range cave.Sensors {
+			if s.DistanceToBeacon < 0 || !cave.Coverage.Contains(s.Location) || !cave.Coverage.Contains(s.ClosestBeacon) {
+				t.Errorf("Sensor %+v outside of the coverage %v", s, cave.Coverage)
+			}
+		}
+	})
+}
@@ -0,0 +1,240 @@
+package day16
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rubinda/aoc"
+	"github.com/rubinda/aoc/internal/graph"
+)
+
+//go:embed example.in
+var example string
+
+const (
+	// startingValve is where you (and the elephant) start.
+	startingValve = "AA"
+	// challenge1Minutes is the time until the volcano erupts.
+	challenge1Minutes = 30
+	// challenge2Minutes is the time left after teaching the elephant to open valves.
+	challenge2Minutes = 26
+	// maxValves is the most valves with a flow rate the search can handle, it keeps a result for every set of them
+	// and the last visit of every valve with every set (about 100 MB for 20 valves).
+	maxValves = 20
+	// maxFlowRate keeps the pressure released by all valves within the int32 the search remembers it in.
+	maxFlowRate = 10000
+)
+
+// Valve is a pressure release valve connected to other valves with tunnels.
+type Valve struct {
+	Name     string
+	FlowRate int
+	Tunnels  []string
+}
+
+// parseValve reads a valve description, e.g. "Valve BB has flow rate=13; tunnels lead to valves CC, AA".
+func parseValve(valveDesc string) (Valve, error) {
+	valve := Valve{}
+	description, tunnels, found := strings.Cut(valveDesc, "; ")
+	if !found {
+		return Valve{}, errors.New("valve description without tunnels")
+	}
+	_, err := fmt.Sscanf(description, "Valve %s has flow rate=%d", &valve.Name, &valve.FlowRate)
+	if err != nil {
+		return Valve{}, err
+	}
+	// Singular and plural descriptions differ ("tunnel leads to valve GG")
+	tunnels = strings.TrimPrefix(tunnels, "tunnels lead to valves ")
+	tunnels = strings.TrimPrefix(tunnels, "tunnel leads to valve ")
+	valve.Tunnels = strings.Split(tunnels, ", ")
+	return valve, nil
+}
+
+// Volcano holds the valves worth opening and the time it takes to walk between them.
+type Volcano struct {
+	// Valves have a positive flow rate, their index is the bit representing them in a set of opened valves.
+	Valves []Valve
+	// Distances holds the minutes needed to move from one valve to another (indices as in Valves).
+	// The extra last row holds distances from the starting valve.
+	Distances [][]int
+}
+
+// ParseVolcano reads the scan output and calculates distances between all valves with a flow rate.
+func ParseVolcano(scan string) (*Volcano, error) {
+	valves, err := aoc.ParseLines(scan, parseValve)
+	if err != nil {
+		return nil, err
+	}
+	tunnels := make(map[string][]string)
+	volcano := &Volcano{}
+	for _, valve := range valves {
+		tunnels[valve.Name] = valve.Tunnels
+		if valve.FlowRate > maxFlowRate {
+			return nil, fmt.Errorf("valve %s has a flow rate of %d, at most %d is supported", valve.Name, valve.FlowRate, maxFlowRate)
+		}
+		if valve.FlowRate > 0 {
+			volcano.Valves = append(volcano.Valves, valve)
+		}
+	}
+	if _, ok := tunnels[startingValve]; !ok {
+		return nil, fmt.Errorf("starting valve %s is missing", startingValve)
+	}
+	// Opening the strongest valves first finds the most pressure early, so later paths to the same valves are skipped
+	sort.SliceStable(volcano.Valves, func(i, j int) bool {
+		return volcano.Valves[i].FlowRate > volcano.Valves[j].FlowRate
+	})
+	// Each valve is a bit in the set of opened valves
+	if len(volcano.Valves) > maxValves {
+		return nil, fmt.Errorf("too many valves with a flow rate (%d), at most %d are supported", len(volcano.Valves), maxValves)
+	}
+
+	// Breadth first search from each valve gives all pairs distances, since tunnels take 1 minute
+	cave := graph.Unweighted[string](func(valve string) []string {
+		return tunnels[valve]
+	})
+	from := make([]string, 0, len(volcano.Valves)+1)
+	for _, valve := range volcano.Valves {
+		from = append(from, valve.Name)
+	}
+	from = append(from, startingValve)
+	volcano.Distances = make([][]int, len(from))
+	for i, name := range from {
+		// Without a goal or a deadline the search can't fail
+		search, _ := graph.BFS[string](context.Background(), cave, nil, name)
+		volcano.Distances[i] = make([]int, len(volcano.Valves))
+		for j, valve := range volcano.Valves {
+			distance, reachable := search.Distance(valve.Name)
+			if !reachable {
+				// Unreachable valves will never be worth opening
+				distance = challenge1Minutes + 1
+			}
+			volcano.Distances[i][j] = distance
+		}
+	}
+	return volcano, nil
+}
+
+// MostPressure returns the most pressure that can be released in given minutes for every set of opened valves.
+// The set is a bitmask where bit i stands for Valves[i]. The search stops with ctx's error once ctx is done.
+func (v *Volcano) MostPressure(ctx context.Context, minutes int) ([]int, error) {
+	states := len(v.Valves) << len(v.Valves)
+	s := &valveSearch{
+		volcano:         v,
+		released:        make([]int, 1<<len(v.Valves)),
+		reachedMinutes:  make([]int8, states),
+		reachedPressure: make([]int32, states),
+		done:            ctx.Done(),
+	}
+	start := len(v.Distances) - 1
+	s.openValves(start, minutes, 0, 0)
+	return s.released, ctx.Err()
+}
+
+// valveSearch is the state of the search for the most pressure released by every set of opened valves.
+type valveSearch struct {
+	volcano  *Volcano
+	released []int
+	// reachedMinutes and reachedPressure hold the minutes left and the pressure released of the last time a valve
+	// was opened with a set of opened valves (indexed by set*len(Valves)+valve). Opening it again with less time
+	// and less pressure can't release more, so that path isn't searched again.
+	reachedMinutes  []int8
+	reachedPressure []int32
+	done            <-chan struct{}
+}
+
+// openValves walks to every closed valve that can still be opened in time (DFS) and records the most
+// pressure released for each set of opened valves. It returns early once done is closed.
+func (s *valveSearch) openValves(position, minutesLeft, opened, pressure int) {
+	select {
+	case <-s.done:
+		return
+	default:
+	}
+	if pressure > s.released[opened] {
+		s.released[opened] = pressure
+	}
+	for next, valve := range s.volcano.Valves {
+		if opened&(1<<next) != 0 {
+			continue
+		}
+		// Walk there and spend a minute opening it, it releases pressure for the remaining time
+		remaining := minutesLeft - s.volcano.Distances[position][next] - 1
+		if remaining <= 0 {
+			continue
+		}
+		nextOpened, nextPressure := opened|1<<next, pressure+remaining*valve.FlowRate
+		state := nextOpened*len(s.volcano.Valves) + next
+		if int(s.reachedMinutes[state]) >= remaining && int(s.reachedPressure[state]) >= nextPressure {
+			continue
+		}
+		s.reachedMinutes[state], s.reachedPressure[state] = int8(remaining), int32(nextPressure)
+		s.openValves(next, remaining, nextOpened, nextPressure)
+	}
+}
+
+// max returns the bigger of given integers.
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// runChallenge returns the desired output for the day's challenge.
+func runChallenge(ctx context.Context, challengePart int, input string) (int, error) {
+	volcano, err := ParseVolcano(input)
+	if err != nil {
+		return 0, err
+	}
+	if challengePart == 1 {
+		released, err := volcano.MostPressure(ctx, challenge1Minutes)
+		if err != nil {
+			return 0, err
+		}
+		most := 0
+		for _, pressure := range released {
+			most = max(most, pressure)
+		}
+		return most, nil
+	} else if challengePart == 2 {
+		released, err := volcano.MostPressure(ctx, challenge2Minutes)
+		if err != nil {
+			return 0, err
+		}
+		// bestSubset[set] is the most pressure released by opening any subset of set
+		bestSubset := make([]int, len(released))
+		copy(bestSubset, released)
+		for bit := 0; bit < len(volcano.Valves); bit++ {
+			for set := range bestSubset {
+				if set&(1<<bit) != 0 {
+					bestSubset[set] = max(bestSubset[set], bestSubset[set^(1<<bit)])
+				}
+			}
+		}
+		// You open some valves, the elephant opens the best subset of the remaining ones
+		all := len(released) - 1
+		most := 0
+		for set, pressure := range released {
+			most = max(most, pressure+bestSubset[all^set])
+		}
+		return most, nil
+	}
+	return -1, nil
+}
+
+func init() {
+	aoc.Register(aoc.Puzzle{
+		Year:    2022,
+		Day:     16,
+		Title:   "Proboscidea Volcanium",
+		Parts:   2,
+		Example: example,
+		Solver: aoc.SolverFunc(func(ctx context.Context, part int, input string) (any, error) {
+			return runChallenge(ctx, part, input)
+		}),
+	})
+}
@@ -0,0 +1,122 @@
+package day16
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rubinda/aoc/internal/aoctest"
+)
+
+const (
+	expected1 = 1651
+	expected2 = 1707
+)
+
+func TestChallenge1(t *testing.T) {
+	actual, err := runChallenge(context.Background(), 1, example)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if actual != expected1 {
+		t.Errorf("Wrong result! Expected: %v, actual: %v", expected1, actual)
+	}
+}
+
+func TestChallenge2(t *testing.T) {
+	actual, err := runChallenge(context.Background(), 2, example)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if actual != expected2 {
+		t.Errorf("Wrong result! Expected: %v, actual: %v", expected2, actual)
+	}
+}
+
+func TestChallengeCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := runChallenge(ctx, 1, example); !errors.Is(err, context.Canceled) {
+		t.Errorf("Wrong error! Expected: %v, actual: %v", context.Canceled, err)
+	}
+}
+
+// denseScan returns a scan of valves with a flow rate that are all a minute apart, the most paths to search.
+func denseScan(valves int) string {
+	names := []string{startingValve}
+	for i := 0; i < valves; i++ {
+		names = append(names, fmt.Sprintf("%c%c", 'B'+i/26, 'A'+i%26))
+	}
+	lines := make([]string, len(names))
+	for i, name := range names {
+		others := make([]string, 0, len(names)-1)
+		for _, other := range names {
+			if other != name {
+				others = append(others, other)
+			}
+		}
+		rate := 0
+		if i > 0 {
+			rate = i*7%23 + 1
+		}
+		lines[i] = fmt.Sprintf("Valve %s has flow rate=%d; tunnels lead to valves %s", name, rate, strings.Join(others, ", "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestMaxValves(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if actual, err := runChallenge(ctx, 1, denseScan(maxValves)); err != nil || actual <= 0 {
+		t.Errorf("Expected the most pressure of %d valves, got %d, %v", maxValves, actual, err)
+	}
+	if _, err := ParseVolcano(denseScan(maxValves + 1)); err == nil {
+		t.Errorf("Expected an error for %d valves", maxValves+1)
+	}
+}
+
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 2022, 16)
+}
+
+func Benchmark1(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		runChallenge(context.Background(), 1, example)
+	}
+}
+func Benchmark2(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		runChallenge(context.Background(), 2, example)
+	}
+}
+
+func FuzzParseVolcano(f *testing.F) {
+	f.Add(example)
+	f.Fuzz(func(t *testing.T, input string) {
+		var volcano *Volcano
+		if aoctest.Parses(t, func() (err error) {
+			volcano, err = ParseVolcano(input)
+			return err
+		}) != nil {
+			return
+		}
+		if len(volcano.Valves) > maxValves || len(volcano.Distances) != len(volcano.Valves)+1 {
+			t.Fatalf("%d valves with %d rows of distances", len(volcano.Valves), len(volcano.Distances))
+		}
+		for i, row := range volcano.Distances {
+			if len(row) != len(volcano.Valves) {
+				t.Fatalf("Row %d has %d distances for %d valves", i, len(row), len(volcano.Valves))
+			}
+			for _, d := range row {
+				if d < 0 {
+					t.Errorf("Negative distance in row %d", i)
+				}
+			}
+		}
+	})
+}
@@ -1,16 +1,40 @@
-package main
+package day17
 
 import (
+	"context"
 	_ "embed"
+	"errors"
 	"fmt"
+	"image"
+	"image/color"
 	"strings"
+
+	"github.com/rubinda/aoc"
+	"github.com/rubinda/aoc/internal/grid"
+	"github.com/rubinda/aoc/internal/tracing"
+	"github.com/rubinda/aoc/internal/viz"
 )
 
 var (
 	//go:embed example.in
-	input string
+	example string
+	// tracer reports the rocks spawned and stopped, and the period of the tower's growth (see aoc run --trace).
+	tracer = tracing.For(2022, 17)
 	// pieceMaterial helps visualizing output
 	pieceMaterial = []string{"-", "+", "J", "I", "B"}
+	// chamberColors are the colors of the void, the chamber walls and the pieces (in the order of pieceMaterial)
+	// in a visualization.
+	chamberColors = color.Palette{
+		color.RGBA{0x10, 0x10, 0x20, 0xff},
+		color.RGBA{0x60, 0x60, 0x60, 0xff},
+		color.RGBA{0x4e, 0xa8, 0xde, 0xff},
+		color.RGBA{0xe9, 0x45, 0x60, 0xff},
+		color.RGBA{0xf4, 0xd3, 0x5e, 0xff},
+		color.RGBA{0x5e, 0xc4, 0x6e, 0xff},
+		color.RGBA{0xb0, 0x6e, 0xde, 0xff},
+	}
+	// recorder records the chamber after every rock (see aoc viz).
+	recorder = viz.For(2022, 17, chamberColors)
 	// RockPieces are tetris blocks that repeat.
 	RockPieces = []RockPiece{
 		{
@@ -39,13 +63,6 @@ var (
 	}
 )
 
-// Relative directions for tetris blocks to move.
-var (
-	relativeLeft  = Point{-1, 0}
-	relativeRight = Point{1, 0}
-	relativeDown  = Point{0, 1}
-)
-
 const (
 	// chamberWidth represents the playing field width.
 	chamberWidth = 7
@@ -84,17 +101,6 @@ func (r RockPiece) Width() int {
 	return len(r[0])
 }
 
-// Point holds coordinates.
-type Point struct {
-	x int
-	y int
-}
-
-// add returns a new point with coordinate-wise sums.
-func (p Point) add(d Point) Point {
-	return Point{p.x + d.x, p.y + d.y}
-}
-
 // Chamber is the playing field for tetris.
 type Chamber struct {
 	Section            [][]string
@@ -106,7 +112,16 @@ type Chamber struct {
 }
 
 // NewChamber returns a new chamber with periodic rock pieces and jets of wind.
-func NewChamber(windJets string) *Chamber {
+// Returns an error if the jet pattern is empty or holds anything but '<' and '>'.
+func NewChamber(windJets string) (*Chamber, error) {
+	if windJets == "" {
+		return nil, errors.New("missing jet pattern")
+	}
+	if i := strings.IndexFunc(windJets, func(r rune) bool {
+		return string(r) != moveLeft && string(r) != moveRight
+	}); i >= 0 {
+		return nil, &aoc.ParseError{Line: 1, Text: windJets, Err: fmt.Errorf("unrecognized jet %q at position %d", windJets[i], i+1)}
+	}
 	chamber := &Chamber{}
 	chamber.Section = make([][]string, chamberSectionDepth)
 	for i := range chamber.Section {
@@ -116,7 +131,7 @@ func NewChamber(windJets string) *Chamber {
 		}
 	}
 	chamber.WindJets = strings.Split(windJets, "")
-	return chamber
+	return chamber, nil
 }
 
 // Deepen increases space in the chamber for new pieces.
@@ -135,13 +150,13 @@ func (c *Chamber) Deepen() {
 }
 
 // NextWindJet returns the next direction a wind jet will blow.
-func (c *Chamber) NextWindJet() Point {
+func (c *Chamber) NextWindJet() grid.Point {
 	jet := c.WindJets[c.jetPointer%len(c.WindJets)]
 	c.jetPointer++
 	if jet == moveLeft {
-		return relativeLeft
+		return grid.Left
 	} else if jet == moveRight {
-		return relativeRight
+		return grid.Right
 	}
 	panic(fmt.Sprintf("Given Jet [%s] is unrecognized", jet))
 }
@@ -149,7 +164,6 @@ func (c *Chamber) NextWindJet() Point {
 // NextRockPiece returns the next RockPiece to be spawned.
 func (c *Chamber) NextRockPiece() RockPiece {
 	rockPiece := RockPieces[c.piecesSpawned%len(RockPieces)]
-	// fmt.Println("Spawning piece ", c.piecesSpawned%len(RockPieces))
 	c.piecesSpawned++
 	return rockPiece
 }
@@ -159,22 +173,26 @@ func (c *Chamber) SpawnPiece() {
 	rockPiece := c.NextRockPiece()
 	// Check if we can place piece on starting point
 	if len(c.Section) < ((c.sectionTowerHeight) + offsetY + rockPiece.Height()) {
-		// fmt.Println("  >deepen")
 		c.Deepen()
+		tracer.Debug("chamber deepened")
 	}
 	spawnY := len(c.Section) - (c.sectionTowerHeight + offsetY + rockPiece.Height())
-	corner := Point{offsetX, spawnY}
-	// fmt.Printf("Spawning at (%d, %d) \n", offsetX, spawnY)
+	corner := grid.Point{X: offsetX, Y: spawnY}
+	if tracer.Enabled(tracing.Debug) {
+		tracer.Debug("rock spawned", tracing.F("rock", (c.piecesSpawned-1)%len(RockPieces)), tracing.F("x", corner.X), tracing.F("y", corner.Y))
+	}
 
 	corner, hasMovedDown := c.movePiece(corner, rockPiece)
-	// fmt.Printf(" >Move: (%d, %d), next: %v \n", corner.x, corner.y, hasMovedDown)
 	for hasMovedDown {
 		corner, hasMovedDown = c.movePiece(corner, rockPiece)
-		// fmt.Printf(" >Move: (%d, %d), next: %v \n", corner.x, corner.y, hasMovedDown)
 	}
 	c.drawPiece(corner, rockPiece)
+	if tracer.Enabled(tracing.Debug) {
+		tracer.Debug("rock stopped", tracing.F("x", corner.X), tracing.F("y", corner.Y), tracing.F("jets", c.jetPointer))
+	}
 	// Recalculate tower height
 	c.increaseTowerHeight()
+	recorder.Step(c)
 }
 
 // increaseTowerHeight recalculates the total tetris tower height in the chamber.
@@ -197,30 +215,28 @@ func (c *Chamber) increaseTowerHeight() {
 
 // movePiece applies a jet of wind and downward movement onto a RockPiece with given upper left corner.
 // Returns false if piece turned solid rock and can't be moved anymore.
-func (c *Chamber) movePiece(corner Point, piece RockPiece) (Point, bool) {
+func (c *Chamber) movePiece(corner grid.Point, piece RockPiece) (grid.Point, bool) {
 	jetDir := c.NextWindJet()
-	if c.canPlace(corner.add(jetDir), piece) {
-		corner = corner.add(jetDir)
+	if c.canPlace(corner.Add(jetDir), piece) {
+		corner = corner.Add(jetDir)
 	}
 	moved := false
-	if moved = c.canPlace(corner.add(relativeDown), piece); moved {
-		corner = corner.add(relativeDown)
+	if moved = c.canPlace(corner.Add(grid.Down), piece); moved {
+		corner = corner.Add(grid.Down)
 	}
 
 	return corner, moved
 }
 
 // canPlace checks if a RockPiece can fit in chamber with given upper left corner.
-func (c *Chamber) canPlace(corner Point, piece RockPiece) bool {
-	if corner.x < 0 || corner.x+piece.Width() > chamberWidth || corner.y+piece.Height() > len(c.Section) {
-		// fmt.Println("  !place overflow")
+func (c *Chamber) canPlace(corner grid.Point, piece RockPiece) bool {
+	if corner.X < 0 || corner.X+piece.Width() > chamberWidth || corner.Y+piece.Height() > len(c.Section) {
 		return false
 	}
 
 	for y := 0; y < piece.Height(); y++ {
 		for x := 0; x < piece.Width(); x++ {
-			if piece[y][x] != MaterialVoid && c.Section[corner.y+y][corner.x+x] != MaterialVoid {
-				// fmt.Println("  !place hit rock")
+			if piece[y][x] != MaterialVoid && c.Section[corner.Y+y][corner.X+x] != MaterialVoid {
 				return false
 			}
 		}
@@ -229,12 +245,12 @@ func (c *Chamber) canPlace(corner Point, piece RockPiece) bool {
 }
 
 // drawPiece draws a rock onto the canvas.
-func (c *Chamber) drawPiece(corner Point, piece RockPiece) {
+func (c *Chamber) drawPiece(corner grid.Point, piece RockPiece) {
 	material := pieceMaterial[(c.piecesSpawned-1)%len(RockPieces)]
 	for y := 0; y < len(piece); y++ {
 		for x := 0; x < len(piece[0]); x++ {
 			if piece[y][x] == MaterialRock {
-				c.Section[corner.y+y][corner.x+x] = material
+				c.Section[corner.Y+y][corner.X+x] = material
 			}
 		}
 	}
@@ -254,85 +270,221 @@ func (c *Chamber) Output() string {
 	return out
 }
 
-// runChallenge returns the desired output for the day's challenge.
-func runChallenge(challengePart int) int {
-	chamber := NewChamber(input)
-	if challengePart == 1 {
-		for i := 0; i < challenge1Runs; i++ {
-			chamber.SpawnPiece()
+// Frame draws the (sectioned) chamber between its walls with a color per piece.
+func (c *Chamber) Frame() *image.Paletted {
+	frame := viz.NewFrame(grid.Rect{Min: grid.Point{X: -1}, Max: grid.Point{X: chamberWidth, Y: len(c.Section) - 1}}, chamberColors)
+	for y, row := range c.Section {
+		frame.SetColorIndex(-1, y, 1)
+		frame.SetColorIndex(chamberWidth, y, 1)
+		for x, material := range row {
+			for i := range pieceMaterial {
+				if material == pieceMaterial[i] {
+					frame.SetColorIndex(x, y, uint8(i+2))
+				}
+			}
 		}
-		return chamber.TowerHeight
 	}
-	if challengePart == 2 {
-		initialRuns := 10000
-		heightGains := make([]int, initialRuns)
-		previousHeight := 0
+	return frame
+}
 
-		// isPeriodic := false
-		for i := 0; i < initialRuns; i++ {
-			chamber.SpawnPiece()
-			heightGains[i] = chamber.TowerHeight - previousHeight
-			previousHeight = chamber.TowerHeight
+// Surface returns the empty spots a falling rock can reach below the row above the tower, relative to the tower's top.
+// Together with the next rock and jet of wind it decides how the tower grows from here on.
+func (c *Chamber) Surface() string {
+	top := len(c.Section) - c.sectionTowerHeight
+	isVoid := func(p grid.Point) bool {
+		return p.Y < 0 || c.Section[p.Y][p.X] == MaterialVoid
+	}
+	reached := make(map[grid.Point]bool)
+	unvisited := make([]grid.Point, 0, chamberWidth)
+	for x := 0; x < chamberWidth; x++ {
+		unvisited = append(unvisited, grid.Point{X: x, Y: top - 1})
+	}
+	for len(unvisited) > 0 {
+		p := unvisited[len(unvisited)-1]
+		unvisited = unvisited[:len(unvisited)-1]
+		if reached[p] {
+			continue
 		}
-		// For some reason, heigh gain becomes periodic after first N runs. BUT if we search for the periodic gains in reverse, there is no offset!
-		// So we find the periodic length L first and then offset
-		periodic := heightGains[len(heightGains)-5:]
-		i := initialRuns - 6
-		foundPeriod := false
-		for i >= 0 && !foundPeriod {
-			allMatch := true
-			for j := 0; j < len(periodic); j++ {
-				if heightGains[i-len(periodic)+j] != periodic[j] {
-					allMatch = false
-					break
-				}
+		reached[p] = true
+		for _, next := range p.Neighbours(grid.Directions4) {
+			if next.X >= 0 && next.X < chamberWidth && next.Y >= top-1 && next.Y < len(c.Section) && !reached[next] && isVoid(next) {
+				unvisited = append(unvisited, next)
 			}
-			if allMatch {
-				foundPeriod = true
+		}
+	}
+	surface := make([]byte, 0, len(reached))
+	for y := top; y < len(c.Section); y++ {
+		rowReached := false
+		for x := 0; x < chamberWidth; x++ {
+			if reached[grid.Point{X: x, Y: y}] {
+				surface = append(surface, byte('0'+x))
+				rowReached = true
 			}
-			periodic = append([]int{heightGains[i]}, periodic...)
-			i--
 		}
-		// fmt.Println("Found periodic growth: ", len(periodic))
-
-		// Find offset
-		periodOffset := -1
-		foundOffset := false
-		for !foundOffset {
-			periodOffset++
-			foundOffset = true
-			for i := range periodic {
-				if heightGains[periodOffset+i] != periodic[i] {
-					foundOffset = false
-					break
-				}
+		if !rowReached {
+			// Spots below a row that can't be reached can't be reached either
+			break
+		}
+		surface = append(surface, '|')
+	}
+	return string(surface)
+}
+
+// State returns the next rock, the next jet of wind and the surface of the tower.
+func (c *Chamber) State() string {
+	return fmt.Sprintf("%d %d %s", c.piecesSpawned%len(RockPieces), c.jetPointer%len(c.WindJets), c.Surface())
+}
+
+// towerHeight returns the height of the tower after given number of rocks. Once the state of the chamber repeats,
+// the tower grows the same from there on, so the remaining rocks are skipped in whole periods.
+func towerHeight(ctx context.Context, windJets string, rocks int) (int, error) {
+	chamber, err := NewChamber(windJets)
+	if err != nil {
+		return 0, err
+	}
+	type seen struct{ rocks, height int }
+	states := make(map[string]seen)
+	// heights holds the height of the tower before each rock
+	heights := make([]int, 0)
+	for n := 0; n < rocks; n++ {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		state := chamber.State()
+		if previous, ok := states[state]; ok {
+			period := n - previous.rocks
+			periods, rest := (rocks-n)/period, (rocks-n)%period
+			growth := chamber.TowerHeight - previous.height
+			tracer.Info("period found", tracing.F("offset", previous.rocks), tracing.F("period", period), tracing.F("growth", growth), tracing.F("periods", periods), tracing.F("remainder", rest))
+			return chamber.TowerHeight + periods*growth + heights[previous.rocks+rest] - previous.height, nil
+		}
+		states[state] = seen{n, chamber.TowerHeight}
+		heights = append(heights, chamber.TowerHeight)
+		chamber.SpawnPiece()
+	}
+	return chamber.TowerHeight, nil
+}
+
+// heightGainsTowerHeight returns the same as towerHeight. It simulates 10000 rocks, finds a period in how much
+// each of them grew the tower and skips the remaining rocks in whole periods.
+func heightGainsTowerHeight(ctx context.Context, windJets string, rocks int) (int, error) {
+	chamber, err := NewChamber(windJets)
+	if err != nil {
+		return 0, err
+	}
+	initialRuns := 10000
+	heightGains := make([]int, initialRuns)
+	previousHeight := 0
+
+	// isPeriodic := false
+	for i := 0; i < initialRuns; i++ {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		chamber.SpawnPiece()
+		heightGains[i] = chamber.TowerHeight - previousHeight
+		previousHeight = chamber.TowerHeight
+	}
+	// For some reason, heigh gain becomes periodic after first N runs. BUT if we search for the periodic gains in reverse, there is no offset!
+	// So we find the periodic length L first and then offset
+	periodic := heightGains[len(heightGains)-5:]
+	i := initialRuns - 6
+	foundPeriod := false
+	for i >= len(periodic) && !foundPeriod {
+		allMatch := true
+		for j := 0; j < len(periodic); j++ {
+			if heightGains[i-len(periodic)+j] != periodic[j] {
+				allMatch = false
+				break
 			}
 		}
-		// fmt.Println("Found offset for periodic growth: ", periodOffset)
-		periodicRuns := (challenge2Runs - periodOffset) / len(periodic)
-		lastPeriodRemainder := (challenge2Runs - periodOffset) - (periodicRuns * len(periodic))
-
-		// fmt.Printf("Need to do %d offset runs, then %d to get period (times %d) and finally add %d of last period \n", periodOffset, len(periodic), periodicRuns, lastPeriodRemainder)
-		chamber := NewChamber(input)
-		offsetHeight := 0
-		periodicGrowth := 0
-		remainderGrowth := 0
-		for i := 0; i < (periodOffset + len(periodic)); i++ {
-			chamber.SpawnPiece()
-			if i == (periodOffset - 1) {
-				offsetHeight = chamber.TowerHeight
-			} else if i == (periodOffset-1)+lastPeriodRemainder {
-				remainderGrowth = chamber.TowerHeight - offsetHeight
-			} else if i == (periodOffset + len(periodic) - 1) {
-				periodicGrowth = chamber.TowerHeight - offsetHeight
+		if allMatch {
+			foundPeriod = true
+		}
+		periodic = append([]int{heightGains[i]}, periodic...)
+		i--
+	}
+	if !foundPeriod {
+		return 0, fmt.Errorf("tower height doesn't grow periodically in the first %d rocks", initialRuns)
+	}
+
+	// Find offset
+	periodOffset := -1
+	foundOffset := false
+	for !foundOffset {
+		periodOffset++
+		foundOffset = true
+		for i := range periodic {
+			if heightGains[periodOffset+i] != periodic[i] {
+				foundOffset = false
+				break
 			}
 		}
-		return offsetHeight + (periodicGrowth * periodicRuns) + remainderGrowth
 	}
+	periodicRuns := (rocks - periodOffset) / len(periodic)
+	lastPeriodRemainder := (rocks - periodOffset) - (periodicRuns * len(periodic))
+	tracer.Info("period found", tracing.F("offset", periodOffset), tracing.F("period", len(periodic)), tracing.F("periods", periodicRuns), tracing.F("remainder", lastPeriodRemainder))
+	// The jet pattern was already checked
+	chamber, _ = NewChamber(windJets)
+	offsetHeight := 0
+	periodicGrowth := 0
+	remainderGrowth := 0
+	for i := 0; i < (periodOffset + len(periodic)); i++ {
+		chamber.SpawnPiece()
+		if i == (periodOffset - 1) {
+			offsetHeight = chamber.TowerHeight
+		} else if i == (periodOffset-1)+lastPeriodRemainder {
+			remainderGrowth = chamber.TowerHeight - offsetHeight
+		} else if i == (periodOffset + len(periodic) - 1) {
+			periodicGrowth = chamber.TowerHeight - offsetHeight
+		}
+	}
+	return offsetHeight + (periodicGrowth * periodicRuns) + remainderGrowth, nil
+}
+
+// runChallenge returns the desired output for the day's challenge.
+func runChallenge(ctx context.Context, challengePart int, input string) (int, error) {
+	return solve(ctx, challengePart, input, towerHeight)
+}
 
-	return -1
+// runHeightGains returns the same as runChallenge, but looks for a period in the height gains in part 2.
+func runHeightGains(ctx context.Context, challengePart int, input string) (int, error) {
+	return solve(ctx, challengePart, input, heightGainsTowerHeight)
 }
 
-func main() {
-	fmt.Println("Total height: ", runChallenge(2))
+// solve returns the output for the day's challenge, the height of the tower in part 2 is found with given function.
+func solve(ctx context.Context, challengePart int, input string, towerHeight func(ctx context.Context, windJets string, rocks int) (int, error)) (int, error) {
+	chamber, err := NewChamber(input)
+	if err != nil {
+		return 0, err
+	}
+	if challengePart == 1 {
+		for i := 0; i < challenge1Runs; i++ {
+			chamber.SpawnPiece()
+		}
+		return chamber.TowerHeight, nil
+	}
+	if challengePart == 2 {
+		return towerHeight(ctx, input, challenge2Runs)
+	}
+
+	return -1, nil
+}
+
+func init() {
+	aoc.Register(aoc.Puzzle{
+		Year:    2022,
+		Day:     17,
+		Title:   "Pyroclastic Flow",
+		Parts:   2,
+		Example: example,
+		Solver: aoc.SolverFunc(func(ctx context.Context, part int, input string) (any, error) {
+			return runChallenge(ctx, part, input)
+		}),
+		Implementations: map[string]aoc.Solver{
+			"height-gains": aoc.SolverFunc(func(ctx context.Context, part int, input string) (any, error) {
+				return runHeightGains(ctx, part, input)
+			}),
+		},
+	})
 }
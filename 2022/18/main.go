@@ -1,14 +1,20 @@
-package main
+package day18
 
 import (
+	"context"
 	_ "embed"
 	"fmt"
 	"strings"
+
+	"github.com/rubinda/aoc"
 )
 
+// maxCoordinate limits the droplet coordinates, the bucket they are placed in (part 2) has to fit into memory.
+const maxCoordinate = 127
+
 var (
 	//go:embed example.in
-	input string
+	example string
 	// Represents relative directions to neighbours in 3D space (6 degrees of freedom).
 	neighbours6DOF = []Voxel{
 		{1, 0, 0},
@@ -89,19 +95,26 @@ func (b *Bucket) SetMaterial(p Voxel, material int) {
 }
 
 // ParseDroplets reads a csv string into a list of lava droplets. Returns map with droplet coordinates as keys.
-func ParseDroplets(csvCoordinates string) map[Voxel]int {
+func ParseDroplets(csvCoordinates string) (map[Voxel]int, error) {
 	points := strings.Split(csvCoordinates, "\n")
 	occupiedSpaces := make(map[Voxel]int)
 	for i := range points {
 		droplet := Voxel{}
-		fmt.Sscanf(points[i], "%d,%d,%d", &droplet.x, &droplet.y, &droplet.z)
+		if _, err := fmt.Sscanf(points[i], "%d,%d,%d", &droplet.x, &droplet.y, &droplet.z); err != nil {
+			return nil, aoc.LineError(i, points[i], err)
+		}
+		for _, c := range []int{droplet.x, droplet.y, droplet.z} {
+			if c < 0 || c > maxCoordinate {
+				return nil, aoc.LineError(i, points[i], fmt.Errorf("coordinates have to be between 0 and %d", maxCoordinate))
+			}
+		}
 		// For bucket filling, we want water to flow beneath the droplets, so any coordinate 0 should move to 1
 		droplet.x++
 		droplet.y++
 		droplet.z++
 		occupiedSpaces[droplet] = 1
 	}
-	return occupiedSpaces
+	return occupiedSpaces, nil
 }
 
 // FillBucket creates a new 3D bucket filled with Lava droplets at given positions.
@@ -150,8 +163,11 @@ func (bucket *Bucket) checkNeigboursForLava(position Voxel, airGaps *Stack) int
 }
 
 // runChallenge returns the desired output for the day's challenge.
-func runChallenge(challengePart int) int {
-	lavaDroplets := ParseDroplets(input)
+func runChallenge(challengePart int, input string) (int, error) {
+	lavaDroplets, err := ParseDroplets(input)
+	if err != nil {
+		return 0, err
+	}
 	surface := 0
 	if challengePart == 1 {
 		for droplet := range lavaDroplets {
@@ -165,7 +181,16 @@ func runChallenge(challengePart int) int {
 			surface += lavaDroplets[droplet]
 		}
 	} else if challengePart == 2 {
-		bucket := FillBucket(lavaDroplets, 25)
+		// Water has to flow around the droplets, so they have to fit inside the bucket with a layer to spare
+		size := 0
+		for droplet := range lavaDroplets {
+			for _, c := range []int{droplet.x, droplet.y, droplet.z} {
+				if c+2 > size {
+					size = c + 2
+				}
+			}
+		}
+		bucket := FillBucket(lavaDroplets, size)
 		unvisited := NewStack()
 		unvisited.Push(Voxel{0, 0, 0})
 		for unvisited.IsNotEmpty() {
@@ -173,9 +198,18 @@ func runChallenge(challengePart int) int {
 			surface += bucket.checkNeigboursForLava(current, unvisited)
 		}
 	}
-	return surface
+	return surface, nil
 }
 
-func main() {
-	fmt.Println(runChallenge(2))
+func init() {
+	aoc.Register(aoc.Puzzle{
+		Year:    2022,
+		Day:     18,
+		Title:   "Boiling Boulders",
+		Parts:   2,
+		Example: example,
+		Solver: aoc.SolverFunc(func(ctx context.Context, part int, input string) (any, error) {
+			return runChallenge(part, input)
+		}),
+	})
 }
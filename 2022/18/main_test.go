@@ -1,7 +1,9 @@
-package main
+package day18
 
 import (
 	"testing"
+
+	"github.com/rubinda/aoc/internal/aoctest"
 )
 
 const (
@@ -10,7 +12,10 @@ const (
 )
 
 func TestChallenge1(t *testing.T) {
-	actual := runChallenge(1)
+	actual, err := runChallenge(1, example)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if actual != expected1 {
 		t.Errorf("Wrong result! Expected: %v, actual: %v", expected1, actual)
@@ -18,20 +23,48 @@ func TestChallenge1(t *testing.T) {
 }
 
 func TestChallenge2(t *testing.T) {
-	actual := runChallenge(2)
+	actual, err := runChallenge(2, example)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if actual != expected2 {
 		t.Errorf("Wrong result! Expected: %v, actual: %v", expected2, actual)
 	}
 }
 
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 2022, 18)
+}
+
 func Benchmark1(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		runChallenge(1)
+		runChallenge(1, example)
 	}
 }
 func Benchmark2(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		runChallenge(2)
+		runChallenge(2, example)
 	}
 }
+
+func FuzzParseDroplets(f *testing.F) {
+	f.Add(example)
+	f.Fuzz(func(t *testing.T, input string) {
+		var droplets map[Voxel]int
+		if aoctest.Parses(t, func() (err error) {
+			droplets, err = ParseDroplets(input)
+			return err
+		}) != nil {
+			return
+		}
+		// Droplets have to leave a layer of water around them in the bucket
+		for v := range droplets {
+			for _, c := range []int{v.x, v.y, v.z} {
+				if c < 1 || c > maxCoordinate+1 {
+					t.Errorf("Droplet %+v doesn't fit the bucket", v)
+				}
+			}
+		}
+	})
+}
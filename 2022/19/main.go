@@ -0,0 +1,200 @@
+package day19
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/rubinda/aoc"
+)
+
+//go:embed example.in
+var example string
+
+// Resources collected by robots (and the type of robot collecting it).
+const (
+	Ore = iota
+	Clay
+	Obsidian
+	Geode
+	resourceCount
+)
+
+const (
+	// challenge1Minutes is the time to evaluate each blueprint in part 1.
+	challenge1Minutes = 24
+	// challenge2Minutes is the time to evaluate the remaining blueprints in part 2.
+	challenge2Minutes = 32
+	// challenge2Blueprints is the number of blueprints the elephants didn't eat.
+	challenge2Blueprints = 3
+)
+
+// Resources holds an amount (of resources or robots) for each resource type.
+type Resources [resourceCount]int
+
+// Blueprint describes the robots a factory can build.
+type Blueprint struct {
+	ID int
+	// Costs holds the resources needed to build each type of robot.
+	Costs [resourceCount]Resources
+	// maxUseful is the most robots of a type worth having. The factory builds a single robot per minute,
+	// so collecting more than the most expensive robot costs is a waste.
+	maxUseful Resources
+}
+
+// ParseBlueprint reads a blueprint description.
+func ParseBlueprint(blueprintDesc string) (Blueprint, error) {
+	b := Blueprint{}
+	_, err := fmt.Sscanf(blueprintDesc,
+		"Blueprint %d: Each ore robot costs %d ore. Each clay robot costs %d ore. Each obsidian robot costs %d ore and %d clay. Each geode robot costs %d ore and %d obsidian.",
+		&b.ID, &b.Costs[Ore][Ore], &b.Costs[Clay][Ore], &b.Costs[Obsidian][Ore], &b.Costs[Obsidian][Clay], &b.Costs[Geode][Ore], &b.Costs[Geode][Obsidian])
+	if err != nil {
+		return Blueprint{}, err
+	}
+	for _, cost := range b.Costs {
+		for resource, amount := range cost {
+			if amount < 0 {
+				return Blueprint{}, errors.New("costs can't be negative")
+			}
+			if amount > b.maxUseful[resource] {
+				b.maxUseful[resource] = amount
+			}
+		}
+	}
+	return b, nil
+}
+
+// factory is the state of the robot factory at a point in time.
+type factory struct {
+	minutesLeft int
+	robots      Resources
+	stock       Resources
+}
+
+// waitFor returns how many minutes the factory has to collect resources before it can build the robot.
+// Returns false if no robots collect a resource the robot needs.
+func (b *Blueprint) waitFor(robot int, f factory) (int, bool) {
+	wait := 0
+	for resource, cost := range b.Costs[robot] {
+		missing := cost - f.stock[resource]
+		if missing <= 0 {
+			continue
+		}
+		if f.robots[resource] == 0 {
+			return 0, false
+		}
+		// Round up, a partial minute of collecting doesn't exist
+		if minutes := (missing + f.robots[resource] - 1) / f.robots[resource]; minutes > wait {
+			wait = minutes
+		}
+	}
+	return wait, true
+}
+
+// MaxGeodes returns the most geodes the blueprint can open in given minutes, starting with one ore robot.
+// The search stops with ctx's error once ctx is done.
+func (b *Blueprint) MaxGeodes(ctx context.Context, minutes int) (int, error) {
+	most := 0
+	b.search(factory{minutesLeft: minutes, robots: Resources{Ore: 1}}, &most, ctx.Done())
+	return most, ctx.Err()
+}
+
+// search decides which robot to build next (branch and bound) and updates the most geodes found so far.
+// It returns early once done is closed.
+func (b *Blueprint) search(f factory, most *int, done <-chan struct{}) {
+	select {
+	case <-done:
+		return
+	default:
+	}
+	// Geodes opened if no more robots get built
+	geodes := f.stock[Geode] + f.robots[Geode]*f.minutesLeft
+	if geodes > *most {
+		*most = geodes
+	}
+	// Even a new geode robot every remaining minute couldn't beat the best so far
+	if geodes+f.minutesLeft*(f.minutesLeft-1)/2 <= *most {
+		return
+	}
+	// Trying geode robots first finds good solutions early, which makes the bound prune more
+	for robot := Geode; robot >= Ore; robot-- {
+		if robot != Geode && f.robots[robot] >= b.maxUseful[robot] {
+			continue
+		}
+		wait, possible := b.waitFor(robot, f)
+		// Robot has to be ready at least a minute before the end to collect anything
+		if !possible || f.minutesLeft-wait-1 <= 0 {
+			continue
+		}
+		next := factory{minutesLeft: f.minutesLeft - wait - 1, robots: f.robots}
+		for resource := range next.stock {
+			next.stock[resource] = f.stock[resource] + f.robots[resource]*(wait+1) - b.Costs[robot][resource]
+		}
+		next.robots[robot]++
+		b.search(next, most, done)
+	}
+}
+
+// evaluate returns the most geodes each blueprint can open in given minutes. Blueprints are evaluated concurrently.
+func evaluate(ctx context.Context, blueprints []Blueprint, minutes int) ([]int, error) {
+	geodes := make([]int, len(blueprints))
+	var wg sync.WaitGroup
+	for i := range blueprints {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// A cancelled search is reported by ctx below
+			geodes[i], _ = blueprints[i].MaxGeodes(ctx, minutes)
+		}(i)
+	}
+	wg.Wait()
+	return geodes, ctx.Err()
+}
+
+// runChallenge returns the desired output for the day's challenge.
+func runChallenge(ctx context.Context, challengePart int, input string) (int, error) {
+	blueprints, err := aoc.ParseLines(input, ParseBlueprint)
+	if err != nil {
+		return 0, err
+	}
+	if challengePart == 1 {
+		evaluated, err := evaluate(ctx, blueprints, challenge1Minutes)
+		if err != nil {
+			return 0, err
+		}
+		qualityLevels := 0
+		for i, geodes := range evaluated {
+			qualityLevels += blueprints[i].ID * geodes
+		}
+		return qualityLevels, nil
+	} else if challengePart == 2 {
+		if len(blueprints) > challenge2Blueprints {
+			blueprints = blueprints[:challenge2Blueprints]
+		}
+		evaluated, err := evaluate(ctx, blueprints, challenge2Minutes)
+		if err != nil {
+			return 0, err
+		}
+		product := 1
+		for _, geodes := range evaluated {
+			product *= geodes
+		}
+		return product, nil
+	}
+	return -1, nil
+}
+
+func init() {
+	aoc.Register(aoc.Puzzle{
+		Year:    2022,
+		Day:     19,
+		Title:   "Not Enough Minerals",
+		Parts:   2,
+		Example: example,
+		Solver: aoc.SolverFunc(func(ctx context.Context, part int, input string) (any, error) {
+			return runChallenge(ctx, part, input)
+		}),
+	})
+}
@@ -0,0 +1,73 @@
+package day19
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/rubinda/aoc/internal/aoctest"
+)
+
+const (
+	expected1 = 33
+	expected2 = 56 * 62
+)
+
+func TestChallenge1(t *testing.T) {
+	actual, err := runChallenge(context.Background(), 1, example)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if actual != expected1 {
+		t.Errorf("Wrong result! Expected: %v, actual: %v", expected1, actual)
+	}
+}
+
+func TestChallenge2(t *testing.T) {
+	actual, err := runChallenge(context.Background(), 2, example)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if actual != expected2 {
+		t.Errorf("Wrong result! Expected: %v, actual: %v", expected2, actual)
+	}
+}
+
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 2022, 19)
+}
+
+func Benchmark1(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		runChallenge(context.Background(), 1, example)
+	}
+}
+func Benchmark2(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		runChallenge(context.Background(), 2, example)
+	}
+}
+
+func FuzzParseBlueprint(f *testing.F) {
+	for _, line := range strings.Split(example, "\n") {
+		f.Add(line)
+	}
+	f.Fuzz(func(t *testing.T, line string) {
+		var b Blueprint
+		if aoctest.Parses(t, func() (err error) {
+			b, err = ParseBlueprint(line)
+			return err
+		}) != nil {
+			return
+		}
+		for robot, cost := range b.Costs {
+			for resource, amount := range cost {
+				if amount < 0 || amount > b.maxUseful[resource] {
+					t.Errorf("Robot %d costs %d of resource %d, at most %d is useful", robot, amount, resource, b.maxUseful[resource])
+				}
+			}
+		}
+	})
+}
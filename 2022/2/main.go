@@ -1,13 +1,16 @@
-package main
+package day2
 
 import (
+	"context"
 	_ "embed"
 	"fmt"
 	"strings"
+
+	"github.com/rubinda/aoc"
 )
 
 //go:embed example.in
-var input string
+var example string
 
 type ChallengeMode int64
 
@@ -65,10 +68,21 @@ var outcomeMatrix = map[string]int{
 	scissorsOp + loss: objectValue[paper],
 }
 
-func runChallenge(challengePart int) int {
+// checkMove makes sure the strategy guide line is an opponent's shape and your column, e.g. "A Y".
+func checkMove(move string) error {
+	if len(move) != 3 || move[1] != ' ' || !strings.ContainsRune("ABC", rune(move[0])) || !strings.ContainsRune("XYZ", rune(move[2])) {
+		return fmt.Errorf("expected a move like \"A Y\"")
+	}
+	return nil
+}
+
+func runChallenge(challengePart int, input string) (int, error) {
 	scoreSum := 0
 	playPlan := strings.Split(input, "\n")
-	for _, move := range playPlan {
+	for i, move := range playPlan {
+		if err := checkMove(move); err != nil {
+			return 0, aoc.LineError(i, move, err)
+		}
 		mine := string(move[2])
 		if challengePart == 1 {
 			scoreSum += objectMatrix[move] + objectValue[mine]
@@ -76,10 +90,18 @@ func runChallenge(challengePart int) int {
 			scoreSum += outcomeMatrix[move] + outcomeValue[mine]
 		}
 	}
-	return scoreSum
+	return scoreSum, nil
 }
 
-func main() {
-	highscore := runChallenge(2)
-	fmt.Println(highscore)
+func init() {
+	aoc.Register(aoc.Puzzle{
+		Year:    2022,
+		Day:     2,
+		Title:   "Rock Paper Scissors",
+		Parts:   2,
+		Example: example,
+		Solver: aoc.SolverFunc(func(ctx context.Context, part int, input string) (any, error) {
+			return runChallenge(part, input)
+		}),
+	})
 }
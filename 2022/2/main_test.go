@@ -1,12 +1,20 @@
-package main
+package day2
 
-import "testing"
+import (
+	"strings"
+	"testing"
+
+	"github.com/rubinda/aoc/internal/aoctest"
+)
 
 const expected1 = 15
 const expected2 = 12
 
 func TestChallenge1(t *testing.T) {
-	actual := runChallenge(1)
+	actual, err := runChallenge(1, example)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if actual != expected1 {
 		t.Errorf("Wrong result! Expected: %d, actual: %d", expected1, actual)
@@ -14,15 +22,39 @@ func TestChallenge1(t *testing.T) {
 }
 
 func TestChallenge2(t *testing.T) {
-	actual := runChallenge(2)
+	actual, err := runChallenge(2, example)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if actual != expected2 {
 		t.Errorf("Wrong result! Expected: %d, actual: %d", expected2, actual)
 	}
 }
 
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 2022, 2)
+}
+
 func Benchmark2(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		runChallenge(2)
+		runChallenge(2, example)
+	}
+}
+
+func FuzzCheckMove(f *testing.F) {
+	for _, move := range strings.Split(example, "\n") {
+		f.Add(move)
 	}
+	f.Fuzz(func(t *testing.T, move string) {
+		if aoctest.Parses(t, func() error { return checkMove(move) }) != nil {
+			return
+		}
+		if _, ok := objectMatrix[move]; !ok {
+			t.Errorf("Accepted move %q has no score", move)
+		}
+		if _, ok := outcomeMatrix[move]; !ok {
+			t.Errorf("Accepted move %q has no outcome", move)
+		}
+	})
 }
@@ -1,15 +1,19 @@
-package main
+package day20
 
 import (
+	"context"
 	_ "embed"
+	"errors"
 	"fmt"
+	"math"
 	"strconv"
-	"strings"
+
+	"github.com/rubinda/aoc"
 )
 
 var (
 	//go:embed example.in
-	input string
+	example string
 )
 
 const (
@@ -121,22 +125,140 @@ func CreateLinkedList(numbers []int) (*DoublyLinkedList, []*ListItem) {
 }
 
 // parseInput takes the challenge input and converts it to a list of integers.
-func parseInput(encrypted string) []int {
-	numbersStr := strings.Split(encrypted, "\n")
-	numbers := make([]int, len(numbersStr))
-	var err error
-	for n := range numbersStr {
-		numbers[n], err = strconv.Atoi(numbersStr[n])
-		if err != nil {
-			panic(err)
+func parseInput(encrypted string) ([]int, error) {
+	numbers, err := aoc.ParseLines(encrypted, strconv.Atoi)
+	if err != nil {
+		return nil, err
+	}
+	if len(numbers) < 2 {
+		return nil, errors.New("need at least 2 numbers to mix")
+	}
+	for _, n := range numbers {
+		if n == 0 {
+			return numbers, nil
 		}
 	}
+	return nil, errors.New("grove coordinates are counted from 0, which is missing")
+}
+
+// Numbers returns the values in the list, starting with the original head.
+func (dll *DoublyLinkedList) Numbers() []int {
+	numbers := make([]int, 0, dll.Len)
+	current := dll.Head
+	for i := 0; i < dll.Len; i++ {
+		numbers = append(numbers, current.Value)
+		current = current.Next
+	}
 	return numbers
 }
 
+// mixLinked mixes the numbers given times by moving items of a linked list step by step.
+func mixLinked(ctx context.Context, numbers []int, mixings int) ([]int, error) {
+	linked, originalPositions := CreateLinkedList(numbers)
+	for i := 0; i < mixings; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		for i := range originalPositions {
+			item := originalPositions[i]
+			linked.Move(item, item.Value)
+		}
+	}
+	return linked.Numbers(), nil
+}
+
+// block is a part of the mixed list, holding the original indices of its numbers.
+type block struct {
+	indices []int
+}
+
+// mixBlocks mixes the numbers given times like mixLinked. The list is split into blocks of about sqrt(n) numbers,
+// so finding where a number is and moving it walks the blocks and a single block instead of the whole list.
+func mixBlocks(ctx context.Context, numbers []int, mixings int) ([]int, error) {
+	n := len(numbers)
+	blockSize := int(math.Sqrt(float64(n))) + 1
+	blocks := make([]*block, 0, n/blockSize+1)
+	// blockOf holds the block each number (by original index) is in
+	blockOf := make([]*block, n)
+	for i := range numbers {
+		if i%blockSize == 0 {
+			blocks = append(blocks, &block{})
+		}
+		b := blocks[len(blocks)-1]
+		b.indices = append(b.indices, i)
+		blockOf[i] = b
+	}
+
+	for m := 0; m < mixings; m++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		for i, value := range numbers {
+			// Take the number out, counting how many come before it
+			position := 0
+			for _, b := range blocks {
+				if b == blockOf[i] {
+					break
+				}
+				position += len(b.indices)
+			}
+			b := blockOf[i]
+			j := 0
+			for b.indices[j] != i {
+				j++
+			}
+			position += j
+			b.indices = append(b.indices[:j], b.indices[j+1:]...)
+
+			// Put it back where it ends up in the circular list without it
+			position = ((position+value)%(n-1) + (n - 1)) % (n - 1)
+			k := 0
+			for k < len(blocks)-1 && position > len(blocks[k].indices) {
+				position -= len(blocks[k].indices)
+				k++
+			}
+			b = blocks[k]
+			b.indices = append(b.indices, 0)
+			copy(b.indices[position+1:], b.indices[position:])
+			b.indices[position] = i
+			blockOf[i] = b
+			if len(b.indices) > 2*blockSize {
+				// Split blocks that grew too big
+				half := &block{indices: append([]int(nil), b.indices[blockSize:]...)}
+				b.indices = b.indices[:blockSize]
+				for _, index := range half.indices {
+					blockOf[index] = half
+				}
+				blocks = append(blocks[:k+1], append([]*block{half}, blocks[k+1:]...)...)
+			}
+		}
+	}
+
+	mixed := make([]int, 0, n)
+	for _, b := range blocks {
+		for _, index := range b.indices {
+			mixed = append(mixed, numbers[index])
+		}
+	}
+	return mixed, nil
+}
+
 // runChallenge returns the desired output for the day's challenge.
-func runChallenge(challengePart int) int {
-	numbers := parseInput(input)
+func runChallenge(ctx context.Context, challengePart int, input string) (int, error) {
+	return solve(ctx, challengePart, input, mixBlocks)
+}
+
+// runLinked returns the same as runChallenge, but mixes the numbers in a linked list.
+func runLinked(ctx context.Context, challengePart int, input string) (int, error) {
+	return solve(ctx, challengePart, input, mixLinked)
+}
+
+// solve returns the output for the day's challenge, the numbers are mixed with given function.
+func solve(ctx context.Context, challengePart int, input string, mix func(ctx context.Context, numbers []int, mixings int) ([]int, error)) (int, error) {
+	numbers, err := parseInput(input)
+	if err != nil {
+		return 0, err
+	}
 	mixings := Mixings_1
 	if challengePart == 2 {
 		// Part 2 has more runs and LARGER numbers (which we normalize later)
@@ -145,27 +267,36 @@ func runChallenge(challengePart int) int {
 			numbers[i] *= decryptionKeyMultiplier
 		}
 	}
-	linked, originalPositions := CreateLinkedList(numbers)
-	for i := 0; i < mixings; i++ {
-		for i := range originalPositions {
-			item := originalPositions[i]
-			linked.Move(item, item.Value)
-		}
+	mixed, err := mix(ctx, numbers, mixings)
+	if err != nil {
+		return 0, err
+	}
+	// The challenge output is the sum of 3 items which are [1000,2000,3000] steps away from the 0
+	zero := 0
+	for mixed[zero] != 0 {
+		zero++
 	}
-	// The challenge output is the sum of 3 items which are [1000,2000,3000] steps away from ZeroItem
 	groveCoordinatesSum := 0
-	steps := 3000
-	item := linked.ZeroItem
-	for steps > 0 {
-		item = item.Next
-		steps--
-		if steps == 2000 || steps == 1000 || steps == 0 {
-			groveCoordinatesSum += item.Value
-		}
+	for _, steps := range []int{1000, 2000, 3000} {
+		groveCoordinatesSum += mixed[(zero+steps)%len(mixed)]
 	}
-	return groveCoordinatesSum
+	return groveCoordinatesSum, nil
 }
 
-func main() {
-	fmt.Println(runChallenge(2))
+func init() {
+	aoc.Register(aoc.Puzzle{
+		Year:    2022,
+		Day:     20,
+		Title:   "Grove Positioning System",
+		Parts:   2,
+		Example: example,
+		Solver: aoc.SolverFunc(func(ctx context.Context, part int, input string) (any, error) {
+			return runChallenge(ctx, part, input)
+		}),
+		Implementations: map[string]aoc.Solver{
+			"linked": aoc.SolverFunc(func(ctx context.Context, part int, input string) (any, error) {
+				return runLinked(ctx, part, input)
+			}),
+		},
+	})
 }
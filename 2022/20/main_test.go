@@ -1,7 +1,11 @@
-package main
+package day20
 
 import (
+	"context"
 	"testing"
+
+	_ "github.com/rubinda/aoc/gen/2022"
+	"github.com/rubinda/aoc/internal/aoctest"
 )
 
 const (
@@ -10,7 +14,10 @@ const (
 )
 
 func TestChallenge1(t *testing.T) {
-	actual := runChallenge(1)
+	actual, err := runChallenge(context.Background(), 1, example)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if actual != expected1 {
 		t.Errorf("Wrong result! Expected: %v, actual: %v", expected1, actual)
@@ -18,20 +25,54 @@ func TestChallenge1(t *testing.T) {
 }
 
 func TestChallenge2(t *testing.T) {
-	actual := runChallenge(2)
+	actual, err := runChallenge(context.Background(), 2, example)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if actual != expected2 {
 		t.Errorf("Wrong result! Expected: %v, actual: %v", expected2, actual)
 	}
 }
 
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 2022, 20)
+}
+
+func TestImplementations(t *testing.T) {
+	// Lengths dividing 1000 (sizes 0.01 and 0.1) would put all grove coordinates on the 0, answering 0 every time
+	aoctest.Differential(t, 2022, 20, 3, 0.0123, 0.137)
+}
+
 func Benchmark1(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		runChallenge(1)
+		runChallenge(context.Background(), 1, example)
 	}
 }
 func Benchmark2(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		runChallenge(2)
+		runChallenge(context.Background(), 2, example)
 	}
 }
+
+func FuzzParseInput(f *testing.F) {
+	f.Add(example)
+	f.Fuzz(func(t *testing.T, input string) {
+		var numbers []int
+		if aoctest.Parses(t, func() (err error) {
+			numbers, err = parseInput(input)
+			return err
+		}) != nil {
+			return
+		}
+		zeros := 0
+		for _, n := range numbers {
+			if n == 0 {
+				zeros++
+			}
+		}
+		if len(numbers) < 2 || zeros == 0 {
+			t.Errorf("Can't mix %v", numbers)
+		}
+	})
+}
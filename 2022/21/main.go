@@ -1,15 +1,22 @@
-package main
+package day21
 
 import (
+	"context"
 	_ "embed"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
+
+	"github.com/rubinda/aoc"
+	"github.com/rubinda/aoc/internal/tracing"
 )
 
 var (
 	//go:embed example.in
-	input string
+	example string
+	// tracer reports the monkeys' equations rewritten to solve for humn (see aoc run --trace).
+	tracer = tracing.For(2022, 21)
 )
 
 // Operations between two Monkey Number fields
@@ -66,6 +73,14 @@ type Monkey struct {
 	DependsOperation string
 }
 
+// Equation returns the monkey's job, e.g. "pppw = cczh / lfgf" or "humn = 5".
+func (m *Monkey) Equation() string {
+	if m.HasNumber || len(m.DependsOn) < 2 {
+		return fmt.Sprintf("%s = %d", m.Name, m.NumberYelled)
+	}
+	return fmt.Sprintf("%s = %s %s %s", m.Name, m.DependsOn[0], m.DependsOperation, m.DependsOn[1])
+}
+
 // GetNumberYelled returns monkey's number or calculates it based on dependencies.
 func (m *Monkey) GetNumberYelled(allMonkeys map[string]*Monkey) int {
 	if m.HasNumber {
@@ -116,35 +131,84 @@ func (m *Monkey) RewriteDependency(extractedDependant string, allMonkeys map[str
 }
 
 // NewMonkey creates a new Monkey struct from challenge input line.
-func NewMonkey(monkeyDesc string) *Monkey {
+func NewMonkey(monkeyDesc string) (*Monkey, error) {
 	monkey := &Monkey{}
-	parts := strings.Split(monkeyDesc, ": ")
-	monkey.Name = parts[0]
+	name, job, found := strings.Cut(monkeyDesc, ": ")
+	if !found {
+		return nil, errors.New("expected a monkey name followed by ': '")
+	}
+	monkey.Name = name
 
-	if n, err := strconv.Atoi(parts[1]); err == nil {
+	if n, err := strconv.Atoi(job); err == nil {
 		monkey.NumberYelled = n
 		monkey.HasNumber = true
 	} else {
-		dependsOn := strings.Fields(parts[1])
+		dependsOn := strings.Fields(job)
 
 		if len(dependsOn) != 3 {
-			panic(fmt.Sprintf("Expected operation between 2 monkeys if monkey number isn't already given (given %v)", parts[1]))
+			return nil, fmt.Errorf("expected operation between 2 monkeys if monkey number isn't already given (given %v)", job)
+		}
+		switch dependsOn[1] {
+		case operationAdd, operationSubract, operationDivide, operationMultiply:
+		default:
+			return nil, fmt.Errorf("unrecognized operation {%s}", dependsOn[1])
 		}
 		monkey.DependsOn = []string{dependsOn[0], dependsOn[2]}
 		monkey.DependsOperation = dependsOn[1]
 	}
-	return monkey
+	return monkey, nil
 }
 
 // ParseMonkeys returns a map of monkeyName pointing to Monkey pointer
-func ParseMonkeys(challengeInput string) map[string]*Monkey {
+func ParseMonkeys(challengeInput string) (map[string]*Monkey, error) {
 	monkeys := make(map[string]*Monkey)
 	monkeyDescs := strings.Split(challengeInput, "\n")
-	for _, monkeyDesc := range monkeyDescs {
-		m := NewMonkey(monkeyDesc)
+	for i, monkeyDesc := range monkeyDescs {
+		m, err := NewMonkey(monkeyDesc)
+		if err != nil {
+			return nil, aoc.LineError(i, monkeyDesc, err)
+		}
+		if _, exists := monkeys[m.Name]; exists {
+			return nil, aoc.LineError(i, monkeyDesc, fmt.Errorf("monkey %s already yells", m.Name))
+		}
 		monkeys[m.Name] = m
 	}
-	return monkeys
+	for _, m := range monkeys {
+		for _, name := range m.DependsOn {
+			if _, exists := monkeys[name]; !exists {
+				return nil, fmt.Errorf("monkey %s waits for monkey %s, who doesn't exist", m.Name, name)
+			}
+		}
+	}
+	if root, exists := monkeys[wantedMonkeyName]; !exists || root.HasNumber {
+		return nil, fmt.Errorf("monkey %s has to wait for 2 other monkeys", wantedMonkeyName)
+	}
+	// Monkeys waiting on each other in a circle would never yell
+	waiting := make(map[string]bool)
+	yelled := make(map[string]bool)
+	var listen func(name string) error
+	listen = func(name string) error {
+		if yelled[name] {
+			return nil
+		}
+		if waiting[name] {
+			return fmt.Errorf("monkey %s ends up waiting for itself", name)
+		}
+		waiting[name] = true
+		for _, dependency := range monkeys[name].DependsOn {
+			if err := listen(dependency); err != nil {
+				return err
+			}
+		}
+		yelled[name] = true
+		return nil
+	}
+	for name := range monkeys {
+		if err := listen(name); err != nil {
+			return nil, err
+		}
+	}
+	return monkeys, nil
 }
 
 // findDependentMonkey returns the monkey who is dependent on monkeyName.
@@ -161,10 +225,13 @@ func findDependentMonkey(monkeyName string, allMonkeys map[string]*Monkey) *Monk
 }
 
 // runChallenge returns the desired output for the day's challenge.
-func runChallenge(challengePart int) int {
-	monkeys := ParseMonkeys(input)
+func runChallenge(challengePart int, input string) (int, error) {
+	monkeys, err := ParseMonkeys(input)
+	if err != nil {
+		return 0, err
+	}
 	if challengePart == 1 {
-		return monkeys[wantedMonkeyName].GetNumberYelled(monkeys)
+		return monkeys[wantedMonkeyName].GetNumberYelled(monkeys), nil
 	}
 	if challengePart == 2 {
 		// The process on example.in
@@ -176,16 +243,27 @@ func runChallenge(challengePart int) int {
 		zer0 := &Monkey{Name: "zer0", HasNumber: true, NumberYelled: 0}
 		monkeys[zer0.Name] = zer0
 		rewrittenName := myMonkeyName
+		if _, exists := monkeys[myMonkeyName]; !exists {
+			return 0, fmt.Errorf("missing monkey %s (that's you)", myMonkeyName)
+		}
 		dependant := findDependentMonkey(rewrittenName, monkeys)
-		for dependant.Name != wantedMonkeyName {
+		for dependant != nil && dependant.Name != wantedMonkeyName {
 			// Since "rewrittenName" will now also depend on dependant, we look for the original dependant beforehand
 			nextDependant := findDependentMonkey(dependant.Name, monkeys)
-			// fmt.Printf("%s = %s %s %s \n", dependant.Name, dependant.DependsOn[0], dependant.DependsOperation, dependant.DependsOn[1])
+			before := ""
+			if tracer.Enabled(tracing.Debug) {
+				before = dependant.Equation()
+			}
 			dependant.RewriteDependency(rewrittenName, monkeys)
-			// fmt.Printf("  => %s = %s %s %s \n", rewrittenName, monkeys[rewrittenName].DependsOn[0], monkeys[rewrittenName].DependsOperation, monkeys[rewrittenName].DependsOn[1])
+			if tracer.Enabled(tracing.Debug) {
+				tracer.Debug("monkey equation rewritten", tracing.F("from", before), tracing.F("to", monkeys[rewrittenName].Equation()))
+			}
 			rewrittenName = dependant.Name
 			dependant = nextDependant
 		}
+		if dependant == nil {
+			return 0, fmt.Errorf("monkey %s never hears what %s yells", wantedMonkeyName, myMonkeyName)
+		}
 		// Rewrite one of the dependants of root, so that
 		// root = rewrittenName = dependantB => rewrittenName = dependantB + zer0
 		rootMonkey := monkeys[wantedMonkeyName]
@@ -197,12 +275,21 @@ func runChallenge(challengePart int) int {
 		} else if rootMonkey.DependsOn[1] == rewrittenName {
 			lastRewrite.DependsOn[1] = rootMonkey.DependsOn[0]
 		}
-		// fmt.Printf("  => %s = %s %s %s \n", lastRewrite.Name, lastRewrite.DependsOn[0], lastRewrite.DependsOperation, lastRewrite.DependsOn[1])
-		return monkeys[myMonkeyName].GetNumberYelled(monkeys)
+		tracer.Info("root equation rewritten", tracing.F("to", lastRewrite.Equation()))
+		return monkeys[myMonkeyName].GetNumberYelled(monkeys), nil
 	}
-	return -1
+	return -1, nil
 }
 
-func main() {
-	fmt.Println(runChallenge(2))
+func init() {
+	aoc.Register(aoc.Puzzle{
+		Year:    2022,
+		Day:     21,
+		Title:   "Monkey Math",
+		Parts:   2,
+		Example: example,
+		Solver: aoc.SolverFunc(func(ctx context.Context, part int, input string) (any, error) {
+			return runChallenge(part, input)
+		}),
+	})
 }
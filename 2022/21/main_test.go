@@ -1,7 +1,9 @@
-package main
+package day21
 
 import (
 	"testing"
+
+	"github.com/rubinda/aoc/internal/aoctest"
 )
 
 const (
@@ -10,7 +12,10 @@ const (
 )
 
 func TestChallenge1(t *testing.T) {
-	actual := runChallenge(1)
+	actual, err := runChallenge(1, example)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if actual != expected1 {
 		t.Errorf("Wrong result! Expected: %v, actual: %v", expected1, actual)
@@ -18,20 +23,53 @@ func TestChallenge1(t *testing.T) {
 }
 
 func TestChallenge2(t *testing.T) {
-	actual := runChallenge(2)
+	actual, err := runChallenge(2, example)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if actual != expected2 {
 		t.Errorf("Wrong result! Expected: %v, actual: %v", expected2, actual)
 	}
 }
 
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 2022, 21)
+}
+
 func Benchmark1(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		runChallenge(1)
+		runChallenge(1, example)
 	}
 }
 func Benchmark2(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		runChallenge(2)
+		runChallenge(2, example)
 	}
 }
+
+func FuzzParseMonkeys(f *testing.F) {
+	f.Add(example)
+	f.Fuzz(func(t *testing.T, input string) {
+		var monkeys map[string]*Monkey
+		if aoctest.Parses(t, func() (err error) {
+			monkeys, err = ParseMonkeys(input)
+			return err
+		}) != nil {
+			return
+		}
+		if root, ok := monkeys[wantedMonkeyName]; !ok || root.HasNumber {
+			t.Errorf("Monkey %s has to wait for others", wantedMonkeyName)
+		}
+		for _, m := range monkeys {
+			if !m.HasNumber && len(m.DependsOn) != 2 {
+				t.Errorf("Monkey %s waits for %v", m.Name, m.DependsOn)
+			}
+			for _, name := range m.DependsOn {
+				if _, ok := monkeys[name]; !ok {
+					t.Errorf("Monkey %s waits for unknown monkey %s", m.Name, name)
+				}
+			}
+		}
+	})
+}
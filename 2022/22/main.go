@@ -1,14 +1,27 @@
-package main
+package day22
 
 import (
+	"context"
 	_ "embed"
+	"errors"
 	"fmt"
+	"math"
+	"regexp"
+	"strconv"
 	"strings"
+
+	"github.com/rubinda/aoc"
+	"github.com/rubinda/aoc/internal/tracing"
 )
 
 var (
 	//go:embed example.in
-	input string
+	example string
+	// example2 is the example cut into the same cube net as the challenge input (needed by the cube map in part 2).
+	//go:embed example2.in
+	example2 string
+	// tracer reports the moves along the path, walls hit and cube sides left (see aoc run --trace).
+	tracer = tracing.For(2022, 22)
 	// sortedFacing provides a (index) score for each turn direction and helps turn direction determination.
 	sortedFacing = []string{faceRight, faceDown, faceLeft, faceUp}
 	// facingDirection provides relative directional coordinates
@@ -18,23 +31,10 @@ var (
 		faceLeft:  {-1, 0},
 		faceUp:    {0, -1},
 	}
-
-	cubeMinBoundaries = []Position{
-		{0, 0},
-		{cubeSize, 0},
-		{0, cubeSize},
-		{0, cubeSize * 2},
-		{cubeSize, 2 * cubeSize},
-		{0, 3 * cubeSize},
-	}
-	cubeMaxBoundaries = []Position{
-		{cubeSize - 1, cubeSize - 1},
-		{2*cubeSize - 1, cubeSize - 1},
-		{cubeSize - 1, 2*cubeSize - 1},
-		{cubeSize - 1, 3*cubeSize - 1},
-		{2*cubeSize - 1, 3*cubeSize - 1},
-		{cubeSize - 1, 4*cubeSize - 1},
-	}
+	// pathPattern matches the path description: numbers of steps with turns in between.
+	pathPattern = regexp.MustCompile(`^\d+([` + turnClockwise + turnCounterClockwise + `]\d+)*$`)
+	// movementPattern matches a single movement in the path: steps with the turn after them (none for the last one).
+	movementPattern = regexp.MustCompile(`(\d+)([` + turnClockwise + turnCounterClockwise + `]?)`)
 )
 
 const (
@@ -68,8 +68,6 @@ const (
 	//     5566
 	//     5566
 	cubeMap = "MAP_CUBE"
-
-	cubeSize = 4
 )
 
 // Movement represents a move instruction
@@ -178,7 +176,9 @@ func (md *MonkeysDescription) canMove() (Position, bool) {
 	// Draw my steps onto map so it's easier to debug!
 
 	if md.tileAt(newPos) == solidTile {
-		// fmt.Printf("  (!) no move\n")
+		if tracer.Enabled(tracing.Debug) {
+			tracer.Debug("wall hit", tracing.F("x", newPos.X), tracing.F("y", newPos.Y))
+		}
 		return md.MyPosition, false
 	}
 	md.MyFacing = newFacing
@@ -196,7 +196,42 @@ func (md *MonkeysDescription) MovePlayer(move Movement) {
 	md.TurnPlayer(move.TurnDirection)
 }
 
-func calculateSide(currentPos Position) int {
+// cubeBoundaries returns the upper left and lower right corners of each cube side for given side length.
+func cubeBoundaries(cubeSize int) (minBoundaries, maxBoundaries []Position) {
+	minBoundaries = []Position{
+		{0, 0},
+		{cubeSize, 0},
+		{0, cubeSize},
+		{0, cubeSize * 2},
+		{cubeSize, 2 * cubeSize},
+		{0, 3 * cubeSize},
+	}
+	maxBoundaries = []Position{
+		{cubeSize - 1, cubeSize - 1},
+		{2*cubeSize - 1, cubeSize - 1},
+		{cubeSize - 1, 2*cubeSize - 1},
+		{cubeSize - 1, 3*cubeSize - 1},
+		{2*cubeSize - 1, 3*cubeSize - 1},
+		{cubeSize - 1, 4*cubeSize - 1},
+	}
+	return
+}
+
+// calculateCubeSize returns the side length of a cube from the number of tiles on its surface.
+func calculateCubeSize(tiles [][]string) int {
+	surface := 0
+	for y := range tiles {
+		for x := range tiles[y] {
+			if tiles[y][x] != voidTile {
+				surface++
+			}
+		}
+	}
+	return int(math.Sqrt(float64(surface / 6)))
+}
+
+// calculateSide returns the index of the cube side given position is on.
+func calculateSide(currentPos Position, cubeSize int) int {
 	currentSide := -1
 	if currentPos.Y >= 3*cubeSize {
 		currentSide = 5
@@ -216,6 +251,29 @@ func calculateSide(currentPos Position) int {
 	return currentSide
 }
 
+// checkCubeNet makes sure the tiles are folded into the only cube net the cube map knows how to walk (see cubeMap).
+func checkCubeNet(tiles [][]string, cubeSize int) error {
+	if cubeSize == 0 || len(tiles) != 4*cubeSize {
+		return fmt.Errorf("expected a cube net %d tiles high", 4*cubeSize)
+	}
+	for y := range tiles {
+		// Rows of sides 0 and 1 or 3 and 4 are twice as wide as the rows of sides 2 and 5
+		width := cubeSize
+		if calculateSide(Position{cubeSize, y}, cubeSize) != calculateSide(Position{0, y}, cubeSize) {
+			width = 2 * cubeSize
+		}
+		if len(tiles[y]) != width {
+			return aoc.LineError(y, strings.Join(tiles[y], ""), fmt.Errorf("expected %d tiles of the cube net, got %d", width, len(tiles[y])))
+		}
+		for x := range tiles[y] {
+			if tiles[y][x] == voidTile {
+				return aoc.LineError(y, strings.Join(tiles[y], ""), errors.New("cube net can't have holes"))
+			}
+		}
+	}
+	return nil
+}
+
 // String returns the textual map representation with player's last position marked with a facing marker.
 func (md *MonkeysDescription) String() string {
 	out := ""
@@ -233,28 +291,43 @@ func (md *MonkeysDescription) String() string {
 }
 
 // NewMonkeyDescription constructs a new map from Monkey's description.
-func NewMonkeyDescription(desc string, mapType string) *MonkeysDescription {
+func NewMonkeyDescription(desc string, mapType string) (*MonkeysDescription, error) {
 	monkeyDesc := &MonkeysDescription{
 		MyFacing: faceRight,
 	}
 
+	// Cube dimensions are known once the map is parsed
+	var cubeSize int
+	var cubeMinBoundaries, cubeMaxBoundaries []Position
+
 	switch mapType {
 	case flatMap:
 		monkeyDesc.StepperFunc = func(currentPos Position, facing string) (Position, string) {
-			return currentPos.add(relativeFacing[facing]), facing
+			newPos := currentPos.add(relativeFacing[facing])
+			if monkeyDesc.tileAt(newPos) != voidTile {
+				return newPos, facing
+			}
+			// Wrap around by walking in the opposite direction until the edge of the map
+			d := relativeFacing[facing]
+			back := Position{-d.X, -d.Y}
+			for monkeyDesc.tileAt(currentPos.add(back)) != voidTile {
+				currentPos = currentPos.add(back)
+			}
+			return currentPos, facing
 		}
 	case cubeMap:
 		monkeyDesc.StepperFunc = func(currentPos Position, facing string) (Position, string) {
-			currentSide := calculateSide(currentPos)
+			currentSide := calculateSide(currentPos, cubeSize)
 			if currentSide < 0 {
 				panic(fmt.Sprintf("Current side is <0 @ %v", currentPos))
 			}
 
-			// fmt.Printf(" on side (stepping '%s'): %d (%v) \n", facing, currentSide+1, currentPos)
 			naiveStep := currentPos.add(relativeFacing[facing])
 			if naiveStep.X < cubeMinBoundaries[currentSide].X || naiveStep.Y < cubeMinBoundaries[currentSide].Y || naiveStep.X > cubeMaxBoundaries[currentSide].X || naiveStep.Y > cubeMaxBoundaries[currentSide].Y {
 				// Move onto different surface -> can change facing there
-				// fmt.Printf("new side (from going '%s'): %v \n", facing, currentPos)
+				if tracer.Enabled(tracing.Debug) {
+					tracer.Debug("cube side left", tracing.F("side", currentSide+1), tracing.F("facing", facing), tracing.F("x", currentPos.X), tracing.F("y", currentPos.Y))
+				}
 				switch currentSide {
 				case 0:
 					switch facing {
@@ -333,6 +406,12 @@ func NewMonkeyDescription(desc string, mapType string) *MonkeysDescription {
 	monkeyDesc.Map = make([][]string, len(mapLines))
 	for y := range monkeyDesc.Map {
 		mapLine := mapLines[y]
+		if i := strings.IndexFunc(mapLine, func(r rune) bool {
+			tile := string(r)
+			return tile != openTile && tile != solidTile && tile != voidTile
+		}); i >= 0 {
+			return nil, aoc.LineError(y, mapLine, fmt.Errorf("unknown tile %q", mapLine[i]))
+		}
 		if mapType == cubeMap {
 			mapLine = strings.TrimSpace(mapLine)
 		}
@@ -340,59 +419,81 @@ func NewMonkeyDescription(desc string, mapType string) *MonkeysDescription {
 		monkeyDesc.Map[y] = make([]string, len(tiles))
 		copy(monkeyDesc.Map[y], tiles)
 	}
+	if mapType == cubeMap {
+		cubeSize = calculateCubeSize(monkeyDesc.Map)
+		cubeMinBoundaries, cubeMaxBoundaries = cubeBoundaries(cubeSize)
+		if err := checkCubeNet(monkeyDesc.Map, cubeSize); err != nil {
+			return nil, err
+		}
+	}
 	// Find starting point
-	startPoint := Position{}
+	startPoint := Position{X: -1}
 	for x := 0; x < len(monkeyDesc.Map[0]); x++ {
 		if monkeyDesc.Map[0][x] == openTile {
 			startPoint.X = x
 			break
 		}
 	}
+	if startPoint.X < 0 {
+		return nil, aoc.LineError(0, mapLines[0], errors.New("no open tile to start on"))
+	}
 	monkeyDesc.MyPosition = startPoint
-	return monkeyDesc
+	return monkeyDesc, nil
 }
 
 // ScanfMovement returns a list of movement instructions
-func ScanfMovement(inst string) []Movement {
+func ScanfMovement(inst string) ([]Movement, error) {
+	if !pathPattern.MatchString(inst) {
+		return nil, errors.New("expected numbers of steps with turns (R or L) in between")
+	}
 	movements := make([]Movement, 0)
-	nextMove := Movement{}
-	for len(inst) > 0 {
-		nextMove.Steps = -1
-		nextMove.TurnDirection = ""
-		fmt.Sscanf(inst, "%d%s", &nextMove.Steps, &nextMove.TurnDirection)
-		inst = nextMove.TurnDirection
-		if len(nextMove.TurnDirection) > 1 {
-			inst = nextMove.TurnDirection[1:]
-			nextMove.TurnDirection = string(nextMove.TurnDirection[0])
-		}
-		if nextMove.Steps > -1 || nextMove.TurnDirection != "" {
-			movements = append(movements, nextMove)
+	for _, m := range movementPattern.FindAllStringSubmatch(inst, -1) {
+		steps, err := strconv.Atoi(m[1])
+		if err != nil {
+			return nil, fmt.Errorf("steps of movement #%d: %w", len(movements)+1, err)
 		}
+		movements = append(movements, Movement{Steps: steps, TurnDirection: m[2]})
+	}
+	return movements, nil
+}
 
+// traceMove emits where the player ended up after a move.
+func traceMove(md *MonkeysDescription, move Movement) {
+	if tracer.Enabled(tracing.Debug) {
+		tracer.Debug("player moved", tracing.F("steps", move.Steps), tracing.F("turn", move.TurnDirection),
+			tracing.F("x", md.MyPosition.X), tracing.F("y", md.MyPosition.Y), tracing.F("facing", md.MyFacing))
+	}
+}
+
+// traceEnd emits where the player stopped and the map with the steps taken.
+func traceEnd(md *MonkeysDescription) {
+	tracer.Info("path followed", tracing.F("x", md.MyPosition.X), tracing.F("y", md.MyPosition.Y), tracing.F("facing", md.MyFacing))
+	if tracer.Enabled(tracing.Debug) {
+		tracer.Debug("map", tracing.F("steps", md.String()))
 	}
-	return movements
 }
 
 // runChallenge returns the desired output for the day's challenge.
-func runChallenge(challengePart int) int {
+func runChallenge(challengePart int, input string) (int, error) {
 	parts := strings.Split(input, "\n\n")
-	moves := ScanfMovement(parts[1])
-
-	// Initial board setup
-	// fmt.Println("==== BoardMap ====")
-	// fmt.Println(boardMap)
-	// fmt.Println("==== Moves ====")
-	// for _, m := range moves {
-	// 	fmt.Printf("%d%s, ", m.Steps, m.TurnDirection)
-	// }
-	// fmt.Println()
+	if len(parts) != 2 {
+		return 0, errors.New("expected the map and the path separated by an empty line")
+	}
+	moves, err := ScanfMovement(parts[1])
+	if err != nil {
+		return 0, aoc.LineError(strings.Count(parts[0], "\n")+2, parts[1], err)
+	}
+
+	tracer.Info("path parsed", tracing.F("moves", len(moves)))
 
 	if challengePart == 1 {
-		monkeyMap := NewMonkeyDescription(parts[0], flatMap)
+		monkeyMap, err := NewMonkeyDescription(parts[0], flatMap)
+		if err != nil {
+			return 0, err
+		}
 		for _, move := range moves {
 			monkeyMap.MovePlayer(move)
-			// fmt.Printf("=== Move %d%s ===\n", move.Steps, move.TurnDirection)
-			// fmt.Println(boardMap)
+			traceMove(monkeyMap, move)
 		}
 		var facingIndex int
 		for facingIndex = range sortedFacing {
@@ -400,17 +501,17 @@ func runChallenge(challengePart int) int {
 				break
 			}
 		}
-		// fmt.Println(boardMap)
+		traceEnd(monkeyMap)
 		finalPassword := (monkeyMap.MyPosition.Y+1)*1000 + (monkeyMap.MyPosition.X+1)*4 + facingIndex
-		return finalPassword
+		return finalPassword, nil
 	} else if challengePart == 2 {
-		monkeyMap := NewMonkeyDescription(parts[0], cubeMap)
-		// fmt.Println("==== BoardMap ====")
-		// fmt.Println(monkeyMap)
+		monkeyMap, err := NewMonkeyDescription(parts[0], cubeMap)
+		if err != nil {
+			return 0, err
+		}
 		for _, move := range moves {
 			monkeyMap.MovePlayer(move)
-			// fmt.Printf("=== Move %d%s ===\n", move.Steps, move.TurnDirection)
-			// fmt.Println(boardMap)
+			traceMove(monkeyMap, move)
 		}
 		var facingIndex int
 		for facingIndex = range sortedFacing {
@@ -418,16 +519,26 @@ func runChallenge(challengePart int) int {
 				break
 			}
 		}
-		// fmt.Println(monkeyMap)
-		// fmt.Println(monkeyMap.MyPosition)
-		// fmt.Println(monkeyMap.MyFacing)
+		traceEnd(monkeyMap)
 
 		finalPassword := (monkeyMap.MyPosition.Y+1)*1000 + (monkeyMap.MyPosition.X+1)*4 + facingIndex
-		return finalPassword
+		return finalPassword, nil
 	}
-	return -1
+	return -1, nil
 }
 
-func main() {
-	fmt.Println(runChallenge(2))
+func init() {
+	aoc.Register(aoc.Puzzle{
+		Year:    2022,
+		Day:     22,
+		Title:   "Monkey Map",
+		Parts:   2,
+		Example: example,
+		PartExamples: map[int]string{
+			2: example2,
+		},
+		Solver: aoc.SolverFunc(func(ctx context.Context, part int, input string) (any, error) {
+			return runChallenge(part, input)
+		}),
+	})
 }
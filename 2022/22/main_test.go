@@ -1,7 +1,10 @@
-package main
+package day22
 
 import (
+	"strings"
 	"testing"
+
+	"github.com/rubinda/aoc/internal/aoctest"
 )
 
 const (
@@ -10,7 +13,10 @@ const (
 )
 
 func TestChallenge1(t *testing.T) {
-	actual := runChallenge(1)
+	actual, err := runChallenge(1, example)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if actual != expected1 {
 		t.Errorf("Wrong result! Expected: %v, actual: %v", expected1, actual)
@@ -18,20 +24,66 @@ func TestChallenge1(t *testing.T) {
 }
 
 func TestChallenge2(t *testing.T) {
-	actual := runChallenge(2)
+	actual, err := runChallenge(2, example2)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if actual != expected2 {
 		t.Errorf("Wrong result! Expected: %v, actual: %v", expected2, actual)
 	}
 }
 
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 2022, 22)
+}
+
 func Benchmark1(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		runChallenge(1)
+		runChallenge(1, example)
 	}
 }
 func Benchmark2(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		runChallenge(2)
+		runChallenge(2, example2)
 	}
 }
+
+func FuzzScanfMovement(f *testing.F) {
+	f.Add(strings.Split(example, "\n\n")[1])
+	f.Fuzz(func(t *testing.T, path string) {
+		var moves []Movement
+		if aoctest.Parses(t, func() (err error) {
+			moves, err = ScanfMovement(path)
+			return err
+		}) != nil {
+			return
+		}
+		// Steps and turns alternate, starting and ending with steps
+		for i, m := range moves {
+			last := i == len(moves)-1
+			if m.Steps < 0 || (last && m.TurnDirection != "") || (!last && m.TurnDirection != turnClockwise && m.TurnDirection != turnCounterClockwise) {
+				t.Errorf("Invalid movement %d of %d: %+v", i+1, len(moves), m)
+			}
+		}
+	})
+}
+
+func FuzzNewMonkeyDescription(f *testing.F) {
+	f.Add(strings.Split(example, "\n\n")[0])
+	f.Add(strings.Split(example2, "\n\n")[0])
+	f.Fuzz(func(t *testing.T, input string) {
+		for _, mapType := range []string{flatMap, cubeMap} {
+			var desc *MonkeysDescription
+			if aoctest.Parses(t, func() (err error) {
+				desc, err = NewMonkeyDescription(input, mapType)
+				return err
+			}) != nil {
+				continue
+			}
+			if desc.tileAt(desc.MyPosition) != openTile {
+				t.Errorf("Starting on %q at %v", desc.tileAt(desc.MyPosition), desc.MyPosition)
+			}
+		}
+	})
+}
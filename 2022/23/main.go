@@ -1,28 +1,48 @@
-package main
+package day23
 
 import (
+	"context"
 	_ "embed"
 	"fmt"
-	"math"
+	"image"
+	"image/color"
 	"strings"
+
+	"github.com/rubinda/aoc"
+	"github.com/rubinda/aoc/internal/grid"
+	"github.com/rubinda/aoc/internal/tracing"
+	"github.com/rubinda/aoc/internal/viz"
 )
 
 var (
 	//go:embed example.in
-	input string
-
-	north     = Point{0, -1}
-	northEast = Point{1, -1}
-	east      = Point{1, 0}
-	southEast = Point{1, 1}
-	south     = Point{0, 1}
-	southWest = Point{-1, 1}
-	west      = Point{-1, 0}
-	northWest = Point{-1, -1}
-
-	directions8 = []Point{
+	example string
+	// tracer reports the elves' moves and rounds, and the round they spread out in (see aoc run --trace).
+	tracer = tracing.For(2022, 23)
+
+	north     = grid.Up
+	northEast = grid.UpRight
+	east      = grid.Right
+	southEast = grid.DownRight
+	south     = grid.Down
+	southWest = grid.DownLeft
+	west      = grid.Left
+	northWest = grid.UpLeft
+
+	directions8 = []grid.Point{
 		northEast, north, northWest, southEast, south, southWest, southWest, west, northWest, northEast, east, southEast,
 	}
+
+	// markerColors are the colors of untouched ground, ground an elf has been on and elves in a visualization.
+	markerColors = color.Palette{
+		color.RGBA{0x0b, 0x3d, 0x20, 0xff},
+		color.RGBA{0x3c, 0x6e, 0x47, 0xff},
+		color.RGBA{0xf4, 0xd3, 0x5e, 0xff},
+	}
+	// markerIndex maps markers to their colors.
+	markerIndex = map[string]uint8{"": 0, groundMarker: 1, elfMarker: 2}
+	// recorder records the grove after every round (see aoc viz).
+	recorder = viz.For(2022, 23, markerColors)
 )
 
 const (
@@ -35,84 +55,61 @@ const (
 	offsetY = 150
 )
 
-type Point struct {
-	X, Y int
-}
-
-func (p Point) add(d Point) Point {
-	return Point{p.X + d.X, p.Y + d.Y}
-}
-
 type Elf struct {
 	// Location holds grove coordinates of elf's current location.
-	Location Point
+	Location grid.Point
 	// ProposedMove holds a relative direction for wanted move.
-	ProposedMove Point
+	ProposedMove grid.Point
 }
 
 type Grove struct {
-	Ground [][]string
+	Ground *grid.Grid[string]
 	Elves  []*Elf
 	// DesiredDirection points to which direction elves want to travel in current round.
 	DesiredDirection int
 }
 
 func (g *Grove) CountEmptySpots() int {
-	smallestX := math.MaxInt
-	smallestY := math.MaxInt
-	biggestX := 0
-	biggestY := 0
-
-	for _, elf := range g.Elves {
-		if elf.Location.X < smallestX {
-			smallestX = elf.Location.X
-		}
-		if elf.Location.X > biggestX {
-			biggestX = elf.Location.X
-		}
-		if elf.Location.Y > biggestY {
-			biggestY = elf.Location.Y
-		}
-		if elf.Location.Y < smallestY {
-			smallestY = elf.Location.Y
-		}
+	elfLocations := make([]grid.Point, len(g.Elves))
+	for i, elf := range g.Elves {
+		elfLocations[i] = elf.Location
 	}
-
-	elfSeparationWidth := biggestX - smallestX + 1
-	elfSeparationDepth := biggestY - smallestY + 1
-
-	return elfSeparationWidth*elfSeparationDepth - len(g.Elves)
-
+	return grid.BoundingBox(elfLocations...).Area() - len(g.Elves)
 }
 
-func (g *Grove) SpaceAt(p Point) string {
-	return g.Ground[p.Y][p.X]
+// SpaceAt returns the marker at given point, untouched ground is empty. Points outside the grove are never ground.
+func (g *Grove) SpaceAt(p grid.Point) string {
+	space, ok := g.Ground.Lookup(p)
+	if ok && space == "" {
+		return groundMarker
+	}
+	return space
 }
 
 func (g *Grove) acceptProposedMove(elf *Elf) {
-	g.Ground[elf.Location.Y][elf.Location.X] = groundMarker
-	// fmt.Printf("Elf move from %v to %v \n", elf.Location, elf.ProposedMove)
-	elf.Location = Point{elf.ProposedMove.X, elf.ProposedMove.Y}
-	g.Ground[elf.Location.Y][elf.Location.X] = elfMarker
-
+	g.Ground.Set(elf.Location, groundMarker)
+	if tracer.Enabled(tracing.Debug) {
+		tracer.Debug("elf moved", tracing.F("from", elf.Location), tracing.F("to", elf.ProposedMove))
+	}
+	elf.Location = elf.ProposedMove
+	g.Ground.Set(elf.Location, elfMarker)
 }
 
 // MoveElves spaces out elves. Returns true if atleast one elf has moved.
 func (g *Grove) MoveElves() bool {
-	movesOnto := make(map[Point]int)
+	movesOnto := make(map[grid.Point]int)
 	for _, elf := range g.Elves {
-		elf.ProposedMove = Point{}
+		elf.ProposedMove = grid.Point{}
 		foundElf := false
 		hasMove := false
 		for dir := 1; dir < 11; dir += 3 {
 			d1 := (g.DesiredDirection + dir - 1) % 12
 			d2 := (g.DesiredDirection + dir) % 12
 			d3 := (g.DesiredDirection + dir + 1) % 12
-			stepPos := elf.Location.add(directions8[d2])
-			if g.SpaceAt(stepPos) == groundMarker && g.SpaceAt(elf.Location.add(directions8[d1])) == groundMarker && g.SpaceAt(elf.Location.add(directions8[d3])) == groundMarker {
+			stepPos := elf.Location.Add(directions8[d2])
+			if g.SpaceAt(stepPos) == groundMarker && g.SpaceAt(elf.Location.Add(directions8[d1])) == groundMarker && g.SpaceAt(elf.Location.Add(directions8[d3])) == groundMarker {
 				if !hasMove {
 					elf.ProposedMove = stepPos
-					// fmt.Printf("  > wants spot %v\n", stepPos)
 					movesOnto[stepPos] += 1
 					hasMove = true
 				}
@@ -125,7 +122,7 @@ func (g *Grove) MoveElves() bool {
 		}
 		if !foundElf {
 			movesOnto[elf.ProposedMove] -= 1
-			elf.ProposedMove = Point{}
+			elf.ProposedMove = grid.Point{}
 		}
 	}
 	movement := false
@@ -145,63 +142,99 @@ func (g *Grove) MoveElves() bool {
 
 // String outputs the string representation of grove.
 func (g *Grove) String() string {
-	out := ""
-	for y := range g.Ground {
-		for x := range g.Ground[y] {
-			out += g.Ground[y][x]
-			if g.Ground[y][x] == "" {
-				out += groundMarker
-			}
+	return g.Ground.Render(func(_ grid.Point, space string) string {
+		if space == "" {
+			return groundMarker
+		}
+		return space
+	})
+}
+
+// Frame draws the elves and the ground around them.
+func (g *Grove) Frame() *image.Paletted {
+	elfLocations := make([]grid.Point, len(g.Elves))
+	for i, elf := range g.Elves {
+		elfLocations[i] = elf.Location
+	}
+	bounds := grid.BoundingBox(elfLocations...).Pad(1)
+	frame := viz.NewFrame(bounds, markerColors)
+	for y := bounds.Min.Y; y <= bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x <= bounds.Max.X; x++ {
+			frame.SetColorIndex(x, y, markerIndex[g.Ground.Get(grid.Point{X: x, Y: y})])
 		}
-		out += "\n"
 	}
-	return out
+	return frame
 }
 
 // PraseGrove structure the challenge input data.
-func ParseGrove(groveDesc string) *Grove {
+func ParseGrove(groveDesc string) (*Grove, error) {
 	grove := &Grove{}
 	lines := strings.Split(groveDesc, "\n")
-	grove.Ground = make([][]string, offsetY*2+1)
+	grove.Ground = grid.NewDense[string](grid.NewRect(offsetX*2+1, offsetY*2+1))
 	grove.Elves = make([]*Elf, 0)
-
-	for y := range grove.Ground {
-		grove.Ground[y] = make([]string, offsetX*2+1)
-		for x := range grove.Ground[y] {
-			grove.Ground[y][x] = groundMarker
-		}
+	if len(lines) > offsetY {
+		return nil, fmt.Errorf("grove scan is %d lines long, at most %d are supported", len(lines), offsetY)
 	}
 
 	for y := range lines {
 		spaces := strings.Split(lines[y], "")
+		if len(spaces) > offsetX {
+			return nil, aoc.LineError(y, lines[y], fmt.Errorf("grove scan is %d wide, at most %d is supported", len(spaces), offsetX))
+		}
 		for x := range spaces {
-			grove.Ground[offsetY+y][offsetX+x] = spaces[x]
+			if spaces[x] != elfMarker && spaces[x] != groundMarker {
+				return nil, aoc.LineError(y, lines[y], fmt.Errorf("unknown marker %q", spaces[x]))
+			}
+			location := grid.Point{X: offsetX + x, Y: offsetY + y}
+			grove.Ground.Set(location, spaces[x])
 			if spaces[x] == elfMarker {
-				grove.Elves = append(grove.Elves, &Elf{Location: Point{offsetX + x, offsetY + y}})
+				grove.Elves = append(grove.Elves, &Elf{Location: location})
 			}
 		}
 	}
-	return grove
+	return grove, nil
 }
 
 // runChallenge returns the desired output for the day's challenge.
-func runChallenge(challengePart int) int {
-	grove := ParseGrove(input)
+func runChallenge(ctx context.Context, challengePart int, input string) (int, error) {
+	grove, err := ParseGrove(input)
+	if err != nil {
+		return 0, err
+	}
 	movement := true
 	round := 0
+	recorder.Step(grove)
 	for movement {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
 		movement = grove.MoveElves()
 		round++
+		recorder.Step(grove)
+		if tracer.Enabled(tracing.Debug) {
+			tracer.Debug("round finished", tracing.F("round", round), tracing.F("moved", movement), tracing.F("grove", grove.String()))
+		}
 		if challengePart == 1 && round == 10 {
-			return grove.CountEmptySpots()
+			return grove.CountEmptySpots(), nil
 		}
 	}
-	if challengePart == 2 {
-		return round
+	if challengePart == 1 {
+		// The elves spread out before the 10th round, the ground stays as it is
+		return grove.CountEmptySpots(), nil
 	}
-	return -1
+	tracer.Info("elves spread out", tracing.F("rounds", round), tracing.F("elves", len(grove.Elves)))
+	return round, nil
 }
 
-func main() {
-	fmt.Println(runChallenge(2))
+func init() {
+	aoc.Register(aoc.Puzzle{
+		Year:    2022,
+		Day:     23,
+		Title:   "Unstable Diffusion",
+		Parts:   2,
+		Example: example,
+		Solver: aoc.SolverFunc(func(ctx context.Context, part int, input string) (any, error) {
+			return runChallenge(ctx, part, input)
+		}),
+	})
 }
@@ -1,7 +1,11 @@
-package main
+package day23
 
 import (
+	"context"
+	"strings"
 	"testing"
+
+	"github.com/rubinda/aoc/internal/aoctest"
 )
 
 const (
@@ -10,7 +14,10 @@ const (
 )
 
 func TestChallenge1(t *testing.T) {
-	actual := runChallenge(1)
+	actual, err := runChallenge(context.Background(), 1, example)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if actual != expected1 {
 		t.Errorf("Wrong result! Expected: %v, actual: %v", expected1, actual)
@@ -18,20 +25,68 @@ func TestChallenge1(t *testing.T) {
 }
 
 func TestChallenge2(t *testing.T) {
-	actual := runChallenge(2)
+	actual, err := runChallenge(context.Background(), 2, example)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if actual != expected2 {
 		t.Errorf("Wrong result! Expected: %v, actual: %v", expected2, actual)
 	}
 }
 
+func TestSpreadOutEarly(t *testing.T) {
+	// A lone elf never moves, so the 10 rounds end after the first one
+	tests := []struct {
+		part     int
+		expected int
+	}{
+		{1, 0},
+		{2, 1},
+	}
+	for _, test := range tests {
+		actual, err := runChallenge(context.Background(), test.part, "#")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual != test.expected {
+			t.Errorf("Part %d: wrong result! Expected: %v, actual: %v", test.part, test.expected, actual)
+		}
+	}
+}
+
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 2022, 23)
+}
+
 func Benchmark1(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		runChallenge(1)
+		runChallenge(context.Background(), 1, example)
 	}
 }
 func Benchmark2(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		runChallenge(2)
+		runChallenge(context.Background(), 2, example)
 	}
 }
+
+func FuzzParseGrove(f *testing.F) {
+	f.Add(example)
+	f.Fuzz(func(t *testing.T, input string) {
+		var grove *Grove
+		if aoctest.Parses(t, func() (err error) {
+			grove, err = ParseGrove(input)
+			return err
+		}) != nil {
+			return
+		}
+		if expected := strings.Count(input, elfMarker); len(grove.Elves) != expected {
+			t.Errorf("Expected %d elves, got %d", expected, len(grove.Elves))
+		}
+		for _, elf := range grove.Elves {
+			if grove.Ground.Get(elf.Location) != elfMarker {
+				t.Errorf("Elf at %v is not on the map", elf.Location)
+			}
+		}
+	})
+}
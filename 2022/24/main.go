@@ -1,37 +1,50 @@
-package main
+package day24
 
 import (
+	"context"
 	_ "embed"
+	"errors"
 	"fmt"
+	"image"
+	"image/color"
 	"strings"
-	"time"
+
+	"github.com/rubinda/aoc"
+	"github.com/rubinda/aoc/internal/graph"
+	"github.com/rubinda/aoc/internal/grid"
+	"github.com/rubinda/aoc/internal/viz"
 )
 
 var (
 	//go:embed example.in
-	input string
-
-	relativeUp    = Point{0, -1}
-	relativeRight = Point{1, 0}
-	relativeDown  = Point{0, 1}
-	relativeLeft  = Point{-1, 0}
+	example string
 
 	// blizzardDirections maps text direction to relative coordinates.
-	blizzardDirections = map[string]Point{
-		blizzardUp:    relativeUp,
-		blizzardRight: relativeRight,
-		blizzardDown:  relativeDown,
-		blizzardLeft:  relativeLeft,
+	blizzardDirections = map[string]grid.Point{
+		blizzardUp:    grid.Up,
+		blizzardRight: grid.Right,
+		blizzardDown:  grid.Down,
+		blizzardLeft:  grid.Left,
 	}
 
 	// exploreDirections are neighbouring locations expedition can move to.
-	exploreDirections = []Point{
-		relativeDown,
-		relativeRight,
-		relativeUp,
-		relativeLeft,
-		{0, 0},
+	exploreDirections = []grid.Point{
+		grid.Down,
+		grid.Right,
+		grid.Up,
+		grid.Left,
+		{X: 0, Y: 0},
 	}
+
+	// valleyColors are the colors of safe ground, walls, blizzards and the expedition in a visualization.
+	valleyColors = color.Palette{
+		color.RGBA{0x1a, 0x1a, 0x2e, 0xff},
+		color.RGBA{0xa0, 0x82, 0x2e, 0xff},
+		color.RGBA{0x9c, 0xd4, 0xf0, 0xff},
+		color.RGBA{0xe9, 0x45, 0x60, 0xff},
+	}
+	// recorder records the expedition at every minute of its trip (see aoc viz).
+	recorder = viz.For(2022, 24, valleyColors)
 )
 
 // Markings of challenge input.
@@ -45,199 +58,293 @@ const (
 	expeditionMarker = "E"
 )
 
-// Point contains 2D coordinates.
-type Point struct {
-	X, Y int
-}
-
-// add performs sum on each coordinates.
-func (p Point) add(d Point) Point {
-	return Point{p.X + d.X, p.Y + d.Y}
+// gcd returns the greatest common divisor of a and b.
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
 }
 
-// Path represents a pathing
-type Path struct {
-	Previous *Path
-	Steps    int
-	Location Point
-	Priority int
+// mod returns the non-negative remainder of a / b.
+func mod(a, b int) int {
+	return (a%b + b) % b
 }
 
 // Maze represents a board with moving blizzards we wish to navigate.
 type Maze struct {
-	Map             [][]string
-	blizzards       []*Blizzard
-	futureBlizzards map[Point]int
+	Map *grid.Grid[string]
+	// initial holds the map before any blizzard moved.
+	initial *grid.Grid[string]
+	// width and height of the valley inside the walls.
+	width, height int
+	// period is the number of minutes after which all blizzards are back to their initial location.
+	period int
+}
 
-	ShortestPath *Path
+// expedition is a state in the time-expanded valley: a location at a minute. Minutes are counted modulo
+// the blizzard period, since the valley looks the same again after that.
+type expedition struct {
+	Location grid.Point
+	Minute   int
 }
 
-// moveBlizzards makes all blizzards move to their next location on map.
-func (m *Maze) moveBlizards() {
-	m.futureBlizzards = make(map[Point]int)
-	blizzardCount := make(map[Point]int)
-	for _, blizzard := range m.blizzards {
-		if _, hasBlizzard := blizzardCount[blizzard.Location]; !hasBlizzard {
-			m.Map[blizzard.Location.Y][blizzard.Location.X] = safeGround
-		}
-		blizzard.Location = blizzard.NextLocation
-		if blizzards, hasBlizzard := blizzardCount[blizzard.Location]; hasBlizzard {
-			m.Map[blizzard.NextLocation.Y][blizzard.NextLocation.X] = fmt.Sprint(blizzards + 1)
-		} else {
-			m.Map[blizzard.Location.Y][blizzard.Location.X] = blizzard.Marker
-		}
-		blizzard.setNextAdvance(len(m.Map[0])-1, len(m.Map)-1)
-		m.futureBlizzards[blizzard.NextLocation] += 1
-		blizzardCount[blizzard.Location] += 1
+// isWall returns true if given location is a wall or out of bounds.
+func (m *Maze) isWall(location grid.Point) bool {
+	return !m.Map.InBounds(location) || m.Map.Get(location) == valleyWall
+}
+
+// blizzardAt returns true if a blizzard covers the location at given minute. Blizzards move in straight
+// lines and wrap around, so it's enough to check the initial map where a blizzard would have come from.
+func (m *Maze) blizzardAt(p grid.Point, minute int) bool {
+	if p.X < 1 || p.Y < 1 || p.X > m.width || p.Y > m.height {
+		return false
 	}
+	x, y := p.X-1, p.Y-1
+	return m.initial.Get(grid.Point{X: mod(x-minute, m.width) + 1, Y: p.Y}) == blizzardRight ||
+		m.initial.Get(grid.Point{X: mod(x+minute, m.width) + 1, Y: p.Y}) == blizzardLeft ||
+		m.initial.Get(grid.Point{X: p.X, Y: mod(y-minute, m.height) + 1}) == blizzardDown ||
+		m.initial.Get(grid.Point{X: p.X, Y: mod(y+minute, m.height) + 1}) == blizzardUp
 }
 
-// isWall returns true if given location is a wall or out of bounds.
-func (m *Maze) isWall(location Point) bool {
-	return location.X < 0 || location.Y < 0 || location.X >= len(m.Map[0]) || location.Y >= len(m.Map) || m.Map[location.Y][location.X] == valleyWall
-}
-
-// MoveExpeditionTo finds shortest path among blizzards from start to goal.
-func (m *Maze) MoveExpeditionTo(start, goal Point) *Path {
-	explore := make(map[Point]*Path)
-	explore[start] = &Path{Previous: nil, Steps: 0, Location: start}
-	time := 0
-	// Quick & dirty safeguard if a path doesn't exist
-	for time < 10000 {
-		time++
-		next := make(map[Point]*Path)
-		for _, path := range explore {
-			if path.Location == goal {
-				return path
-			}
-			for _, dir := range exploreDirections {
-				// Check 5 directions - wait, up, right, down, left
-				expeditionMove := path.Location.add(dir)
-				_, blizzardLocation := m.futureBlizzards[expeditionMove]
-				if blizzardLocation || m.isWall(expeditionMove) {
-					// fmt.Printf("  %v is wall\n", expeditionMove)
-					continue
-				}
-				// fmt.Println("Adding ", expeditionMove)
-				next[expeditionMove] = &Path{Previous: path, Steps: time, Location: expeditionMove}
-
-			}
+// locations is a set of locations in the maze, a bit per location.
+type locations []uint64
+
+// newLocations returns an empty set of locations in the maze.
+func (m *Maze) newLocations() locations {
+	return make(locations, (m.Map.Bounds().Area()+63)/64)
+}
+
+// index returns the bit of a location (inside the maze) in a set of locations.
+func (m *Maze) index(p grid.Point) int {
+	return p.Y*m.Map.Bounds().Width() + p.X
+}
+
+// has returns true if the bit i is set.
+func (l locations) has(i int) bool {
+	return l[i/64]&(1<<(i%64)) != 0
+}
+
+// add sets the bit i.
+func (l locations) add(i int) {
+	l[i/64] |= 1 << (i % 64)
+}
+
+// valley is the maze expanded in time, a graph of the states the expedition can be in.
+type valley struct {
+	*Maze
+}
+
+// Neighbours returns the states the expedition can be in a minute later.
+func (v valley) Neighbours(e expedition) []expedition {
+	minute := (e.Minute + 1) % v.period
+	next := make([]expedition, 0, len(exploreDirections))
+	for _, dir := range exploreDirections {
+		// Check 5 directions - wait, up, right, down, left
+		expeditionMove := e.Location.Add(dir)
+		if v.isWall(expeditionMove) || v.blizzardAt(expeditionMove, minute) {
+			continue
 		}
-		explore = next
-		m.moveBlizards()
+		next = append(next, expedition{expeditionMove, minute})
 	}
-	return nil
+	return next
 }
 
-// String returns ANSI colored text view of the map.
-func (m *Maze) String() string {
-	out := ""
-	for y := range m.Map {
-		for x := range m.Map[y] {
-			switch m.Map[y][x] {
-			case expeditionMarker:
-				out += fmt.Sprintf("\u001b[31m%s\u001b[0m", expeditionMarker)
-			case valleyWall:
-				out += fmt.Sprintf("\u001b[33m%s\u001b[0m", valleyWall)
-			case safeGround, "S", "G":
-				out += m.Map[y][x]
-			default:
-				// Blizzards
-				out += fmt.Sprintf("\u001b[34m%s\u001b[0m", m.Map[y][x])
-			}
-		}
-		out += "\n"
+// NewVisited returns an empty set of states, a set of locations for every minute of the period.
+func (v valley) NewVisited() graph.Visited[expedition] {
+	return &visitedStates{maze: v.Maze, minutes: make([]locations, v.period)}
+}
+
+// visitedStates holds the locations the expedition reached at every minute of the blizzard period. The set of a
+// minute is only allocated once it is reached, a trip rarely lasts as long as the period.
+type visitedStates struct {
+	maze    *Maze
+	minutes []locations
+}
+
+// Visit adds the state to the set.
+func (v *visitedStates) Visit(e expedition) bool {
+	seen := v.minutes[e.Minute]
+	if seen == nil {
+		seen = v.maze.newLocations()
+		v.minutes[e.Minute] = seen
+	}
+	i := v.maze.index(e.Location)
+	if seen.has(i) {
+		return false
 	}
-	return out
+	seen.add(i)
+	return true
 }
 
-// Blizzard describes a moving snow blizzard we wish to avoid.
-type Blizzard struct {
-	Location     Point
-	NextLocation Point
-	Direction    Point
-	Marker       string
+// MoveExpeditionTo finds shortest path among blizzards from start to goal when leaving at given minute.
+// Returns the location of the expedition at every minute of the trip (start included) or nil if there is no path.
+// The search stops with ctx's error once ctx is done.
+func (m *Maze) MoveExpeditionTo(ctx context.Context, start, goal grid.Point, departure int) ([]grid.Point, error) {
+	search, err := graph.BFS[expedition](ctx, valley{m}, func(e expedition) bool {
+		return e.Location == goal
+	}, expedition{start, departure % m.period})
+	if err != nil || !search.Found {
+		return nil, err
+	}
+	states := search.Path(search.Goal)
+	path := make([]grid.Point, len(states))
+	for i, state := range states {
+		path[i] = state.Location
+	}
+	return path, nil
 }
 
-// nextAdvance calculates the next spot blizzard will move to based on map boundaries.
-func (b *Blizzard) setNextAdvance(wallX, wallY int) {
-	b.NextLocation = b.Location.add(b.Direction)
-	if b.NextLocation.Y == wallY {
-		b.NextLocation.Y = 1
-	} else if b.NextLocation.Y == 0 {
-		b.NextLocation.Y = wallY - 1
-	} else if b.NextLocation.X == wallX {
-		b.NextLocation.X = 1
-	} else if b.NextLocation.X == 0 {
-		b.NextLocation.X = wallX - 1
+// trip is the expedition at a minute of its trip through the maze.
+type trip struct {
+	maze *Maze
+	expedition
+}
+
+// Frame draws the valley with the blizzards at the trip's minute and the expedition.
+func (t trip) Frame() *image.Paletted {
+	m := t.maze
+	return viz.GridFrame(m.initial, valleyColors, func(p grid.Point, spot string) uint8 {
+		switch {
+		case p == t.Location:
+			return 3
+		case spot == valleyWall:
+			return 1
+		case m.blizzardAt(p, t.Minute):
+			return 2
+		}
+		return 0
+	})
+}
+
+// recordTrip records every minute of a trip along the path, leaving at given minute.
+func (m *Maze) recordTrip(path []grid.Point, departure int) {
+	if !recorder.Enabled() {
+		return
+	}
+	for i, location := range path {
+		recorder.Step(trip{m, expedition{location, (departure + i) % m.period}})
 	}
 }
 
+// String returns ANSI colored text view of the map.
+func (m *Maze) String() string {
+	return m.Map.Render(func(_ grid.Point, spot string) string {
+		switch spot {
+		case expeditionMarker:
+			return fmt.Sprintf("\u001b[31m%s\u001b[0m", expeditionMarker)
+		case valleyWall:
+			return fmt.Sprintf("\u001b[33m%s\u001b[0m", valleyWall)
+		case safeGround, "S", "G":
+			return spot
+		default:
+			// Blizzards
+			return fmt.Sprintf("\u001b[34m%s\u001b[0m", spot)
+		}
+	})
+}
+
 // parseMaze creates a maze structure from challenge input.
-func parseMaze(mazeDesc string) (maze *Maze, start, goal Point) {
-	maze = &Maze{}
+func parseMaze(mazeDesc string) (maze *Maze, start, goal grid.Point, err error) {
 	lines := strings.Split(mazeDesc, "\n")
-	maze.Map = make([][]string, len(lines))
-	maze.blizzards = make([]*Blizzard, 0)
-	maze.futureBlizzards = make(map[Point]int)
-
-	for y := range lines {
-		spots := strings.Split(lines[y], "")
-		maze.Map[y] = make([]string, len(spots))
-		for x := range spots {
-			if y == 0 && spots[x] == safeGround {
-				start = Point{x, y}
-			} else if y == len(lines)-1 && spots[x] == safeGround {
-				goal = Point{x, y}
-			}
-			maze.Map[y][x] = spots[x]
-			if dir, ok := blizzardDirections[spots[x]]; ok {
-				blizzard := &Blizzard{Location: Point{x, y}, Direction: dir, Marker: spots[x]}
-				blizzard.setNextAdvance(len(spots)-1, len(lines)-1)
-				maze.blizzards = append(maze.blizzards, blizzard)
-				maze.futureBlizzards[blizzard.NextLocation]++
-			}
+	if len(lines) < 3 || len(lines[0]) < 3 {
+		return nil, start, goal, errors.New("valley has to be surrounded by walls")
+	}
+	maze = &Maze{}
+	maze.Map, err = grid.Parse(mazeDesc, func(p grid.Point, r rune) (string, error) {
+		spot := string(r)
+		if _, isBlizzard := blizzardDirections[spot]; !isBlizzard && spot != valleyWall && spot != safeGround {
+			return "", fmt.Errorf("unknown marker %q", spot)
 		}
+		return spot, nil
+	})
+	if err != nil {
+		return nil, start, goal, err
+	}
+	for y, line := range lines {
+		if len(line) != len(lines[0]) {
+			return nil, start, goal, aoc.LineError(y, line, errors.New("valley has to be rectangular"))
+		}
+	}
+	maze.initial = maze.Map.Clone()
+	maze.width, maze.height = maze.Map.Bounds().Width()-2, maze.Map.Bounds().Height()-2
+	maze.period = maze.width * maze.height / gcd(maze.width, maze.height)
+
+	corner := maze.Map.Bounds().Max
+	valley := maze.Map.Bounds().Pad(-1)
+	foundStart, foundGoal, strayBlizzards := false, false, 0
+	maze.Map.Each(func(p grid.Point, spot string) {
+		if p.Y == 0 && spot == safeGround {
+			start, foundStart = p, true
+		} else if p.Y == corner.Y && spot == safeGround {
+			goal, foundGoal = p, true
+		}
+		if _, isBlizzard := blizzardDirections[spot]; isBlizzard && !valley.Contains(p) {
+			strayBlizzards++
+		}
+	})
+	if !foundStart || !foundGoal {
+		return nil, start, goal, errors.New("valley needs an opening in the top and bottom wall")
+	}
+	if strayBlizzards > 0 {
+		return nil, start, goal, fmt.Errorf("%d blizzards in the walls of the valley", strayBlizzards)
 	}
 	return
 }
 
 // runChallenge returns the desired output for the day's challenge.
-func runChallenge(challengePart int) int {
-	maze, start, goal := parseMaze(input)
-	initialPathing := maze.MoveExpeditionTo(start, goal)
-
-	if challengePart == 1 {
-		// Move start -> goal
-		return initialPathing.Steps
-	} else if challengePart == 2 {
-		// Move start -> goal -> start -> goal and return steps
-		backtrack := maze.MoveExpeditionTo(goal, start)
-		thereAgain := maze.MoveExpeditionTo(start, goal)
-		return initialPathing.Steps + backtrack.Steps + thereAgain.Steps
-	} else if challengePart == 3 {
-		// Visualize movement of challenge 1 path
-		nodes := make([]Point, 0)
-		for initialPathing != nil {
-			nodes = append([]Point{initialPathing.Location}, nodes...)
-			initialPathing = initialPathing.Previous
+func runChallenge(ctx context.Context, challengePart int, input string) (int, error) {
+	maze, start, goal, err := parseMaze(input)
+	if err != nil {
+		return 0, err
+	}
+	// cross returns the path through the valley to the destination when leaving at given minute
+	cross := func(from, to grid.Point, departure int, destination string) ([]grid.Point, error) {
+		path, err := maze.MoveExpeditionTo(ctx, from, to, departure)
+		if err != nil {
+			return nil, err
 		}
-		maze, start, goal := parseMaze(input)
-		maze.Map[start.Y][start.X] = "S"
-		maze.Map[goal.Y][goal.X] = "G"
-		for _, node := range nodes {
-			previous := maze.Map[node.Y][node.X]
-			maze.Map[node.Y][node.X] = expeditionMarker
-			fmt.Printf("\033[2J")
-			fmt.Println(maze)
-			maze.Map[node.Y][node.X] = previous
-			maze.moveBlizards()
-			time.Sleep(250 * time.Millisecond)
+		if path == nil {
+			return nil, fmt.Errorf("no path through the valley %s", destination)
 		}
+		maze.recordTrip(path[1:], departure+1)
+		return path, nil
+	}
+	if recorder.Enabled() {
+		recorder.Step(trip{maze, expedition{start, 0}})
+	}
+
+	// Move start -> goal
+	initialPathing, err := cross(start, goal, 0, "to the goal")
+	if err != nil {
+		return 0, err
+	}
+	steps := len(initialPathing) - 1
+	if challengePart == 1 {
+		return steps, nil
+	}
+	// Move start -> goal -> start -> goal and return steps
+	backtrack, err := cross(goal, start, steps, "back to the start")
+	if err != nil {
+		return 0, err
+	}
+	steps += len(backtrack) - 1
+	thereAgain, err := cross(start, goal, steps, "to the goal again")
+	if err != nil {
+		return 0, err
 	}
-	return -1
+	return steps + len(thereAgain) - 1, nil
 }
 
-func main() {
-	runChallenge(3)
+func init() {
+	aoc.Register(aoc.Puzzle{
+		Year:    2022,
+		Day:     24,
+		Title:   "Blizzard Basin",
+		Parts:   2,
+		Example: example,
+		Solver: aoc.SolverFunc(func(ctx context.Context, part int, input string) (any, error) {
+			return runChallenge(ctx, part, input)
+		}),
+	})
 }
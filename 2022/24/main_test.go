@@ -1,7 +1,11 @@
-package main
+package day24
 
 import (
+	"context"
 	"testing"
+
+	"github.com/rubinda/aoc/internal/aoctest"
+	"github.com/rubinda/aoc/internal/grid"
 )
 
 const (
@@ -10,7 +14,10 @@ const (
 )
 
 func TestChallenge1(t *testing.T) {
-	actual := runChallenge(1)
+	actual, err := runChallenge(context.Background(), 1, example)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if actual != expected1 {
 		t.Errorf("Wrong result! Expected: %v, actual: %v", expected1, actual)
@@ -18,20 +25,59 @@ func TestChallenge1(t *testing.T) {
 }
 
 func TestChallenge2(t *testing.T) {
-	actual := runChallenge(2)
+	actual, err := runChallenge(context.Background(), 2, example)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if actual != expected2 {
 		t.Errorf("Wrong result! Expected: %v, actual: %v", expected2, actual)
 	}
 }
 
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 2022, 24)
+}
+
 func Benchmark1(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		runChallenge(1)
+		runChallenge(context.Background(), 1, example)
 	}
 }
 func Benchmark2(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		runChallenge(2)
+		runChallenge(context.Background(), 2, example)
+	}
+}
+
+func FuzzParseMaze(f *testing.F) {
+	f.Add(example)
+	f.Fuzz(func(t *testing.T, input string) {
+		var maze *Maze
+		var start, goal grid.Point
+		if aoctest.Parses(t, func() (err error) {
+			maze, start, goal, err = parseMaze(input)
+			return err
+		}) != nil {
+			return
+		}
+		bounds := maze.Map.Bounds()
+		if start.Y != bounds.Min.Y || goal.Y != bounds.Max.Y {
+			t.Errorf("Start %v and goal %v aren't in the outer walls of %v", start, goal, bounds)
+		}
+		// Blizzards stay inside the valley
+		inside := bounds.Pad(-1)
+		maze.initial.Each(func(p grid.Point, spot string) {
+			if _, isBlizzard := blizzardDirections[spot]; isBlizzard && !inside.Contains(p) {
+				t.Errorf("Blizzard %s at %v outside of the valley %v", spot, p, inside)
+			}
+		})
+	})
+}
+
+func TestNoPath(t *testing.T) {
+	// The blizzard never leaves the only spot of the valley
+	if actual, err := runChallenge(context.Background(), 1, "#.#\n#>#\n#.#"); err == nil {
+		t.Errorf("Expected an error without a path, got %v", actual)
 	}
 }
@@ -1,15 +1,18 @@
-package main
+package day25
 
 import (
+	"context"
 	_ "embed"
+	"errors"
 	"fmt"
 	"math"
-	"strings"
+
+	"github.com/rubinda/aoc"
 )
 
 var (
 	//go:embed example.in
-	input string
+	example string
 )
 
 var (
@@ -41,12 +44,23 @@ func reverse(s string) string {
 }
 
 // SNAFUToDecimal converts a SNAFU number to the decimal value.
-func SNAFUToDecimal(snafuValue string) int {
+func SNAFUToDecimal(snafuValue string) (int, error) {
+	if snafuValue == "" {
+		return 0, errors.New("empty SNAFU number")
+	}
 	decimalValue := 0
-	for b, bitVal := range reverse(snafuValue) {
-		decimalValue += bitConversionDecimal[bitVal] * int(math.Pow(5, float64(b)))
+	for _, bitVal := range snafuValue {
+		digit, ok := bitConversionDecimal[bitVal]
+		if !ok {
+			return 0, fmt.Errorf("unknown SNAFU digit %q", bitVal)
+		}
+		// Leave room for the next digit, ints don't fit more than 27 SNAFU digits
+		if decimalValue > (math.MaxInt-2)/5 || decimalValue < (math.MinInt+2)/5 {
+			return 0, fmt.Errorf("SNAFU number %s is too big", snafuValue)
+		}
+		decimalValue = 5*decimalValue + digit
 	}
-	return decimalValue
+	return decimalValue, nil
 }
 
 // DecimalToSNAFU converts a decimal number to the SNAFU value.
@@ -78,18 +92,30 @@ func DecimalToSNAFU(value int) string {
 }
 
 // runChallenge returns the desired output for the day's challenge.
-func runChallenge(challengePart int) string {
+func runChallenge(challengePart int, input string) (string, error) {
 	if challengePart == 1 {
-		snafus := strings.Split(input, "\n")
+		fuelRequirements, err := aoc.ParseLines(input, SNAFUToDecimal)
+		if err != nil {
+			return "", err
+		}
 		fuelRequirement := 0
-		for _, snafu := range snafus {
-			fuelRequirement += SNAFUToDecimal(snafu)
+		for _, fuel := range fuelRequirements {
+			fuelRequirement += fuel
 		}
-		return DecimalToSNAFU(fuelRequirement)
+		return DecimalToSNAFU(fuelRequirement), nil
 	}
-	return ""
+	return "", nil
 }
 
-func main() {
-	fmt.Println(runChallenge(1))
+func init() {
+	aoc.Register(aoc.Puzzle{
+		Year:    2022,
+		Day:     25,
+		Title:   "Full of Hot Air",
+		Parts:   1,
+		Example: example,
+		Solver: aoc.SolverFunc(func(ctx context.Context, part int, input string) (any, error) {
+			return runChallenge(part, input)
+		}),
+	})
 }
@@ -1,7 +1,10 @@
-package main
+package day25
 
 import (
+	"strings"
 	"testing"
+
+	"github.com/rubinda/aoc/internal/aoctest"
 )
 
 const (
@@ -9,13 +12,20 @@ const (
 )
 
 func TestChallenge1(t *testing.T) {
-	actual := runChallenge(1)
+	actual, err := runChallenge(1, example)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if actual != expected1 {
 		t.Errorf("Wrong result! Expected: %v, actual: %v", expected1, actual)
 	}
 }
 
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 2022, 25)
+}
+
 func TestDecimalToSNAFU(t *testing.T) {
 	expected := map[int]string{
 		1:         "1",
@@ -59,15 +69,45 @@ func TestSNAFUToDecimal(t *testing.T) {
 		"122":    37,
 	}
 	for snafu, dec := range expected {
-		calculated := SNAFUToDecimal(snafu)
+		calculated, err := SNAFUToDecimal(snafu)
+		if err != nil {
+			t.Fatal(err)
+		}
 		if calculated != dec {
 			t.Errorf("Wrong result! Expected: %v, calculated: %v", dec, calculated)
 		}
 	}
 }
 
+func TestSNAFUToDecimalInvalid(t *testing.T) {
+	for _, snafu := range []string{"", "13", "1=+"} {
+		if _, err := SNAFUToDecimal(snafu); err == nil {
+			t.Errorf("Expected an error for %q", snafu)
+		}
+	}
+}
+
 func Benchmark1(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		runChallenge(1)
+		runChallenge(1, example)
 	}
 }
+
+func FuzzSNAFUToDecimal(f *testing.F) {
+	for _, line := range strings.Split(example, "\n") {
+		f.Add(line)
+	}
+	f.Fuzz(func(t *testing.T, snafu string) {
+		var value int
+		if aoctest.Parses(t, func() (err error) {
+			value, err = SNAFUToDecimal(snafu)
+			return err
+		}) != nil {
+			return
+		}
+		// Positive values convert back to the same digits (without leading zeros)
+		if trimmed := strings.TrimLeft(snafu, "0"); value > 0 && DecimalToSNAFU(value) != trimmed {
+			t.Errorf("%s is %d, which converts back to %s", snafu, value, DecimalToSNAFU(value))
+		}
+	})
+}
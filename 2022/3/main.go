@@ -1,16 +1,19 @@
-package main
+package day3
 
 import (
+	"context"
 	_ "embed"
-	"fmt"
+	"errors"
 	"strings"
 	"unicode"
 
 	"golang.org/x/exp/maps"
+
+	"github.com/rubinda/aoc"
 )
 
 //go:embed example.in
-var input string
+var example string
 
 const (
 	upperCaseShift = 38
@@ -36,7 +39,19 @@ func itemToPriority(item rune) int {
 	return int(item) - shift
 }
 
-func challenge1() int {
+// checkRucksacks makes sure every rucksack only holds items marked with letters.
+func checkRucksacks(rucksacks []string) error {
+	for i, rucksack := range rucksacks {
+		for _, item := range rucksack {
+			if item > unicode.MaxASCII || !unicode.IsLetter(item) {
+				return aoc.LineError(i, rucksack, errors.New("items have to be letters"))
+			}
+		}
+	}
+	return nil
+}
+
+func challenge1(input string) int {
 	compartment1 := make(map[rune]int, 0)
 	compartment2 := make(map[rune]int, 0)
 	var commonItems []rune
@@ -65,7 +80,7 @@ func challenge1() int {
 	return priorityScore
 }
 
-func challenge2() int {
+func challenge2(input string) int {
 	elfGroups := make(map[int]map[rune]int, 0)
 	var authBadges []rune
 
@@ -106,17 +121,32 @@ func challenge2() int {
 	return priorityScore
 }
 
-func runChallenge(challengePart int) int {
+func runChallenge(challengePart int, input string) (int, error) {
+	rucksacks := strings.Split(input, "\n")
+	if err := checkRucksacks(rucksacks); err != nil {
+		return 0, err
+	}
+	if challengePart == 2 && len(rucksacks)%elvesInGroup != 0 {
+		return 0, errors.New("elves have to be split in groups of 3")
+	}
 	priorityScore := 0
 	if challengePart == 1 {
-		priorityScore = challenge1()
+		priorityScore = challenge1(input)
 	} else {
-		priorityScore = challenge2()
+		priorityScore = challenge2(input)
 	}
-	return priorityScore
+	return priorityScore, nil
 }
 
-func main() {
-	priorityScore := runChallenge(2)
-	fmt.Printf("Priority is %d \n", priorityScore)
+func init() {
+	aoc.Register(aoc.Puzzle{
+		Year:    2022,
+		Day:     3,
+		Title:   "Rucksack Reorganization",
+		Parts:   2,
+		Example: example,
+		Solver: aoc.SolverFunc(func(ctx context.Context, part int, input string) (any, error) {
+			return runChallenge(part, input)
+		}),
+	})
 }
@@ -1,12 +1,20 @@
-package main
+package day3
 
-import "testing"
+import (
+	"strings"
+	"testing"
+
+	"github.com/rubinda/aoc/internal/aoctest"
+)
 
 const expected1 = 157
 const expected2 = 70
 
 func TestChallenge1(t *testing.T) {
-	actual := runChallenge(1)
+	actual, err := runChallenge(1, example)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if actual != expected1 {
 		t.Errorf("Wrong result! Expected: %d, actual: %d", expected1, actual)
@@ -14,15 +22,36 @@ func TestChallenge1(t *testing.T) {
 }
 
 func TestChallenge2(t *testing.T) {
-	actual := runChallenge(2)
+	actual, err := runChallenge(2, example)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if actual != expected2 {
 		t.Errorf("Wrong result! Expected: %d, actual: %d", expected2, actual)
 	}
 }
 
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 2022, 3)
+}
+
 func Benchmark2(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		runChallenge(2)
+		runChallenge(2, example)
 	}
 }
+
+func FuzzCheckRucksacks(f *testing.F) {
+	f.Add(example)
+	f.Fuzz(func(t *testing.T, input string) {
+		if aoctest.Parses(t, func() error { return checkRucksacks(strings.Split(input, "\n")) }) != nil {
+			return
+		}
+		for _, item := range input {
+			if item != '\n' && (itemToPriority(item) < 1 || itemToPriority(item) > 52) {
+				t.Errorf("Accepted item %q has priority %d", item, itemToPriority(item))
+			}
+		}
+	})
+}
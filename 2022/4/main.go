@@ -1,14 +1,17 @@
-package main
+package day4
 
 import (
+	"context"
 	_ "embed"
 	"fmt"
 	"strconv"
 	"strings"
+
+	"github.com/rubinda/aoc"
 )
 
 //go:embed example.in
-var input string
+var example string
 
 type ElfCleaner struct {
 	sectionMin int
@@ -23,15 +26,23 @@ func (a ElfCleaner) fullyContains(b ElfCleaner) bool {
 	return a.sectionMin <= b.sectionMin && a.sectionMax >= b.sectionMax
 }
 
-func parseAssignments(assignmentDesc string) []ElfCleaner {
+func parseAssignments(assignmentDesc string) ([]ElfCleaner, error) {
 	sections := strings.Split(assignmentDesc, ",")
 	cleaners := make([]ElfCleaner, len(sections))
 	for i, section := range sections {
-		bounds := strings.Split(section, "-")
-		cleaners[i].sectionMin, _ = strconv.Atoi(bounds[0])
-		cleaners[i].sectionMax, _ = strconv.Atoi(bounds[1])
+		min, max, found := strings.Cut(section, "-")
+		if !found {
+			return nil, fmt.Errorf("section range %q without a dash", section)
+		}
+		var err error
+		if cleaners[i].sectionMin, err = strconv.Atoi(min); err != nil {
+			return nil, err
+		}
+		if cleaners[i].sectionMax, err = strconv.Atoi(max); err != nil {
+			return nil, err
+		}
 	}
-	return cleaners
+	return cleaners, nil
 }
 
 func isFullyContained(cleaners []ElfCleaner, n int) bool {
@@ -59,10 +70,13 @@ func hasOverlap(cleaners []ElfCleaner, n int) bool {
 	return false
 }
 
-func runChallenge(challengePart int) int {
+func runChallenge(challengePart int, input string) (int, error) {
 	contained := 0
-	for _, assignment := range strings.Split(input, "\n") {
-		cleaners := parseAssignments(assignment)
+	for i, assignment := range strings.Split(input, "\n") {
+		cleaners, err := parseAssignments(assignment)
+		if err != nil {
+			return 0, aoc.LineError(i, assignment, err)
+		}
 
 		for i := range cleaners {
 			if challengePart == 1 && isFullyContained(cleaners, i) {
@@ -75,10 +89,18 @@ func runChallenge(challengePart int) int {
 			}
 		}
 	}
-	return contained
+	return contained, nil
 }
 
-func main() {
-	answer := runChallenge(2)
-	fmt.Println(answer)
+func init() {
+	aoc.Register(aoc.Puzzle{
+		Year:    2022,
+		Day:     4,
+		Title:   "Camp Cleanup",
+		Parts:   2,
+		Example: example,
+		Solver: aoc.SolverFunc(func(ctx context.Context, part int, input string) (any, error) {
+			return runChallenge(part, input)
+		}),
+	})
 }
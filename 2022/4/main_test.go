@@ -1,12 +1,20 @@
-package main
+package day4
 
-import "testing"
+import (
+	"strings"
+	"testing"
+
+	"github.com/rubinda/aoc/internal/aoctest"
+)
 
 const expected1 = 2
 const expected2 = 4
 
 func TestChallenge1(t *testing.T) {
-	actual := runChallenge(1)
+	actual, err := runChallenge(1, example)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if actual != expected1 {
 		t.Errorf("Wrong result! Expected: %d, actual: %d", expected1, actual)
@@ -14,15 +22,40 @@ func TestChallenge1(t *testing.T) {
 }
 
 func TestChallenge2(t *testing.T) {
-	actual := runChallenge(2)
+	actual, err := runChallenge(2, example)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if actual != expected2 {
 		t.Errorf("Wrong result! Expected: %d, actual: %d", expected2, actual)
 	}
 }
 
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 2022, 4)
+}
+
 func Benchmark2(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		runChallenge(2)
+		runChallenge(2, example)
+	}
+}
+
+func FuzzParseAssignments(f *testing.F) {
+	for _, line := range strings.Split(example, "\n") {
+		f.Add(line)
 	}
+	f.Fuzz(func(t *testing.T, line string) {
+		var cleaners []ElfCleaner
+		if aoctest.Parses(t, func() (err error) {
+			cleaners, err = parseAssignments(line)
+			return err
+		}) != nil {
+			return
+		}
+		if len(cleaners) != strings.Count(line, ",")+1 {
+			t.Errorf("Expected %d elves, got %d", strings.Count(line, ",")+1, len(cleaners))
+		}
+	})
 }
@@ -1,11 +1,16 @@
-package main
+package day5
 
 import (
+	"context"
 	_ "embed"
+	"errors"
 	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/rubinda/aoc"
+	"github.com/rubinda/aoc/internal/tracing"
 )
 
 const (
@@ -14,7 +19,10 @@ const (
 )
 
 //go:embed example.in
-var input string
+var example string
+
+// tracer reports every crate stacked by the crane (see aoc run --trace).
+var tracer = tracing.For(2022, 5)
 
 type Stack []string
 
@@ -67,12 +75,32 @@ type MoveInstruction struct {
 	destStack   int
 }
 
-func RecklessParseInt(s string) int {
-	n, _ := strconv.ParseInt(s, 10, 0)
-	return int(n)
+// parseMove reads a move instruction, e.g. "move 1 from 2 to 1".
+// Source and destination stacks are returned 0 based.
+func parseMove(moveDesc string, moveSplitter *regexp.Regexp, numStacks int) (MoveInstruction, error) {
+	// will return [N S D] (N - number of crates, S - source, D - destionation)
+	inst := moveSplitter.FindAllString(moveDesc, -1)
+	if len(inst) != 3 {
+		return MoveInstruction{}, errors.New("expected 3 numbers in a move")
+	}
+	numbers := make([]int, len(inst))
+	for i, s := range inst {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return MoveInstruction{}, err
+		}
+		numbers[i] = n
+	}
+	move := MoveInstruction{nCrates: numbers[0], sourceStack: numbers[1] - 1, destStack: numbers[2] - 1}
+	for _, stack := range []int{move.sourceStack, move.destStack} {
+		if stack < 0 || stack >= numStacks {
+			return MoveInstruction{}, fmt.Errorf("stack %d does not exist", stack+1)
+		}
+	}
+	return move, nil
 }
 
-func parseInput(crateDesc string) (*CargoShip, []MoveInstruction) {
+func parseInput(crateDesc string) (*CargoShip, []MoveInstruction, error) {
 	lines := strings.Split(crateDesc, "\n")
 	crateSectionEnd := 0
 	inCratesSection := true
@@ -80,26 +108,29 @@ func parseInput(crateDesc string) (*CargoShip, []MoveInstruction) {
 	moves := make([]MoveInstruction, 0)
 	moveSplitter := regexp.MustCompile(crateMoveNumbers)
 	for i, line := range lines {
-		//fmt.Println(line)
 		if line == "" {
+			if !inCratesSection {
+				return nil, nil, aoc.LineError(i, line, errors.New("unexpected empty line"))
+			}
+			if i < 1 {
+				return nil, nil, aoc.LineError(i, line, errors.New("missing crate stacks"))
+			}
 			crateSectionEnd = i - 2 // Naming is trivial so ignore the number line
 			numStacks = len(strings.Fields(lines[i-1]))
 			inCratesSection = false
 			continue
 		}
 		if !inCratesSection {
-			// will return [N S D] (N - number of crates, S - source, D - destionation)
-			// Source and destination crates start counting from 1
-			inst := moveSplitter.FindAllString(line, -1)
-			moves = append(moves,
-				MoveInstruction{
-					nCrates:     RecklessParseInt(inst[0]),
-					sourceStack: RecklessParseInt(inst[1]) - 1,
-					destStack:   RecklessParseInt(inst[2]) - 1,
-				},
-			)
+			move, err := parseMove(line, moveSplitter, numStacks)
+			if err != nil {
+				return nil, nil, aoc.LineError(i, line, err)
+			}
+			moves = append(moves, move)
 		}
 	}
+	if inCratesSection {
+		return nil, nil, errors.New("missing empty line between crates and moves")
+	}
 	// Parse the crates in reverse to properly populate stack
 	crateMatcher := regexp.MustCompile(crateCaptureGroup)
 	cargoShip := &CargoShip{}
@@ -109,16 +140,24 @@ func parseInput(crateDesc string) (*CargoShip, []MoveInstruction) {
 		for _, m := range crateMatcher.FindAllStringSubmatchIndex(lines[i], -1) {
 			crateName := lines[i][m[2]:m[3]]
 			crateStack := m[2] / 4
-			//fmt.Printf("Crate named [%s] belongs to stack %d \n", crateName, crateStack)
+			if crateStack >= numStacks {
+				return nil, nil, aoc.LineError(i, lines[i], fmt.Errorf("crate [%s] outside of the %d stacks", crateName, numStacks))
+			}
+			if tracer.Enabled(tracing.Debug) {
+				tracer.Debug("crate stacked", tracing.F("crate", crateName), tracing.F("stack", crateStack+1))
+			}
 			cargoShip.crateStacks[crateStack].Push(crateName)
 		}
 	}
 
-	return cargoShip, moves
+	return cargoShip, moves, nil
 }
 
-func runChallenge(challengePart int) string {
-	ship, moves := parseInput(input)
+func runChallenge(challengePart int, input string) (string, error) {
+	ship, moves, err := parseInput(input)
+	if err != nil {
+		return "", err
+	}
 	result := ""
 	for _, move := range moves {
 		if challengePart == 1 {
@@ -137,10 +176,18 @@ func runChallenge(challengePart int) string {
 		result += stack.PeekLast()
 	}
 
-	return result
+	return result, nil
 }
 
-func main() {
-	result := runChallenge(2)
-	fmt.Println(result)
+func init() {
+	aoc.Register(aoc.Puzzle{
+		Year:    2022,
+		Day:     5,
+		Title:   "Supply Stacks",
+		Parts:   2,
+		Example: example,
+		Solver: aoc.SolverFunc(func(ctx context.Context, part int, input string) (any, error) {
+			return runChallenge(part, input)
+		}),
+	})
 }
@@ -1,12 +1,19 @@
-package main
+package day5
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/rubinda/aoc/internal/aoctest"
+)
 
 const expected1 = "CMZ"
 const expected2 = "MCD"
 
 func TestChallenge1(t *testing.T) {
-	actual := runChallenge(1)
+	actual, err := runChallenge(1, example)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if actual != expected1 {
 		t.Errorf("Wrong result! Expected: %s, actual: %s", expected1, actual)
@@ -14,15 +21,41 @@ func TestChallenge1(t *testing.T) {
 }
 
 func TestChallenge2(t *testing.T) {
-	actual := runChallenge(2)
+	actual, err := runChallenge(2, example)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if actual != expected2 {
 		t.Errorf("Wrong result! Expected: %s, actual: %s", expected2, actual)
 	}
 }
 
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 2022, 5)
+}
+
 func Benchmark2(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		runChallenge(2)
+		runChallenge(2, example)
 	}
 }
+
+func FuzzParseInput(f *testing.F) {
+	f.Add(example)
+	f.Fuzz(func(t *testing.T, input string) {
+		var ship *CargoShip
+		var moves []MoveInstruction
+		if aoctest.Parses(t, func() (err error) {
+			ship, moves, err = parseInput(input)
+			return err
+		}) != nil {
+			return
+		}
+		for _, m := range moves {
+			if m.nCrates < 0 || m.sourceStack < 0 || m.sourceStack >= len(ship.crateStacks) || m.destStack < 0 || m.destStack >= len(ship.crateStacks) {
+				t.Errorf("Invalid move %+v between %d stacks", m, len(ship.crateStacks))
+			}
+		}
+	})
+}
@@ -1,8 +1,12 @@
-package main
+package day6
 
 import (
+	"context"
 	_ "embed"
 	"fmt"
+
+	"github.com/rubinda/aoc"
+	"github.com/rubinda/aoc/internal/tracing"
 )
 
 const (
@@ -11,7 +15,10 @@ const (
 )
 
 //go:embed example.in
-var input string
+var example string
+
+// tracer reports where the marker was found (see aoc run --trace).
+var tracer = tracing.For(2022, 6)
 
 func isUniqueChars(str string) bool {
 	charMap := make(map[rune]bool, len(str))
@@ -25,26 +32,36 @@ func isUniqueChars(str string) bool {
 	return true
 }
 
-func runChallenge(challengePart int) int {
+// runChallenge returns the number of characters processed before the first marker of the part.
+func runChallenge(challengePart int, input string) (int, error) {
 	inputLen := len(input)
-	bufferLen := startMarkerLen
+	bufferLen, marker := startMarkerLen, "start-of-packet"
 	if challengePart == 2 {
-		bufferLen = messageMarkerLen
+		bufferLen, marker = messageMarkerLen, "start-of-message"
 	}
 	for i := range input {
-		if (i + bufferLen) >= inputLen {
+		if (i + bufferLen) > inputLen {
 			break
 		}
 		buffer := input[i : i+bufferLen]
 		if isUniqueChars(buffer) {
-			// fmt.Printf("'%s' @ {%d:%d} is unique \n", buffer, i, i+bufferLen)
+			tracer.Info("marker found", tracing.F("marker", buffer), tracing.F("start", i), tracing.F("end", i+bufferLen))
 			// Return the number of characters processed
-			return i + bufferLen
+			return i + bufferLen, nil
 		}
 	}
-	return -1
+	return 0, fmt.Errorf("no %s marker (%d different characters in a row)", marker, bufferLen)
 }
 
-func main() {
-	fmt.Println(runChallenge(1))
+func init() {
+	aoc.Register(aoc.Puzzle{
+		Year:    2022,
+		Day:     6,
+		Title:   "Tuning Trouble",
+		Parts:   2,
+		Example: example,
+		Solver: aoc.SolverFunc(func(ctx context.Context, part int, input string) (any, error) {
+			return runChallenge(part, input)
+		}),
+	})
 }
@@ -1,12 +1,19 @@
-package main
+package day6
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/rubinda/aoc/internal/aoctest"
+)
 
 const expected1 = 11
 const expected2 = 26
 
 func TestChallenge1(t *testing.T) {
-	actual := runChallenge(1)
+	actual, err := runChallenge(1, example)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if actual != expected1 {
 		t.Errorf("Wrong result! Expected: %v, actual: %v", expected1, actual)
@@ -14,13 +21,47 @@ func TestChallenge1(t *testing.T) {
 }
 
 func TestChallenge2(t *testing.T) {
-	actual := runChallenge(2)
+	actual, err := runChallenge(2, example)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if actual != expected2 {
 		t.Errorf("Wrong result! Expected: %v, actual: %v", expected2, actual)
 	}
 }
 
+func TestMarker(t *testing.T) {
+	tests := []struct {
+		part     int
+		input    string
+		expected int
+	}{
+		// The marker ends with the input
+		{1, "abcd", 4},
+		{1, "aabcd", 5},
+		{2, "abcdefghijklmn", 14},
+	}
+	for _, test := range tests {
+		actual, err := runChallenge(test.part, test.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual != test.expected {
+			t.Errorf("Wrong result! Expected: %v, actual: %v", test.expected, actual)
+		}
+	}
+	for _, input := range []string{"", "abc", "abcabcabc"} {
+		if actual, err := runChallenge(1, input); err == nil {
+			t.Errorf("Expected an error for %q without a marker, got %v", input, actual)
+		}
+	}
+}
+
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 2022, 6)
+}
+
 var benchmarkString = "abcdefghijklmnopqrstuvwxyza"
 
 func BenchmarkIsUniqueChars(b *testing.B) {
@@ -31,6 +72,24 @@ func BenchmarkIsUniqueChars(b *testing.B) {
 
 func Benchmark2(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		runChallenge(2)
+		runChallenge(2, example)
 	}
 }
+
+func FuzzRunChallenge(f *testing.F) {
+	f.Add(example)
+	f.Fuzz(func(t *testing.T, input string) {
+		for part := 1; part <= 2; part++ {
+			var processed int
+			if aoctest.Parses(t, func() (err error) {
+				processed, err = runChallenge(part, input)
+				return err
+			}) != nil {
+				continue
+			}
+			if processed < 4 || processed > len(input) {
+				t.Errorf("Part %d: marker after %d of %d characters", part, processed, len(input))
+			}
+		}
+	})
+}
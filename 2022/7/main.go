@@ -1,10 +1,15 @@
-package main
+package day7
 
 import (
+	"context"
 	_ "embed"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
+
+	"github.com/rubinda/aoc"
+	"github.com/rubinda/aoc/internal/tracing"
 )
 
 const (
@@ -22,7 +27,10 @@ const (
 )
 
 //go:embed example.in
-var input string
+var example string
+
+// tracer reports the directories small enough to count, or big enough to free the space (see aoc run --trace).
+var tracer = tracing.For(2022, 7)
 
 // INode represents a node in the filesystem. Depending on nodeType some properties can be omitted
 type INode struct {
@@ -77,10 +85,13 @@ func (n *INode) PrintSubTree(level int) {
 }
 
 // NewINode parses a 'ls' command input line into a directory or file and appends it to the parent
-func NewINode(desc string, parent *INode) *INode {
+func NewINode(desc string, parent *INode) (*INode, error) {
 	n := &INode{}
 
 	parts := strings.Split(desc, " ")
+	if len(parts) != 2 {
+		return nil, errors.New("expected a size or 'dir' followed by a name")
+	}
 	n.name = parts[1]
 	n.parentDir = parent
 	parent.contents = append(parent.contents, n)
@@ -89,13 +100,16 @@ func NewINode(desc string, parent *INode) *INode {
 		n.nodeType = dirType
 		n.contents = make([]*INode, 0)
 	} else if size, err := strconv.Atoi(parts[0]); err == nil {
+		if size < 0 {
+			return nil, fmt.Errorf("negative size of file %s", n.name)
+		}
 		n.nodeType = fileType
 		n.size = size
 		n.updateParentSize(size)
 	} else {
-		panic("Unknown desc string: " + desc)
+		return nil, err
 	}
-	return n
+	return n, nil
 }
 
 // EmptyDirNode creates a new empty directory INode.
@@ -115,16 +129,28 @@ func EmptyDirNode(name string, parent *INode) *INode {
 // parseInput creates a hierarchical structure of INodes.
 // Returns the root ('/') node.
 // See example.in or challenge.in for desired input.
-func parseInput(in string) *INode {
+func parseInput(in string) (*INode, error) {
 	var root *INode
 	var cwd *INode
-	for _, inst := range strings.Split(input, "\n") {
+	for i, inst := range strings.Split(in, "\n") {
 		parts := strings.Fields(inst)
+		if len(parts) == 0 {
+			return nil, aoc.LineError(i, inst, errors.New("unexpected empty line"))
+		}
 		if parts[0] == commandSign {
+			if len(parts) < 2 {
+				return nil, aoc.LineError(i, inst, errors.New("missing command"))
+			}
 			switch parts[1] {
 			case "cd":
+				if len(parts) != 3 {
+					return nil, aoc.LineError(i, inst, errors.New("cd needs a directory"))
+				}
 				newCwd := parts[2]
 				if newCwd == ".." {
+					if cwd == nil || cwd.parentDir == nil {
+						return nil, aoc.LineError(i, inst, errors.New("already at the top"))
+					}
 					cwd = cwd.parentDir
 					continue
 				}
@@ -141,31 +167,45 @@ func parseInput(in string) *INode {
 			case "ls":
 				// No clue what to do with this information ...
 				continue
+			default:
+				return nil, aoc.LineError(i, inst, fmt.Errorf("unknown command %q", parts[1]))
 			}
 		} else {
+			if cwd == nil {
+				return nil, aoc.LineError(i, inst, errors.New("listing before changing into a directory"))
+			}
 			// Should be contents of cwd until a '$' pops up
-			NewINode(inst, cwd)
+			if _, err := NewINode(inst, cwd); err != nil {
+				return nil, aoc.LineError(i, inst, err)
+			}
 		}
 	}
-	return root
+	if root == nil {
+		return nil, errors.New("root directory '/' never listed")
+	}
+	return root, nil
 }
 
 // runChallenge returns the desired output for the days challenge.
-// May print additional information to stdout
-func runChallenge(challengePart int) int {
+func runChallenge(challengePart int, input string) (int, error) {
 	result := 0
-	root := parseInput(input)
+	root, err := parseInput(input)
+	if err != nil {
+		return 0, err
+	}
 
 	if challengePart == 1 {
 		matches := root.FindDirs(func(n *INode) bool {
 			return n.nodeType == dirType && n.size <= 100000
 		})
-		// fmt.Printf("Found %d directories that match: \n", len(matches))
+		tracer.Info("directories found", tracing.F("count", len(matches)))
 		for _, m := range matches {
-			// fmt.Printf("%10d %s \n", m.size, m.name)
+			if tracer.Enabled(tracing.Debug) {
+				tracer.Debug("directory", tracing.F("name", m.name), tracing.F("size", m.size))
+			}
 			result += m.size
 		}
-		return result
+		return result, nil
 	}
 
 	if challengePart == 2 {
@@ -174,22 +214,32 @@ func runChallenge(challengePart int) int {
 			matches := root.FindDirs(func(n *INode) bool {
 				return n.nodeType == dirType && n.size >= minimumSize
 			})
-			// fmt.Printf("Found %d directories that match: \n", len(matches))
+			tracer.Info("directories found", tracing.F("count", len(matches)), tracing.F("minimumSize", minimumSize))
 			// Find smallest directory that is > minimumSize
 			smallest := -1
 			for _, d := range matches {
-				// fmt.Printf("%10d %s \n", d.size, d.name)
+				if tracer.Enabled(tracing.Debug) {
+					tracer.Debug("directory", tracing.F("name", d.name), tracing.F("size", d.size))
+				}
 				if d.size > minimumSize && (smallest < 0 || d.size < smallest) {
 					smallest = d.size
 				}
 			}
-			return smallest
+			return smallest, nil
 		}
 	}
-	return -1
+	return -1, nil
 }
 
-func main() {
-	res := runChallenge(2)
-	fmt.Println(res)
+func init() {
+	aoc.Register(aoc.Puzzle{
+		Year:    2022,
+		Day:     7,
+		Title:   "No Space Left On Device",
+		Parts:   2,
+		Example: example,
+		Solver: aoc.SolverFunc(func(ctx context.Context, part int, input string) (any, error) {
+			return runChallenge(part, input)
+		}),
+	})
 }
@@ -1,12 +1,19 @@
-package main
+package day7
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/rubinda/aoc/internal/aoctest"
+)
 
 const expected1 = 95437
 const expected2 = 24933642
 
 func TestChallenge1(t *testing.T) {
-	actual := runChallenge(1)
+	actual, err := runChallenge(1, example)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if actual != expected1 {
 		t.Errorf("Wrong result! Expected: %v, actual: %v", expected1, actual)
@@ -14,20 +21,58 @@ func TestChallenge1(t *testing.T) {
 }
 
 func TestChallenge2(t *testing.T) {
-	actual := runChallenge(2)
+	actual, err := runChallenge(2, example)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if actual != expected2 {
 		t.Errorf("Wrong result! Expected: %v, actual: %v", expected2, actual)
 	}
 }
 
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 2022, 7)
+}
+
 func Benchmark1(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		runChallenge(1)
+		runChallenge(1, example)
 	}
 }
 func Benchmark2(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		runChallenge(2)
+		runChallenge(2, example)
 	}
 }
+
+// checkSizes makes sure every directory is as big as its contents.
+func checkSizes(t *testing.T, dir *INode) {
+	t.Helper()
+	total := 0
+	for _, n := range dir.contents {
+		if n.nodeType == dirType {
+			checkSizes(t, n)
+		} else if n.size < 0 {
+			t.Errorf("File %s has a negative size %d", n.name, n.size)
+		}
+		total += n.size
+	}
+	if total != dir.size {
+		t.Errorf("Directory %s has size %d, its contents %d", dir.name, dir.size, total)
+	}
+}
+
+func FuzzParseInput(f *testing.F) {
+	f.Add(example)
+	f.Fuzz(func(t *testing.T, input string) {
+		var root *INode
+		if aoctest.Parses(t, func() (err error) {
+			root, err = parseInput(input)
+			return err
+		}) != nil {
+			return
+		}
+		checkSizes(t, root)
+	})
+}
@@ -1,31 +1,46 @@
-package main
+package day8
 
 import (
+	"context"
 	_ "embed"
+	"errors"
 	"fmt"
+	"image"
+	"image/color"
 	"strconv"
 	"strings"
+
+	"github.com/rubinda/aoc"
+	"github.com/rubinda/aoc/internal/grid"
+	"github.com/rubinda/aoc/internal/viz"
 )
 
-//go:embed example.in
-var input string
+// maxTreeHeight is the height of the tallest trees.
+const maxTreeHeight = 9
 
-// markCondition is used when printing the forest to mark certain trees
-type markCondition func(t *Tree) bool
+var (
+	//go:embed example.in
+	example string
+	// treeColors holds the colors of trees by height in a visualization, followed by the colors of marked trees.
+	treeColors = treePalette()
+	// recorder records the forest after every line of trees (see aoc viz).
+	recorder = viz.For(2022, 8, treeColors)
+)
 
-// direction represents 1 step into a direction (e.g. up, right, down, left)
-type direction struct {
-	y int
-	x int
+// treePalette returns shades of green for trees by height followed by shades of gold for marked trees.
+func treePalette() color.Palette {
+	palette := make(color.Palette, 0, 2*(maxTreeHeight+1))
+	for h := 0; h <= maxTreeHeight; h++ {
+		palette = append(palette, color.RGBA{uint8(0x10 + 0x0c*h), uint8(0x30 + 0x14*h), uint8(0x10 + 0x08*h), 0xff})
+	}
+	for h := 0; h <= maxTreeHeight; h++ {
+		palette = append(palette, color.RGBA{uint8(0x90 + 0x0c*h), uint8(0x60 + 0x0f*h), 0x10, 0xff})
+	}
+	return palette
 }
 
-// Directions are based on the top left corner
-var (
-	up    = direction{-1, 0}
-	right = direction{0, 1}
-	down  = direction{1, 0}
-	left  = direction{0, -1}
-)
+// markCondition is used when printing the forest to mark certain trees
+type markCondition func(t *Tree) bool
 
 // Tree belongs to a forest
 type Tree struct {
@@ -49,8 +64,7 @@ func (t *Tree) isVisibleOver(b *Tree) bool {
 // Forest represents a square collection of trees
 type Forest struct {
 	trees        [][]*Tree
-	width        int
-	depth        int
+	bounds       grid.Rect
 	visibleTrees []*Tree
 	mostScenic   *Tree
 }
@@ -69,6 +83,34 @@ func (f *Forest) PrintMarkedTrees(condition markCondition) {
 	}
 }
 
+// markedTrees is the forest with the trees of a condition marked.
+type markedTrees struct {
+	forest    *Forest
+	condition markCondition
+}
+
+// Frame draws the trees by height, marked ones in another color.
+func (m markedTrees) Frame() *image.Paletted {
+	frame := viz.NewFrame(m.forest.bounds, treeColors)
+	for _, line := range m.forest.trees {
+		for _, tree := range line {
+			index := uint8(tree.height)
+			if m.condition(tree) {
+				index += maxTreeHeight + 1
+			}
+			frame.SetColorIndex(tree.x, tree.y, index)
+		}
+	}
+	return frame
+}
+
+// recordMarkedTrees records the forest with the trees of given condition marked.
+func (f *Forest) recordMarkedTrees(condition markCondition) {
+	if recorder.Enabled() {
+		recorder.Step(markedTrees{f, condition})
+	}
+}
+
 // markVisible sets a tree to visible status
 func (f *Forest) markVisible(t *Tree) {
 	alreadyVisible := t.isVisible
@@ -79,7 +121,7 @@ func (f *Forest) markVisible(t *Tree) {
 }
 
 // checkVisibility manually checks if the tree is highest in given direction and thus visible
-func (f *Forest) checkVisibility(tree *Tree, d direction) {
+func (f *Forest) checkVisibility(tree *Tree, d grid.Point) {
 	neighbour := f.getNeighbour(tree, d)
 	for neighbour != nil {
 		if f.isOnEdge(neighbour) {
@@ -98,7 +140,7 @@ func (f *Forest) checkVisibility(tree *Tree, d direction) {
 
 // isOnEdge returns true if tree is on the perimiter of the forest
 func (f *Forest) isOnEdge(tree *Tree) bool {
-	return tree.x == 0 || tree.y == 0 || tree.x == (f.depth-1) || tree.y == (f.width-1)
+	return tree.x == f.bounds.Min.X || tree.y == f.bounds.Min.Y || tree.x == f.bounds.Max.X || tree.y == f.bounds.Max.Y
 }
 
 // MarkVisibleTrees will set visible status on every tree in forest that fulfills the given conditions
@@ -106,8 +148,8 @@ func (f *Forest) isOnEdge(tree *Tree) bool {
 func (f *Forest) MarkVisibleTrees() {
 	// Becase we traverse from the top left corner, we can store the highest neighbours in these directions
 	// and use them without for instant checking if tree is visible from these directions
-	topHighestLine := make([]*Tree, f.depth)
-	leftHighestLine := make([]*Tree, f.width)
+	topHighestLine := make([]*Tree, f.bounds.Width())
+	leftHighestLine := make([]*Tree, f.bounds.Height())
 
 	// For forest width
 	for y, line := range f.trees {
@@ -134,10 +176,10 @@ func (f *Forest) MarkVisibleTrees() {
 				f.markVisible(tree)
 			} else {
 				// Check into the right direction
-				f.checkVisibility(tree, right)
+				f.checkVisibility(tree, grid.Right)
 				if !tree.isVisible {
 					// Check into the bottom direction
-					f.checkVisibility(tree, down)
+					f.checkVisibility(tree, grid.Down)
 				}
 			}
 			if tree.isVisible {
@@ -150,13 +192,16 @@ func (f *Forest) MarkVisibleTrees() {
 				}
 			}
 		}
+		f.recordMarkedTrees(func(t *Tree) bool {
+			return t.isVisible
+		})
 	}
 }
 
 // viewingDistance returns how many trees are visible from given tree and direction
 // A view into the abyss from the edge has a viewing distance of 0
 // A view into a tree of the same height has a viewing distance of 1
-func (f *Forest) viewingDistance(t *Tree, d direction) int {
+func (f *Forest) viewingDistance(t *Tree, d grid.Point) int {
 	vd := 0
 	neighbour := f.getNeighbour(t, d)
 	for neighbour != nil {
@@ -169,25 +214,18 @@ func (f *Forest) viewingDistance(t *Tree, d direction) int {
 	return vd
 }
 
-// outOfBounds returns true if given position is invalid for current forest
-func (f *Forest) outOfBounds(x, y int) bool {
-	return x < 0 || y < 0 || x >= f.depth || y >= f.width
-}
-
 // getNeighbour gets the next neighbouring tree in given direction. Returns nil if out of bounds
-func (f *Forest) getNeighbour(t *Tree, d direction) *Tree {
-	x := t.x + d.x
-	y := t.y + d.y
-	if f.outOfBounds(x, y) {
+func (f *Forest) getNeighbour(t *Tree, d grid.Point) *Tree {
+	p := grid.Point{X: t.x, Y: t.y}.Add(d)
+	if !f.bounds.Contains(p) {
 		return nil
 	}
-	return f.trees[y][x]
+	return f.trees[p.Y][p.X]
 }
 
 // calculateScenicScores adds a scenic score to each tree in forest
 // Read Advent Of Code 2022 Day 8 Part 2 for details on how to calculate a score
 func (f *Forest) calculateScenicScores() {
-	directions := []direction{up, right, down, left}
 	f.mostScenic = f.trees[0][0]
 
 	for _, line := range f.trees {
@@ -197,7 +235,7 @@ func (f *Forest) calculateScenicScores() {
 				continue
 			}
 			scenicScore := 1
-			for _, d := range directions {
+			for _, d := range grid.Directions4 {
 				scenicScore *= f.viewingDistance(tree, d)
 			}
 			tree.scenicScore = scenicScore
@@ -205,14 +243,16 @@ func (f *Forest) calculateScenicScores() {
 				f.mostScenic = tree
 			}
 		}
+		f.recordMarkedTrees(func(t *Tree) bool {
+			return t == f.mostScenic
+		})
 	}
 }
 
 // Instantiate an empty forest of given size
 func PrepareForest(xLen, yLen int) *Forest {
 	forest := &Forest{}
-	forest.depth = xLen
-	forest.width = yLen
+	forest.bounds = grid.NewRect(xLen, yLen)
 	forest.visibleTrees = make([]*Tree, 0)
 	forest.trees = make([][]*Tree, yLen)
 	for i := range forest.trees {
@@ -222,43 +262,56 @@ func PrepareForest(xLen, yLen int) *Forest {
 }
 
 // parseForest reads the challenge inputs. See example.in or challenge.in
-func parseForest(desc string) *Forest {
+func parseForest(desc string) (*Forest, error) {
 	lines := strings.Split(desc, "\n")
 	yLen := len(lines)
 	xLen := len(strings.Split(lines[0], ""))
+	if xLen == 0 {
+		return nil, errors.New("empty forest")
+	}
 	forest := PrepareForest(xLen, yLen)
 	for y, treeLine := range lines {
-		for x, tH := range strings.Split(treeLine, "") {
-			treeHeight, _ := strconv.Atoi(tH)
+		heights := strings.Split(treeLine, "")
+		if len(heights) != xLen {
+			return nil, aoc.LineError(y, treeLine, fmt.Errorf("expected %d trees in a line, got %d", xLen, len(heights)))
+		}
+		for x, tH := range heights {
+			treeHeight, err := strconv.Atoi(tH)
+			if err != nil {
+				return nil, aoc.LineError(y, treeLine, err)
+			}
 			forest.trees[y][x] = &Tree{x: x, y: y, height: treeHeight}
 		}
 	}
-	return forest
+	return forest, nil
 }
 
 // runChallenge returns the desired output for the days challenge.
-// May print additional information to stdout
-func runChallenge(challengePart int) int {
+func runChallenge(challengePart int, input string) (int, error) {
 	result := -1
-	forest := parseForest(input)
+	forest, err := parseForest(input)
+	if err != nil {
+		return 0, err
+	}
 	if challengePart == 1 {
 		forest.MarkVisibleTrees()
 		result = len(forest.visibleTrees)
-		// forest.PrintMarkedTrees(func(t *Tree) bool {
-		// 	return t.isVisible
-		// })
-		fmt.Println("Number of visible trees", len(forest.visibleTrees))
 	} else if challengePart == 2 {
 		forest.calculateScenicScores()
-		// forest.PrintMarkedTrees(func(t *Tree) bool {
-		// 	return t.x == forest.mostScenic.x && t.y == forest.mostScenic.y
-		// })
-		fmt.Println("Most scenic tree has a score of ", forest.mostScenic.scenicScore)
-		return forest.mostScenic.scenicScore
+		return forest.mostScenic.scenicScore, nil
 	}
-	return result
+	return result, nil
 }
 
-func main() {
-	runChallenge(2)
+func init() {
+	aoc.Register(aoc.Puzzle{
+		Year:    2022,
+		Day:     8,
+		Title:   "Treetop Tree House",
+		Parts:   2,
+		Example: example,
+		Solver: aoc.SolverFunc(func(ctx context.Context, part int, input string) (any, error) {
+			return runChallenge(part, input)
+		}),
+	})
 }
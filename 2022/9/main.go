@@ -1,15 +1,19 @@
-package main
+package day9
 
 import (
+	"context"
 	_ "embed"
+	"errors"
 	"fmt"
 	"math"
 	"strconv"
 	"strings"
+
+	"github.com/rubinda/aoc"
 )
 
 //go:embed example2.in
-var input string
+var example string
 
 // sign returns the integer sign of a number.
 func sign(a int) int {
@@ -130,21 +134,34 @@ func (r *rope) moveHead(move moveInstruction) {
 }
 
 // parseMoves reads the challenge input - move instructions for our snakey rope. See example.in or challenge.in.
-func parseMoves(desc string) []moveInstruction {
-	lines := strings.Split(desc, "\n")
-	moves := make([]moveInstruction, len(lines))
-	for i, line := range lines {
+func parseMoves(desc string) ([]moveInstruction, error) {
+	return aoc.ParseLines(desc, func(line string) (moveInstruction, error) {
 		s := strings.Fields(line)
-		steps, _ := strconv.Atoi(s[1])
-		moves[i] = moveInstruction{direction: directionMap[s[0]], steps: steps}
-	}
-	return moves
+		if len(s) != 2 {
+			return moveInstruction{}, errors.New("expected a direction and number of steps")
+		}
+		direction, ok := directionMap[s[0]]
+		if !ok {
+			return moveInstruction{}, fmt.Errorf("unknown direction %q", s[0])
+		}
+		steps, err := strconv.Atoi(s[1])
+		if err != nil {
+			return moveInstruction{}, err
+		}
+		if steps < 0 {
+			return moveInstruction{}, errors.New("steps can't be negative")
+		}
+		return moveInstruction{direction: direction, steps: steps}, nil
+	})
 }
 
 // runChallenge returns the desired output for the days challenge.
 // May print additional information to stdout.
-func runChallenge(challengePart int) int {
-	moves := parseMoves(input)
+func runChallenge(challengePart int, input string) (int, error) {
+	moves, err := parseMoves(input)
+	if err != nil {
+		return 0, err
+	}
 	knots := 2
 	if challengePart == 2 {
 		knots = 10
@@ -154,10 +171,18 @@ func runChallenge(challengePart int) int {
 	for _, move := range moves {
 		snakeyRope.moveHead(move)
 	}
-	return len(snakeyRope.visitedPositions)
-
+	return len(snakeyRope.visitedPositions), nil
 }
 
-func main() {
-	fmt.Println(runChallenge(2))
+func init() {
+	aoc.Register(aoc.Puzzle{
+		Year:    2022,
+		Day:     9,
+		Title:   "Rope Bridge",
+		Parts:   2,
+		Example: example,
+		Solver: aoc.SolverFunc(func(ctx context.Context, part int, input string) (any, error) {
+			return runChallenge(part, input)
+		}),
+	})
 }
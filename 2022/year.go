@@ -0,0 +1,31 @@
+// Package year2022 registers the solved days of Advent of Code 2022 when imported.
+package year2022
+
+// Every solved day registers itself with the aoc registry when imported.
+import (
+	_ "github.com/rubinda/aoc/2022/1"
+	_ "github.com/rubinda/aoc/2022/10"
+	_ "github.com/rubinda/aoc/2022/11"
+	_ "github.com/rubinda/aoc/2022/12"
+	_ "github.com/rubinda/aoc/2022/13"
+	_ "github.com/rubinda/aoc/2022/14"
+	_ "github.com/rubinda/aoc/2022/15"
+	_ "github.com/rubinda/aoc/2022/16"
+	_ "github.com/rubinda/aoc/2022/17"
+	_ "github.com/rubinda/aoc/2022/18"
+	_ "github.com/rubinda/aoc/2022/19"
+	_ "github.com/rubinda/aoc/2022/2"
+	_ "github.com/rubinda/aoc/2022/20"
+	_ "github.com/rubinda/aoc/2022/21"
+	_ "github.com/rubinda/aoc/2022/22"
+	_ "github.com/rubinda/aoc/2022/23"
+	_ "github.com/rubinda/aoc/2022/24"
+	_ "github.com/rubinda/aoc/2022/25"
+	_ "github.com/rubinda/aoc/2022/3"
+	_ "github.com/rubinda/aoc/2022/4"
+	_ "github.com/rubinda/aoc/2022/5"
+	_ "github.com/rubinda/aoc/2022/6"
+	_ "github.com/rubinda/aoc/2022/7"
+	_ "github.com/rubinda/aoc/2022/8"
+	_ "github.com/rubinda/aoc/2022/9"
+)
@@ -0,0 +1,104 @@
+package aoc
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// AnswersVersion is the version of the answers file format written by this package.
+const AnswersVersion = 1
+
+// Answer is a known correct answer to a challenge part for a specific puzzle input.
+type Answer struct {
+	Year int `json:"year"`
+	Day  int `json:"day"`
+	Part int `json:"part"`
+	// Input is the InputHash of the puzzle input the answer belongs to.
+	Input  string `json:"input"`
+	Answer string `json:"answer"`
+}
+
+// Answers is a collection of known answers, usually stored in answers.json.
+type Answers struct {
+	Version int      `json:"version"`
+	Answers []Answer `json:"answers"`
+}
+
+// InputHash returns the hex encoded SHA-256 of the (normalized, see ReadInput) puzzle input.
+func InputHash(input string) string {
+	sum := sha256.Sum256([]byte(input))
+	return hex.EncodeToString(sum[:])
+}
+
+// FormatAnswer converts a solver answer into the text stored in the answers file.
+func FormatAnswer(answer any) string {
+	return fmt.Sprint(answer)
+}
+
+// LoadAnswers reads known answers from a JSON file. A missing file results in an empty collection.
+func LoadAnswers(path string) (*Answers, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Answers{Version: AnswersVersion}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	answers := &Answers{}
+	if err := json.Unmarshal(data, answers); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if answers.Version < 1 || answers.Version > AnswersVersion {
+		return nil, fmt.Errorf("%s: unsupported answers version %d (supported up to %d)", path, answers.Version, AnswersVersion)
+	}
+	return answers, nil
+}
+
+// Find returns the known answer for given puzzle part and input hash.
+func (a *Answers) Find(year, day, part int, inputHash string) (string, bool) {
+	for _, known := range a.Answers {
+		if known.Year == year && known.Day == day && known.Part == part && known.Input == inputHash {
+			return known.Answer, true
+		}
+	}
+	return "", false
+}
+
+// Set records an answer, replacing a previously known answer for the same puzzle part and input.
+func (a *Answers) Set(answer Answer) {
+	for i, known := range a.Answers {
+		if known.Year == answer.Year && known.Day == answer.Day && known.Part == answer.Part && known.Input == answer.Input {
+			a.Answers[i] = answer
+			return
+		}
+	}
+	a.Answers = append(a.Answers, answer)
+}
+
+// Save writes the answers sorted by year, day, part and input hash so the file diffs nicely.
+func (a *Answers) Save(path string) error {
+	a.Version = AnswersVersion
+	sort.Slice(a.Answers, func(i, j int) bool {
+		x, y := a.Answers[i], a.Answers[j]
+		if x.Year != y.Year {
+			return x.Year < y.Year
+		}
+		if x.Day != y.Day {
+			return x.Day < y.Day
+		}
+		if x.Part != y.Part {
+			return x.Part < y.Part
+		}
+		return x.Input < y.Input
+	})
+	data, err := json.MarshalIndent(a, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
@@ -0,0 +1,46 @@
+package aoc
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAnswersRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.json")
+	answers, err := LoadAnswers(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := InputHash("1\n2\n3")
+	answers.Set(Answer{Year: 2022, Day: 2, Part: 1, Input: hash, Answer: "wrong"})
+	answers.Set(Answer{Year: 2022, Day: 1, Part: 1, Input: hash, Answer: "6"})
+	answers.Set(Answer{Year: 2022, Day: 2, Part: 1, Input: hash, Answer: "15"})
+	if err := answers.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadAnswers(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Answers) != 2 || loaded.Answers[0].Day != 1 {
+		t.Errorf("Wrong answers saved! Actual: %v", loaded.Answers)
+	}
+	if actual, ok := loaded.Find(2022, 2, 1, hash); !ok || actual != "15" {
+		t.Errorf("Wrong result! Expected: %v, actual: %v", "15", actual)
+	}
+	if _, ok := loaded.Find(2022, 2, 2, hash); ok {
+		t.Error("Found answer for a part that was never recorded")
+	}
+}
+
+func TestLoadAnswersVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.json")
+	if err := os.WriteFile(path, []byte(`{"version": 99, "answers": []}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadAnswers(path); err == nil {
+		t.Error("Expected an error for an unsupported answers version")
+	}
+}
@@ -0,0 +1,173 @@
+// Package aoc holds the registry of solved Advent of Code challenges.
+// Each day package registers its solver on init, the aoc command then looks it up by year and day.
+package aoc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+var (
+	// ErrNotFound is returned when no solver is registered for the requested year and day.
+	ErrNotFound = errors.New("puzzle not found")
+	// ErrUnknownPart is returned when a puzzle has no such challenge part.
+	ErrUnknownPart = errors.New("unknown challenge part")
+	// ErrPanic is returned when a solver panics, e.g. on input it doesn't expect.
+	ErrPanic = errors.New("solver panicked")
+	// ErrUnknownImplementation is returned when a puzzle has no implementation of given name.
+	ErrUnknownImplementation = errors.New("unknown implementation")
+)
+
+// Solver solves the challenge of a single day.
+type Solver interface {
+	// Solve returns the answer for the given challenge part and puzzle input.
+	// Solvers that take long check ctx in their main loops and return its error once it is done.
+	Solve(ctx context.Context, part int, input io.Reader) (any, error)
+}
+
+// SolverFunc allows the use of ordinary functions that take the whole puzzle input as text as solvers.
+type SolverFunc func(ctx context.Context, part int, input string) (any, error)
+
+// Solve reads the puzzle input (see ReadInput) and calls f(ctx, part, input).
+func (f SolverFunc) Solve(ctx context.Context, part int, input io.Reader) (any, error) {
+	text, err := ReadInput(input)
+	if err != nil {
+		return nil, err
+	}
+	return f(ctx, part, text)
+}
+
+// Key identifies a puzzle in the registry.
+type Key struct {
+	Year int
+	Day  int
+}
+
+// String returns the key in YEAR/DAY format.
+func (k Key) String() string {
+	return fmt.Sprintf("%d/%d", k.Year, k.Day)
+}
+
+// Puzzle describes a registered day's challenge.
+type Puzzle struct {
+	Year  int
+	Day   int
+	Title string
+	// Parts is the number of challenge parts the solver answers (starting with 1).
+	Parts int
+	// Example is the example input from the puzzle description.
+	Example string
+	// PartExamples holds examples of parts that don't use Example.
+	PartExamples map[int]string
+	Solver       Solver
+	// Implementations holds other solvers of the same challenge by name, e.g. a naive one next to an optimized Solver.
+	// All of them have to give the same answers as Solver, aoctest.Differential checks that on generated inputs.
+	Implementations map[string]Solver
+}
+
+// ExampleInput returns the example input for given challenge part.
+func (p Puzzle) ExampleInput(part int) string {
+	if example, ok := p.PartExamples[part]; ok {
+		return example
+	}
+	return p.Example
+}
+
+// Implementation returns the puzzle solved by the implementation of given name instead of Solver.
+func (p Puzzle) Implementation(name string) (Puzzle, error) {
+	solver, ok := p.Implementations[name]
+	if !ok {
+		return Puzzle{}, fmt.Errorf("%v: %w %q", p.Key(), ErrUnknownImplementation, name)
+	}
+	p.Solver = solver
+	return p, nil
+}
+
+// ImplementationNames returns the names of the puzzle's other implementations, sorted.
+func (p Puzzle) ImplementationNames() []string {
+	names := make([]string, 0, len(p.Implementations))
+	for name := range p.Implementations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Key returns the registry key of the puzzle.
+func (p Puzzle) Key() Key {
+	return Key{p.Year, p.Day}
+}
+
+// Solve returns the answer for the given challenge part and puzzle input.
+// A panicking solver results in an ErrPanic error instead of crashing the caller.
+// Solving doesn't start if ctx is already done.
+func (p Puzzle) Solve(ctx context.Context, part int, input io.Reader) (answer any, err error) {
+	if part < 1 || part > p.Parts {
+		return nil, fmt.Errorf("%v: %w %d", p.Key(), ErrUnknownPart, part)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			answer, err = nil, fmt.Errorf("%w: %v", ErrPanic, r)
+		}
+	}()
+	return p.Solver.Solve(ctx, part, input)
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[Key]Puzzle)
+)
+
+// Register makes a puzzle available to the runner.
+// Register panics if called twice for the same year and day or if the puzzle has no solver.
+func Register(p Puzzle) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if p.Solver == nil {
+		panic(fmt.Sprintf("aoc: Register solver is nil for %v", p.Key()))
+	}
+	for name, solver := range p.Implementations {
+		if solver == nil {
+			panic(fmt.Sprintf("aoc: Register implementation %q is nil for %v", name, p.Key()))
+		}
+	}
+	if _, dup := registry[p.Key()]; dup {
+		panic(fmt.Sprintf("aoc: Register called twice for %v", p.Key()))
+	}
+	registry[p.Key()] = p
+}
+
+// Lookup returns the puzzle registered for given year and day.
+func Lookup(year, day int) (Puzzle, error) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	p, ok := registry[Key{year, day}]
+	if !ok {
+		return Puzzle{}, fmt.Errorf("%v: %w", Key{year, day}, ErrNotFound)
+	}
+	return p, nil
+}
+
+// Puzzles returns all registered puzzles sorted by year and day.
+func Puzzles() []Puzzle {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	puzzles := make([]Puzzle, 0, len(registry))
+	for _, p := range registry {
+		puzzles = append(puzzles, p)
+	}
+	sort.Slice(puzzles, func(i, j int) bool {
+		if puzzles[i].Year != puzzles[j].Year {
+			return puzzles[i].Year < puzzles[j].Year
+		}
+		return puzzles[i].Day < puzzles[j].Day
+	})
+	return puzzles
+}
@@ -0,0 +1,125 @@
+package aoc
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// Registry tests use year 1 so they don't collide with real puzzles.
+const testYear = 1
+
+func init() {
+	Register(Puzzle{
+		Year:  testYear,
+		Day:   2,
+		Title: "Second",
+		Parts: 2,
+		Solver: SolverFunc(func(ctx context.Context, part int, input string) (any, error) {
+			return part * len(input), nil
+		}),
+		Implementations: map[string]Solver{
+			"slow": SolverFunc(func(ctx context.Context, part int, input string) (any, error) {
+				answer := 0
+				for i := 0; i < part; i++ {
+					answer += len(input)
+				}
+				return answer, nil
+			}),
+		},
+	})
+	Register(Puzzle{
+		Year:  testYear,
+		Day:   1,
+		Title: "First",
+		Parts: 1,
+		Solver: SolverFunc(func(ctx context.Context, part int, input string) (any, error) {
+			if input == "panic" {
+				panic("unexpected input")
+			}
+			return input, nil
+		}),
+	})
+}
+
+func TestLookup(t *testing.T) {
+	p, err := Lookup(testYear, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual, err := p.Solve(context.Background(), 2, strings.NewReader("12345\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual != 10 {
+		t.Errorf("Wrong result! Expected: %v, actual: %v", 10, actual)
+	}
+
+	if _, err := Lookup(testYear, 3); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Wrong error! Expected: %v, actual: %v", ErrNotFound, err)
+	}
+}
+
+func TestImplementation(t *testing.T) {
+	p, _ := Lookup(testYear, 2)
+	if names := p.ImplementationNames(); len(names) != 1 || names[0] != "slow" {
+		t.Errorf("Wrong implementations! Actual: %v", names)
+	}
+	slow, err := p.Implementation("slow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual, err := slow.Solve(context.Background(), 2, strings.NewReader("12345\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual != 10 {
+		t.Errorf("Wrong result! Expected: %v, actual: %v", 10, actual)
+	}
+
+	if _, err := p.Implementation("fast"); !errors.Is(err, ErrUnknownImplementation) {
+		t.Errorf("Wrong error! Expected: %v, actual: %v", ErrUnknownImplementation, err)
+	}
+}
+
+func TestSolveUnknownPart(t *testing.T) {
+	p, _ := Lookup(testYear, 1)
+	for _, part := range []int{0, 2} {
+		if _, err := p.Solve(context.Background(), part, strings.NewReader("")); !errors.Is(err, ErrUnknownPart) {
+			t.Errorf("Wrong error for part %d! Expected: %v, actual: %v", part, ErrUnknownPart, err)
+		}
+	}
+}
+
+func TestSolveCancelled(t *testing.T) {
+	p, _ := Lookup(testYear, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := p.Solve(ctx, 1, strings.NewReader("12345\n")); !errors.Is(err, context.Canceled) {
+		t.Errorf("Wrong error! Expected: %v, actual: %v", context.Canceled, err)
+	}
+}
+
+func TestSolvePanic(t *testing.T) {
+	p, _ := Lookup(testYear, 1)
+	if _, err := p.Solve(context.Background(), 1, strings.NewReader("panic")); !errors.Is(err, ErrPanic) {
+		t.Errorf("Wrong error! Expected: %v, actual: %v", ErrPanic, err)
+	}
+}
+
+func TestPuzzlesSorted(t *testing.T) {
+	puzzles := Puzzles()
+	if len(puzzles) != 2 || puzzles[0].Day != 1 || puzzles[1].Day != 2 {
+		t.Errorf("Wrong order! Actual: %v", puzzles)
+	}
+}
+
+func TestRegisterTwice(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected Register to panic on duplicate key")
+		}
+	}()
+	Register(Puzzle{Year: testYear, Day: 1, Solver: SolverFunc(nil)})
+}
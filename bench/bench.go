@@ -0,0 +1,218 @@
+// Package bench measures and profiles solver performance and compares it against a saved baseline.
+package bench
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rubinda/aoc"
+)
+
+// BaselineVersion is the version of the baseline file format written by this package.
+const BaselineVersion = 1
+
+// Result holds the measurements of a single challenge part on a specific input.
+type Result struct {
+	Year int `json:"year"`
+	Day  int `json:"day"`
+	Part int `json:"part"`
+	// Input is the aoc.InputHash of the benchmarked input.
+	Input       string `json:"input"`
+	Runs        int    `json:"runs"`
+	NsPerOp     int64  `json:"ns_per_op"`
+	BytesPerOp  int64  `json:"bytes_per_op"`
+	AllocsPerOp int64  `json:"allocs_per_op"`
+}
+
+// sameBenchmark returns true if both results measure the same part on the same input.
+func (r Result) sameBenchmark(o Result) bool {
+	return r.Year == o.Year && r.Day == o.Day && r.Part == o.Part && r.Input == o.Input
+}
+
+// Baseline is a set of results together with the environment they were measured in.
+type Baseline struct {
+	Version   int       `json:"version"`
+	Created   time.Time `json:"created"`
+	GoVersion string    `json:"go_version"`
+	GOOS      string    `json:"goos"`
+	GOARCH    string    `json:"goarch"`
+	CPUs      int       `json:"cpus"`
+	Results   []Result  `json:"results"`
+}
+
+// NewBaseline returns an empty baseline for the current environment.
+func NewBaseline() *Baseline {
+	return &Baseline{
+		Version:   BaselineVersion,
+		Created:   time.Now().UTC().Truncate(time.Second),
+		GoVersion: runtime.Version(),
+		GOOS:      runtime.GOOS,
+		GOARCH:    runtime.GOARCH,
+		CPUs:      runtime.NumCPU(),
+		Results:   make([]Result, 0),
+	}
+}
+
+// Find returns the result of the same benchmark as r.
+func (b *Baseline) Find(r Result) (Result, bool) {
+	for _, known := range b.Results {
+		if known.sameBenchmark(r) {
+			return known, true
+		}
+	}
+	return Result{}, false
+}
+
+// Load reads a baseline from a JSON file.
+func Load(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	b := &Baseline{}
+	if err := json.Unmarshal(data, b); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if b.Version < 1 || b.Version > BaselineVersion {
+		return nil, fmt.Errorf("%s: unsupported baseline version %d (supported up to %d)", path, b.Version, BaselineVersion)
+	}
+	return b, nil
+}
+
+// Save writes the baseline as JSON.
+func (b *Baseline) Save(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Run benchmarks a challenge part on given input for at least benchtime (and at least one run).
+// Like testing.B, the number of runs grows until the measurement takes long enough.
+func Run(ctx context.Context, p aoc.Puzzle, part int, input string, benchtime time.Duration) (Result, error) {
+	result := Result{Year: p.Year, Day: p.Day, Part: part, Input: aoc.InputHash(input)}
+	solve := func() error {
+		_, err := p.Solve(ctx, part, strings.NewReader(input))
+		return err
+	}
+	// The first run catches errors and warms up
+	if err := solve(); err != nil {
+		return result, err
+	}
+
+	runs := 1
+	for {
+		elapsed, mallocs, bytes, err := measure(solve, runs)
+		if err != nil {
+			return result, err
+		}
+		if elapsed >= benchtime || runs >= 1e9 {
+			result.Runs = runs
+			result.NsPerOp = elapsed.Nanoseconds() / int64(runs)
+			result.AllocsPerOp = int64(mallocs) / int64(runs)
+			result.BytesPerOp = int64(bytes) / int64(runs)
+			return result, nil
+		}
+		runs = predictRuns(runs, elapsed, benchtime)
+	}
+}
+
+// measure runs f n times and returns the elapsed time with allocations made.
+func measure(f func() error, n int) (elapsed time.Duration, mallocs, bytes uint64, err error) {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	for i := 0; i < n; i++ {
+		if err := f(); err != nil {
+			return 0, 0, 0, err
+		}
+	}
+	elapsed = time.Since(start)
+	runtime.ReadMemStats(&after)
+	return elapsed, after.Mallocs - before.Mallocs, after.TotalAlloc - before.TotalAlloc, nil
+}
+
+// predictRuns estimates the number of runs needed to reach benchtime (growing at most 100x, at least by 1).
+func predictRuns(runs int, elapsed, benchtime time.Duration) int {
+	next := runs * 100
+	if elapsed > 0 {
+		// Aim 20% over the target so we don't fall just short of it
+		next = int(int64(runs) * benchtime.Nanoseconds() * 6 / 5 / elapsed.Nanoseconds())
+	}
+	if next > runs*100 {
+		next = runs * 100
+	}
+	if next <= runs {
+		next = runs + 1
+	}
+	return next
+}
+
+// Comparison holds a current result and the matching baseline result.
+type Comparison struct {
+	Current Result
+	Base    Result
+	// HasBase is false if the baseline has no result of the same benchmark.
+	HasBase bool
+	// Change is the relative change of ns/op (0.5 means 50% slower, -0.5 twice as fast).
+	Change float64
+	// Regression is true if the change is over the allowed threshold.
+	Regression bool
+}
+
+// Compare matches current results to the baseline and flags those that got slower by more than threshold.
+func Compare(base *Baseline, current []Result, threshold float64) []Comparison {
+	comparisons := make([]Comparison, len(current))
+	for i, r := range current {
+		c := Comparison{Current: r}
+		c.Base, c.HasBase = base.Find(r)
+		if c.HasBase && c.Base.NsPerOp > 0 {
+			c.Change = float64(r.NsPerOp-c.Base.NsPerOp) / float64(c.Base.NsPerOp)
+			c.Regression = c.Change > threshold
+		}
+		comparisons[i] = c
+	}
+	return comparisons
+}
+
+// Merge adds results to the baseline, replacing earlier results of the same benchmarks.
+func (b *Baseline) Merge(results []Result) {
+	for _, r := range results {
+		replaced := false
+		for i := range b.Results {
+			if b.Results[i].sameBenchmark(r) {
+				b.Results[i] = r
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			b.Results = append(b.Results, r)
+		}
+	}
+	sort.Slice(b.Results, func(i, j int) bool {
+		x, y := b.Results[i], b.Results[j]
+		if x.Year != y.Year {
+			return x.Year < y.Year
+		}
+		if x.Day != y.Day {
+			return x.Day < y.Day
+		}
+		if x.Part != y.Part {
+			return x.Part < y.Part
+		}
+		return x.Input < y.Input
+	})
+}
+
+// ErrRegression is returned when a benchmark is slower than the baseline allows.
+var ErrRegression = errors.New("performance regression")
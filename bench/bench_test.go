@@ -0,0 +1,113 @@
+package bench
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rubinda/aoc"
+)
+
+// testPuzzle counts the lines of input, part 2 fails.
+var testPuzzle = aoc.Puzzle{
+	Year:  1,
+	Day:   1,
+	Parts: 2,
+	Solver: aoc.SolverFunc(func(ctx context.Context, part int, input string) (any, error) {
+		if part == 2 {
+			return nil, errors.New("not solved")
+		}
+		return len(strings.Split(input, "\n")), nil
+	}),
+}
+
+func TestRun(t *testing.T) {
+	r, err := Run(context.Background(), testPuzzle, 1, "1\n2\n3", 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Runs < 2 || r.NsPerOp <= 0 || r.Input != aoc.InputHash("1\n2\n3") {
+		t.Errorf("Unexpected result: %+v", r)
+	}
+	if _, err := Run(context.Background(), testPuzzle, 2, "1", time.Millisecond); err == nil {
+		t.Error("Expected solver error to be returned")
+	}
+}
+
+func TestSolve(t *testing.T) {
+	answer, m, err := Solve(context.Background(), testPuzzle, 1, strings.NewReader("1\n2\n3"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if answer != 3 || m.Wall <= 0 || m.Allocs == 0 {
+		t.Errorf("Unexpected answer %v or measurement %+v", answer, m)
+	}
+	if _, _, err := Solve(context.Background(), testPuzzle, 2, strings.NewReader("1")); err == nil {
+		t.Error("Expected solver error to be returned")
+	}
+}
+
+func TestStartProfile(t *testing.T) {
+	if _, err := StartProfile("block", filepath.Join(t.TempDir(), "block")); err == nil {
+		t.Error("Expected an error for an unknown profile")
+	}
+	path := ProfilePath(t.TempDir(), "mem", aoc.Key{Year: 1, Day: 1}, 1)
+	if expected := "1-1-1.mem.pprof"; filepath.Base(path) != expected {
+		t.Errorf("Wrong result! Expected: %v, actual: %v", expected, filepath.Base(path))
+	}
+	stop, err := StartProfile("mem", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stop(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	base := NewBaseline()
+	base.Merge([]Result{
+		{Year: 1, Day: 1, Part: 1, Input: "a", NsPerOp: 100},
+		{Year: 1, Day: 1, Part: 2, Input: "a", NsPerOp: 100},
+	})
+	current := []Result{
+		{Year: 1, Day: 1, Part: 1, Input: "a", NsPerOp: 300},
+		{Year: 1, Day: 1, Part: 2, Input: "a", NsPerOp: 105},
+		{Year: 1, Day: 1, Part: 2, Input: "b", NsPerOp: 100},
+	}
+	comparisons := Compare(base, current, 0.1)
+	expected := []struct {
+		hasBase, regression bool
+		change              float64
+	}{
+		{true, true, 2},
+		{true, false, 0.05},
+		{false, false, 0},
+	}
+	for i, c := range comparisons {
+		e := expected[i]
+		if c.HasBase != e.hasBase || c.Regression != e.regression || c.Change != e.change {
+			t.Errorf("Wrong comparison %d! Expected: %+v, actual: %+v", i, e, c)
+		}
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	b := NewBaseline()
+	b.Merge([]Result{{Year: 1, Day: 2, Part: 1, Input: "a", NsPerOp: 1}, {Year: 1, Day: 1, Part: 1, Input: "a", NsPerOp: 1}})
+	b.Merge([]Result{{Year: 1, Day: 2, Part: 1, Input: "a", NsPerOp: 2}})
+	if err := b.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Results) != 2 || loaded.Results[0].Day != 1 || loaded.Results[1].NsPerOp != 2 {
+		t.Errorf("Wrong results! Actual: %+v", loaded.Results)
+	}
+}
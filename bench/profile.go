@@ -0,0 +1,89 @@
+package bench
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"time"
+
+	"github.com/rubinda/aoc"
+)
+
+// Profiles are the kinds of profiles StartProfile can take.
+var Profiles = []string{"cpu", "mem", "trace"}
+
+// Measurement holds what solving a part once took.
+type Measurement struct {
+	Wall   time.Duration
+	Allocs uint64
+	Bytes  uint64
+}
+
+// String returns the measurement as e.g. "1.532ms, 2301 allocs, 187904 B".
+func (m Measurement) String() string {
+	return fmt.Sprintf("%v, %d allocs, %d B", m.Wall.Round(time.Microsecond), m.Allocs, m.Bytes)
+}
+
+// Solve solves a challenge part once and measures its wall time and allocations (including reading the input).
+func Solve(ctx context.Context, p aoc.Puzzle, part int, input io.Reader) (answer any, m Measurement, err error) {
+	m.Wall, m.Allocs, m.Bytes, err = measure(func() error {
+		answer, err = p.Solve(ctx, part, input)
+		return err
+	}, 1)
+	return answer, m, err
+}
+
+// ProfilePath returns the file in dir for a profile of given kind taken while solving a part,
+// e.g. 2022-14-1.cpu.pprof (runtime/trace files end with .trace.out).
+func ProfilePath(dir, kind string, key aoc.Key, part int) string {
+	ext := "pprof"
+	if kind == "trace" {
+		ext = "out"
+	}
+	return filepath.Join(dir, fmt.Sprintf("%d-%d-%d.%s.%s", key.Year, key.Day, part, kind, ext))
+}
+
+// StartProfile starts a profile of given kind (see Profiles) written to path once stop is called.
+// A cpu profile samples the CPU usage with runtime/pprof, mem writes the allocations made (since the start
+// of the program) and trace records an execution trace with runtime/trace.
+func StartProfile(kind, path string) (stop func() error, err error) {
+	switch kind {
+	case "cpu", "mem", "trace":
+	default:
+		return nil, fmt.Errorf("unknown profile %q, expected one of %v", kind, Profiles)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	switch kind {
+	case "cpu":
+		err = pprof.StartCPUProfile(f)
+	case "trace":
+		err = trace.Start(f)
+	}
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return func() error {
+		switch kind {
+		case "cpu":
+			pprof.StopCPUProfile()
+		case "mem":
+			runtime.GC()
+			if err := pprof.Lookup("allocs").WriteTo(f, 0); err != nil {
+				f.Close()
+				return err
+			}
+		case "trace":
+			trace.Stop()
+		}
+		return f.Close()
+	}, nil
+}
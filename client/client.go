@@ -0,0 +1,192 @@
+// Package client talks to the Advent of Code website: it downloads puzzle inputs (and keeps them in a local cache)
+// and submits answers, while making sure requests are spaced out politely.
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rubinda/aoc"
+)
+
+const (
+	// DefaultBaseURL is the Advent of Code website.
+	DefaultBaseURL = "https://adventofcode.com"
+	// DefaultInterval is the least time between two requests to the website.
+	DefaultInterval = 5 * time.Second
+	// UserAgent identifies this tool to the website, as asked by the Advent of Code automation guidelines.
+	UserAgent = "github.com/rubinda/aoc"
+
+	// inputFile is the name of a cached puzzle input inside the day's cache directory.
+	inputFile = "input.txt"
+	// stampFile records when the last request was made, so the interval holds between separate runs.
+	stampFile = ".last-request"
+	// maxInputSize is the most bytes read from an input response. Real inputs are a few dozen kilobytes.
+	maxInputSize = 4 << 20
+	// maxErrorBody is the most bytes of an unexpected response shown in an error.
+	maxErrorBody = 200
+)
+
+var (
+	// ErrNoSession is returned when a request needs the session cookie and none is configured.
+	ErrNoSession = errors.New("no session cookie configured")
+	// ErrUnauthorized is returned when the website doesn't accept the session cookie.
+	ErrUnauthorized = errors.New("session cookie not accepted (log in again and update the config)")
+	// ErrNotAvailable is returned for puzzles that don't exist or aren't unlocked yet.
+	ErrNotAvailable = errors.New("puzzle not available")
+)
+
+// Client downloads puzzle inputs from and submits answers to the Advent of Code website.
+type Client struct {
+	// BaseURL is the website address without a trailing slash.
+	BaseURL string
+	// Session is the value of the website's session cookie.
+	Session string
+	// CacheDir holds downloaded inputs in YEAR/DAY subdirectories (see aoc.InputDir) and the log of submitted answers.
+	CacheDir string
+	// Interval is the least time between two requests.
+	Interval time.Duration
+	HTTP     *http.Client
+
+	// now and sleep are replaced in tests.
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// New returns a client for the website in the config that caches inputs in cacheDir.
+func New(cfg Config, cacheDir string) *Client {
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return &Client{
+		BaseURL:  strings.TrimRight(baseURL, "/"),
+		Session:  cfg.Session,
+		CacheDir: cacheDir,
+		Interval: DefaultInterval,
+		HTTP:     &http.Client{Timeout: 30 * time.Second},
+		now:      time.Now,
+		sleep:    sleep,
+	}
+}
+
+// InputPath returns where the input of given year and day is cached.
+func (c *Client) InputPath(year, day int) string {
+	return filepath.Join(aoc.InputDir(c.CacheDir, year, day), inputFile)
+}
+
+// Input returns the puzzle input of given year and day. A cached input is returned without contacting the website.
+func (c *Client) Input(ctx context.Context, year, day int) (string, error) {
+	path := c.InputPath(year, day)
+	if data, err := os.ReadFile(path); err == nil {
+		return string(data), nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return "", err
+	}
+	return c.Download(ctx, year, day)
+}
+
+// Download fetches the puzzle input of given year and day from the website and stores it in the cache.
+func (c *Client) Download(ctx context.Context, year, day int) (string, error) {
+	resp, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/%d/day/%d/input", year, day), nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(resp); err != nil {
+		return "", fmt.Errorf("%d/%d input: %w", year, day, err)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxInputSize))
+	if err != nil {
+		return "", fmt.Errorf("%d/%d input: %w", year, day, err)
+	}
+	if len(data) == 0 {
+		return "", fmt.Errorf("%d/%d input: empty response", year, day)
+	}
+
+	path := c.InputPath(year, day)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return "", err
+	}
+	// Inputs are personal, keep them readable by the owner only
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// do sends an authenticated request to the website, waiting for the interval since the previous request first.
+func (c *Client) do(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
+	if c.Session == "" {
+		return nil, ErrNoSession
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", UserAgent)
+	if body != nil {
+		// The only requests with a body are answer forms
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	req.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+	if err := c.wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.HTTP.Do(req)
+}
+
+// wait sleeps until the interval since the last request has passed and records the new request.
+func (c *Client) wait(ctx context.Context) error {
+	if err := os.MkdirAll(c.CacheDir, 0o700); err != nil {
+		return err
+	}
+	stamp := filepath.Join(c.CacheDir, stampFile)
+	if data, err := os.ReadFile(stamp); err == nil {
+		if last, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(string(data))); err == nil {
+			if remaining := last.Add(c.Interval).Sub(c.now()); remaining > 0 {
+				if err := c.sleep(ctx, remaining); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return os.WriteFile(stamp, []byte(c.now().UTC().Format(time.RFC3339Nano)), 0o600)
+}
+
+// sleep waits for the duration, or returns the context's error if it is done first.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// checkStatus converts unsuccessful responses to errors.
+func checkStatus(resp *http.Response) error {
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		return nil
+	case resp.StatusCode == http.StatusNotFound:
+		return ErrNotAvailable
+	// The website answers with 400 when the session cookie is missing or expired
+	case resp.StatusCode == http.StatusBadRequest, resp.StatusCode == http.StatusUnauthorized, resp.StatusCode == http.StatusForbidden:
+		return ErrUnauthorized
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	return fmt.Errorf("unexpected response %s: %s", resp.Status, strings.TrimSpace(string(body)))
+}
@@ -0,0 +1,233 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rubinda/aoc"
+	"github.com/rubinda/aoc/client/clienttest"
+)
+
+const testSession = "53616c7465645f5f"
+
+// newTestClient returns a client for a stand-in server serving the input of 2022/1.
+// The client's clock only moves when it sleeps, the sleeps are recorded.
+func newTestClient(t *testing.T) (*Client, *clienttest.Server, *[]time.Duration) {
+	t.Helper()
+	server := clienttest.NewServer(testSession, map[aoc.Key]string{
+		{Year: 2022, Day: 1}: "1000\n2000",
+	})
+	t.Cleanup(server.Close)
+
+	c := New(Config{Session: testSession, BaseURL: server.URL}, t.TempDir())
+	now := time.Date(2022, 12, 1, 5, 0, 0, 0, time.UTC)
+	slept := make([]time.Duration, 0)
+	c.now = func() time.Time { return now }
+	c.sleep = func(_ context.Context, d time.Duration) error {
+		slept = append(slept, d)
+		now = now.Add(d)
+		return nil
+	}
+	return c, server, &slept
+}
+
+func TestInputCached(t *testing.T) {
+	c, server, _ := newTestClient(t)
+	for i := 0; i < 2; i++ {
+		input, err := c.Input(context.Background(), 2022, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := "1000\n2000\n"; input != expected {
+			t.Errorf("Wrong input! Expected: %q, actual: %q", expected, input)
+		}
+	}
+	requests := server.Requests()
+	if len(requests) != 1 {
+		t.Fatalf("Expected a single request, got %d", len(requests))
+	}
+	if ua := requests[0].UserAgent(); ua != UserAgent {
+		t.Errorf("Wrong user agent! Expected: %q, actual: %q", UserAgent, ua)
+	}
+	info, err := os.Stat(c.InputPath(2022, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("Cached input should only be readable by the owner, has mode %v", info.Mode())
+	}
+}
+
+func TestDownloadInterval(t *testing.T) {
+	c, server, slept := newTestClient(t)
+	for i := 0; i < 3; i++ {
+		if _, err := c.Download(context.Background(), 2022, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(server.Requests()) != 3 {
+		t.Errorf("Expected 3 requests, got %d", len(server.Requests()))
+	}
+	// The first request doesn't have to wait, the following ones wait for the whole interval
+	if len(*slept) != 2 || (*slept)[0] != DefaultInterval || (*slept)[1] != DefaultInterval {
+		t.Errorf("Wrong waits between requests: %v", *slept)
+	}
+
+	// A new client (e.g. the next run of the command) still waits for the rest of the interval
+	next := New(Config{Session: testSession, BaseURL: server.URL}, c.CacheDir)
+	next.now = func() time.Time { return c.now().Add(2 * time.Second) }
+	next.sleep = func(_ context.Context, d time.Duration) error {
+		*slept = append(*slept, d)
+		return nil
+	}
+	if _, err := next.Download(context.Background(), 2022, 1); err != nil {
+		t.Fatal(err)
+	}
+	if last := (*slept)[len(*slept)-1]; last != DefaultInterval-2*time.Second {
+		t.Errorf("Wrong wait for the next run! Expected: %v, actual: %v", DefaultInterval-2*time.Second, last)
+	}
+}
+
+func TestDownloadCanceled(t *testing.T) {
+	c, server, _ := newTestClient(t)
+	if _, err := c.Download(context.Background(), 2022, 1); err != nil {
+		t.Fatal(err)
+	}
+	// The next request has to wait for the whole interval in real time, unless it is canceled
+	c.sleep = sleep
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := c.Download(ctx, 2022, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wrong error! Expected: %v, actual: %v", context.DeadlineExceeded, err)
+	}
+	if elapsed := time.Since(start); elapsed > DefaultInterval/2 {
+		t.Errorf("Canceled download kept waiting for %v", elapsed)
+	}
+	if len(server.Requests()) != 1 {
+		t.Errorf("Canceled download shouldn't be sent, got %d requests", len(server.Requests()))
+	}
+}
+
+func TestInputErrors(t *testing.T) {
+	c, server, _ := newTestClient(t)
+	if _, err := c.Input(context.Background(), 2022, 2); !errors.Is(err, ErrNotAvailable) {
+		t.Errorf("Wrong error! Expected: %v, actual: %v", ErrNotAvailable, err)
+	}
+
+	c.Session = "expired"
+	if _, err := c.Input(context.Background(), 2022, 1); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Wrong error! Expected: %v, actual: %v", ErrUnauthorized, err)
+	}
+
+	c.Session = ""
+	requests := len(server.Requests())
+	if _, err := c.Input(context.Background(), 2022, 1); !errors.Is(err, ErrNoSession) {
+		t.Errorf("Wrong error! Expected: %v, actual: %v", ErrNoSession, err)
+	}
+	if len(server.Requests()) != requests {
+		t.Error("Requests without a session shouldn't be sent")
+	}
+	if _, err := os.Stat(c.InputPath(2022, 2)); !errors.Is(err, os.ErrNotExist) {
+		t.Error("Failed downloads shouldn't be cached")
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"session": "from-file", "base_url": "http://localhost"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(SessionEnv, "")
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Session != "from-file" || cfg.BaseURL != "http://localhost" {
+		t.Errorf("Wrong config: %+v", cfg)
+	}
+
+	t.Setenv(SessionEnv, "from-env")
+	if cfg, err = LoadConfig(filepath.Join(t.TempDir(), "missing.json")); err != nil || cfg.Session != "from-env" {
+		t.Errorf("Expected session from the environment, got %+v, %v", cfg, err)
+	}
+}
+
+func TestParseResult(t *testing.T) {
+	page := func(message string) string {
+		return "<!DOCTYPE html>\n<html><body><main>\n<article><p>" + message + " <a href=\"/2022/day/1\">[Return to Day 1]</a></p></article>\n</main></body></html>"
+	}
+	tests := []struct {
+		page    string
+		verdict Verdict
+		wait    time.Duration
+	}{
+		{page("That's the right answer!  You are <span class=\"day-success\">one gold star</span> closer to collecting enough star fruit."), Correct, 0},
+		{page("That's not the right answer; your answer is too high.  If you're stuck, make sure you're using the full input data; there are also some general tips on the <a href=\"/2022/about\">about page</a>. Please wait one minute before trying again."), TooHigh, time.Minute},
+		{page("That's not the right answer; your answer is too low.  Please wait one minute before trying again."), TooLow, time.Minute},
+		{page("That's not the right answer.  Because you have guessed incorrectly 4 times on this puzzle, please wait 5 minutes before trying again."), Wrong, 5 * time.Minute},
+		{page("You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 1m 5s left to wait."), TooSoon, time.Minute + 5*time.Second},
+		{page("You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 42s left to wait."), TooSoon, 42 * time.Second},
+		{page("You don't seem to be solving the right level.  Did you already complete it?"), WrongLevel, 0},
+		{"<html>Something else</html>", Unknown, 0},
+	}
+	for _, test := range tests {
+		result := ParseResult(test.page)
+		if result.Verdict != test.verdict || result.Wait != test.wait {
+			t.Errorf("Wrong result for %q! Expected: %v (wait %v), actual: %v (wait %v)", result.Message, test.verdict, test.wait, result.Verdict, result.Wait)
+		}
+	}
+}
+
+func TestSubmit(t *testing.T) {
+	c, server, _ := newTestClient(t)
+	server.SetAnswer(2022, 1, 1, "24000")
+	server.SetAnswer(2022, 2, 1, "15")
+	ctx := context.Background()
+
+	result, err := c.Submit(ctx, 2022, 1, 1, "30000")
+	if err != nil || result.Verdict != TooHigh || result.Wait != time.Minute {
+		t.Fatalf("Expected too high with a minute to wait, got %+v, %v", result, err)
+	}
+	// The cooldown is enforced without asking the website
+	requests := len(server.Requests())
+	if _, err := c.Submit(ctx, 2022, 1, 1, "24000"); !errors.Is(err, ErrCooldown) {
+		t.Errorf("Wrong error! Expected: %v, actual: %v", ErrCooldown, err)
+	}
+	c.sleep(ctx, time.Minute)
+	for _, answer := range []string{"30000", "31000"} {
+		if _, err := c.Submit(ctx, 2022, 1, 1, answer); !errors.Is(err, ErrKnownWrong) {
+			t.Errorf("Wrong error for %s! Expected: %v, actual: %v", answer, ErrKnownWrong, err)
+		}
+	}
+	if len(server.Requests()) != requests {
+		t.Error("Answers known to be wrong or too soon shouldn't be sent")
+	}
+	// The website's own (real time) cooldown hasn't passed yet
+	if result, err := c.Submit(ctx, 2022, 1, 1, "24000"); err != nil || result.Verdict != TooSoon || result.Wait == 0 {
+		t.Errorf("Expected too soon with a wait, got %+v, %v", result, err)
+	}
+
+	if result, err := c.Submit(ctx, 2022, 2, 1, "15\n"); err != nil || result.Verdict != Correct {
+		t.Errorf("Expected a correct answer, got %+v, %v", result, err)
+	}
+	if _, err := c.Submit(ctx, 2022, 2, 1, "15"); !errors.Is(err, ErrSolved) {
+		t.Errorf("Wrong error! Expected: %v, actual: %v", ErrSolved, err)
+	}
+
+	guesses, err := c.Guesses()
+	if err != nil {
+		t.Fatal(err)
+	}
+	verdicts := make([]Verdict, 0, len(guesses))
+	for _, g := range guesses {
+		verdicts = append(verdicts, g.Verdict)
+	}
+	if len(verdicts) != 3 || verdicts[0] != TooHigh || verdicts[1] != TooSoon || verdicts[2] != Correct {
+		t.Errorf("Wrong guess log: %v", verdicts)
+	}
+}
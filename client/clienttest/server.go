@@ -0,0 +1,169 @@
+// Package clienttest provides a local stand-in for the Advent of Code website, so the client can be tested
+// without a network connection.
+package clienttest
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rubinda/aoc"
+)
+
+// Server serves puzzle inputs at /YEAR/day/DAY/input and checks answers posted to /YEAR/day/DAY/answer
+// for requests with the right session cookie.
+type Server struct {
+	*httptest.Server
+	// Session is the only session cookie the server accepts.
+	Session string
+	// Cooldown is how long the server refuses answers for a day after a wrong one.
+	Cooldown time.Duration
+
+	mu       sync.Mutex
+	inputs   map[aoc.Key]string
+	answers  map[part]string
+	solved   map[part]bool
+	until    map[aoc.Key]time.Time
+	requests []*http.Request
+}
+
+// part identifies a single challenge part.
+type part struct {
+	key   aoc.Key
+	level int
+}
+
+// NewServer starts a server that accepts given session cookie and serves given inputs.
+// The caller should call Close when finished.
+func NewServer(session string, inputs map[aoc.Key]string) *Server {
+	s := &Server{
+		Session:  session,
+		Cooldown: time.Minute,
+		inputs:   inputs,
+		answers:  make(map[part]string),
+		solved:   make(map[part]bool),
+		until:    make(map[aoc.Key]time.Time),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", s.serve)
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// Requests returns the requests received so far.
+func (s *Server) Requests() []*http.Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*http.Request(nil), s.requests...)
+}
+
+// record remembers a request and returns false (after writing the response) if it isn't logged in.
+func (s *Server) record(w http.ResponseWriter, r *http.Request) bool {
+	s.mu.Lock()
+	s.requests = append(s.requests, r)
+	s.mu.Unlock()
+	if cookie, err := r.Cookie("session"); err != nil || cookie.Value != s.Session {
+		// Same as the website, which doesn't use 401 for missing logins
+		http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+// SetAnswer sets the right answer to a part of the puzzle of given year and day.
+func (s *Server) SetAnswer(year, day, level int, answer string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.answers[part{aoc.Key{Year: year, Day: day}, level}] = answer
+}
+
+// serve routes the requests for a day's input and answers.
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	var year, day int
+	var rest string
+	if n, _ := fmt.Sscanf(r.URL.Path, "/%d/day/%d/%s", &year, &day, &rest); n == 3 {
+		key := aoc.Key{Year: year, Day: day}
+		switch {
+		case rest == "input" && r.Method == http.MethodGet:
+			s.serveInput(w, r, key)
+			return
+		case rest == "answer" && r.Method == http.MethodPost:
+			s.serveAnswer(w, r, key)
+			return
+		}
+	}
+	http.NotFound(w, r)
+}
+
+// serveInput responds with the input of the requested day.
+func (s *Server) serveInput(w http.ResponseWriter, r *http.Request, key aoc.Key) {
+	if !s.record(w, r) {
+		return
+	}
+	input, ok := s.inputs[key]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	// Inputs are served with a trailing newline, like the real ones
+	fmt.Fprintln(w, input)
+}
+
+// serveAnswer checks a posted answer and responds with a page in the style of the website.
+func (s *Server) serveAnswer(w http.ResponseWriter, r *http.Request, key aoc.Key) {
+	if !s.record(w, r) {
+		return
+	}
+	level, _ := strconv.Atoi(r.PostFormValue("level"))
+	answer := r.PostFormValue("answer")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := part{key, level}
+	right, ok := s.answers[p]
+	now := time.Now()
+	switch {
+	case !ok || s.solved[p]:
+		writeArticle(w, "You don't seem to be solving the right level.  Did you already complete it?")
+	case now.Before(s.until[key]):
+		left := s.until[key].Sub(now).Round(time.Second)
+		writeArticle(w, fmt.Sprintf("You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have %s left to wait.", formatLeft(left)))
+	case answer == right:
+		s.solved[p] = true
+		writeArticle(w, "That's the right answer!  You are one gold star closer to saving your vacation.")
+	default:
+		s.until[key] = now.Add(s.Cooldown)
+		hint := ""
+		guess, err1 := strconv.Atoi(answer)
+		want, err2 := strconv.Atoi(right)
+		if err1 == nil && err2 == nil {
+			hint = "; your answer is too low"
+			if guess > want {
+				hint = "; your answer is too high"
+			}
+		}
+		wait := "one minute"
+		if minutes := int(s.Cooldown.Minutes()); minutes != 1 {
+			wait = fmt.Sprintf("%d minutes", minutes)
+		}
+		writeArticle(w, fmt.Sprintf("That's not the right answer%s.  If you're stuck, make sure you're using the full input data. "+
+			"Please wait %s before trying again.", hint, wait))
+	}
+}
+
+// formatLeft formats the remaining wait like the website, e.g. "1m 5s".
+func formatLeft(d time.Duration) string {
+	if d >= time.Minute {
+		return fmt.Sprintf("%dm %ds", int(d.Minutes()), int(d.Seconds())%60)
+	}
+	return fmt.Sprintf("%ds", int(d.Seconds()))
+}
+
+// writeArticle writes a page with the message as its main article.
+func writeArticle(w http.ResponseWriter, message string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprintf(w, "<!DOCTYPE html>\n<html lang=\"en-us\">\n<body>\n<main>\n<article><p>%s <a href=\"/\">[Return]</a></p></article>\n</main>\n</body>\n</html>\n", message)
+}
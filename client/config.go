@@ -0,0 +1,48 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// SessionEnv is the environment variable that overrides the session cookie from the config file.
+const SessionEnv = "AOC_SESSION"
+
+// Config holds the settings needed to talk to the website.
+type Config struct {
+	// Session is the value of the "session" cookie of a logged in browser.
+	Session string `json:"session"`
+	// BaseURL replaces DefaultBaseURL, e.g. to use a local stand-in server.
+	BaseURL string `json:"base_url,omitempty"`
+}
+
+// DefaultConfigPath returns the config file in the user's configuration directory (e.g. ~/.config/aoc/config.json).
+func DefaultConfigPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "aoc", "config.json"), nil
+}
+
+// LoadConfig reads the config file at path. A missing file results in an empty config.
+// The session cookie from SessionEnv takes precedence over the file.
+func LoadConfig(path string) (Config, error) {
+	cfg := Config{}
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return Config{}, fmt.Errorf("reading config %s: %w", path, err)
+		}
+	case !errors.Is(err, os.ErrNotExist):
+		return Config{}, err
+	}
+	if session := os.Getenv(SessionEnv); session != "" {
+		cfg.Session = session
+	}
+	return cfg, nil
+}
@@ -0,0 +1,130 @@
+package client
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// guessFile is the log of submitted answers in the cache directory, one JSON object per line.
+const guessFile = "guesses.jsonl"
+
+// Guess is a submitted answer and the website's verdict.
+type Guess struct {
+	Year    int       `json:"year"`
+	Day     int       `json:"day"`
+	Part    int       `json:"part"`
+	Answer  string    `json:"answer"`
+	Verdict Verdict   `json:"verdict"`
+	Time    time.Time `json:"time"`
+	// Until is when the website accepts the next answer for the day, zero if it didn't ask to wait.
+	Until time.Time `json:"until"`
+}
+
+// GuessesPath returns the path of the log of submitted answers.
+func (c *Client) GuessesPath() string {
+	return filepath.Join(c.CacheDir, guessFile)
+}
+
+// Guesses returns all submitted answers in the order they were sent.
+func (c *Client) Guesses() ([]Guess, error) {
+	path := c.GuessesPath()
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	guesses := make([]Guess, 0)
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var g Guess
+		if err := json.Unmarshal(scanner.Bytes(), &g); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		guesses = append(guesses, g)
+	}
+	return guesses, scanner.Err()
+}
+
+// logGuess appends a guess to the log.
+func (c *Client) logGuess(g Guess) error {
+	data, err := json.Marshal(g)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.CacheDir, 0o700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(c.GuessesPath(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// checkGuess returns an error if the previous guesses for the part show that the answer is wrong
+// (or the part is already solved). Numeric answers are also checked against earlier too high and too low guesses.
+func checkGuess(guesses []Guess, year, day, part int, answer string) error {
+	value, numErr := strconv.Atoi(answer)
+	for _, g := range guesses {
+		if g.Year != year || g.Day != day || g.Part != part {
+			continue
+		}
+		switch {
+		case g.Verdict == Correct:
+			return fmt.Errorf("%w with answer %s", ErrSolved, g.Answer)
+		case g.Verdict.IsWrong() && g.Answer == answer:
+			return fmt.Errorf("%w: %s was %v", ErrKnownWrong, answer, g.Verdict)
+		}
+		previous, err := strconv.Atoi(g.Answer)
+		if numErr != nil || err != nil {
+			continue
+		}
+		if g.Verdict == TooHigh && value > previous {
+			return fmt.Errorf("%w: %s is too high, %s already was", ErrKnownWrong, answer, g.Answer)
+		}
+		if g.Verdict == TooLow && value < previous {
+			return fmt.Errorf("%w: %s is too low, %s already was", ErrKnownWrong, answer, g.Answer)
+		}
+	}
+	return nil
+}
+
+// cooldown returns when the website accepts the next answer for given year and day.
+func cooldown(guesses []Guess, year, day int) time.Time {
+	var until time.Time
+	for _, g := range guesses {
+		if g.Year == year && g.Day == day && g.Until.After(until) {
+			until = g.Until
+		}
+	}
+	return until
+}
+
+// Cooldown returns how long to wait before the next answer for given year and day can be submitted.
+func (c *Client) Cooldown(year, day int) (time.Duration, error) {
+	guesses, err := c.Guesses()
+	if err != nil {
+		return 0, err
+	}
+	if remaining := cooldown(guesses, year, day).Sub(c.now()); remaining > 0 {
+		return remaining, nil
+	}
+	return 0, nil
+}
@@ -0,0 +1,188 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxPageSize is the most bytes read from an answer response page.
+const maxPageSize = 1 << 20
+
+// Verdict is the website's judgement of a submitted answer.
+type Verdict int
+
+const (
+	// Unknown means the response page wasn't recognized.
+	Unknown Verdict = iota
+	// Correct means the answer was right and the part is solved.
+	Correct
+	// Wrong means the answer was wrong and the website gave no hint.
+	Wrong
+	// TooHigh means the answer was wrong and the right one is smaller.
+	TooHigh
+	// TooLow means the answer was wrong and the right one is larger.
+	TooLow
+	// TooSoon means the answer wasn't checked, because the cooldown after a previous answer hadn't passed.
+	TooSoon
+	// WrongLevel means the answer wasn't checked, because the part is already solved or not unlocked yet.
+	WrongLevel
+)
+
+var verdictNames = [...]string{
+	Unknown:    "unknown",
+	Correct:    "correct",
+	Wrong:      "wrong",
+	TooHigh:    "too high",
+	TooLow:     "too low",
+	TooSoon:    "too soon",
+	WrongLevel: "wrong level",
+}
+
+// String returns the name of the verdict.
+func (v Verdict) String() string {
+	if v < 0 || int(v) >= len(verdictNames) {
+		return fmt.Sprintf("Verdict(%d)", int(v))
+	}
+	return verdictNames[v]
+}
+
+// IsWrong reports whether the answer was checked and rejected.
+func (v Verdict) IsWrong() bool {
+	return v == Wrong || v == TooHigh || v == TooLow
+}
+
+// MarshalText encodes the verdict as its name.
+func (v Verdict) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText decodes a verdict name.
+func (v *Verdict) UnmarshalText(text []byte) error {
+	for i, name := range verdictNames {
+		if name == string(text) {
+			*v = Verdict(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown verdict %q", text)
+}
+
+// Result is the website's response to a submitted answer.
+type Result struct {
+	Verdict Verdict
+	// Wait is how long the website asks to wait before submitting the next answer.
+	Wait time.Duration
+	// Message is the text of the response, without markup.
+	Message string
+}
+
+var (
+	// ErrKnownWrong is returned when an answer is wrong according to the previous guesses.
+	ErrKnownWrong = errors.New("answer is known to be wrong")
+	// ErrSolved is returned when the part was already solved with a previous guess.
+	ErrSolved = errors.New("already solved")
+	// ErrCooldown is returned when an answer is submitted before the website's cooldown has passed.
+	ErrCooldown = errors.New("too soon to submit another answer")
+)
+
+var (
+	articlePattern = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	tagPattern     = regexp.MustCompile(`<[^>]*>`)
+	// leftPattern matches the remaining wait after answering too soon, e.g. "You have 1m 5s left to wait".
+	leftPattern = regexp.MustCompile(`(?:(\d+)m )?(\d+)s left to wait`)
+	// waitPattern matches the wait after a wrong answer, e.g. "please wait one minute" or "wait 5 minutes".
+	waitPattern = regexp.MustCompile(`(?i)wait (\w+) minutes?`)
+)
+
+// minutes are the numbers the website spells out in the wait after a wrong answer.
+var minutes = map[string]int{"one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "ten": 10}
+
+// ParseResult recognizes the verdict in the page returned after submitting an answer.
+func ParseResult(page string) Result {
+	text := page
+	if m := articlePattern.FindStringSubmatch(page); m != nil {
+		text = m[1]
+	}
+	text = html.UnescapeString(tagPattern.ReplaceAllString(text, " "))
+	result := Result{Message: strings.Join(strings.Fields(text), " ")}
+
+	switch msg := result.Message; {
+	case strings.Contains(msg, "That's the right answer"):
+		result.Verdict = Correct
+	case strings.Contains(msg, "That's not the right answer"):
+		switch {
+		case strings.Contains(msg, "your answer is too high"):
+			result.Verdict = TooHigh
+		case strings.Contains(msg, "your answer is too low"):
+			result.Verdict = TooLow
+		default:
+			result.Verdict = Wrong
+		}
+		if m := waitPattern.FindStringSubmatch(msg); m != nil {
+			n, ok := minutes[strings.ToLower(m[1])]
+			if !ok {
+				n, _ = strconv.Atoi(m[1])
+			}
+			result.Wait = time.Duration(n) * time.Minute
+		}
+	case strings.Contains(msg, "You gave an answer too recently"):
+		result.Verdict = TooSoon
+		if m := leftPattern.FindStringSubmatch(msg); m != nil {
+			mins, _ := strconv.Atoi(m[1])
+			secs, _ := strconv.Atoi(m[2])
+			result.Wait = time.Duration(mins)*time.Minute + time.Duration(secs)*time.Second
+		}
+	case strings.Contains(msg, "You don't seem to be solving the right level"):
+		result.Verdict = WrongLevel
+	}
+	return result
+}
+
+// Submit sends the answer to a part of the puzzle of given year and day and records the guess in the cache.
+// Answers that previous guesses show to be wrong aren't sent, neither are answers before the cooldown has passed.
+func (c *Client) Submit(ctx context.Context, year, day, part int, answer string) (Result, error) {
+	answer = strings.TrimSpace(answer)
+	if answer == "" || strings.Contains(answer, "\n") {
+		return Result{}, fmt.Errorf("answer %q is not a single line", answer)
+	}
+	guesses, err := c.Guesses()
+	if err != nil {
+		return Result{}, err
+	}
+	if err := checkGuess(guesses, year, day, part, answer); err != nil {
+		return Result{}, err
+	}
+	if remaining := cooldown(guesses, year, day).Sub(c.now()); remaining > 0 {
+		return Result{}, fmt.Errorf("%w: %v left to wait", ErrCooldown, remaining.Round(time.Second))
+	}
+
+	form := url.Values{"level": {strconv.Itoa(part)}, "answer": {answer}}
+	resp, err := c.do(ctx, http.MethodPost, fmt.Sprintf("/%d/day/%d/answer", year, day), strings.NewReader(form.Encode()))
+	if err != nil {
+		return Result{}, err
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(resp); err != nil {
+		return Result{}, fmt.Errorf("%d/%d answer: %w", year, day, err)
+	}
+	page, err := io.ReadAll(io.LimitReader(resp.Body, maxPageSize))
+	if err != nil {
+		return Result{}, fmt.Errorf("%d/%d answer: %w", year, day, err)
+	}
+
+	result := ParseResult(string(page))
+	guess := Guess{Year: year, Day: day, Part: part, Answer: answer, Verdict: result.Verdict, Time: c.now().UTC()}
+	if result.Wait > 0 {
+		guess.Until = guess.Time.Add(result.Wait)
+	}
+	return result, c.logGuess(guess)
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/rubinda/aoc/bench"
+)
+
+// benchCmd benchmarks all (or selected) puzzles and optionally compares them to a saved baseline.
+func benchCmd(ctx context.Context, args []string, s streams) error {
+	fs := flag.NewFlagSet("bench", flag.ContinueOnError)
+	part := fs.Int("part", 0, "only benchmark this challenge part")
+	useExample := fs.Bool("example", false, "benchmark the examples instead of personal inputs")
+	inputsDir := fs.String("inputs", ".", "directory with puzzle inputs in YEAR/DAY/ subdirectories")
+	cacheDir := fs.String("cache", defaultCacheDir, "directory with inputs downloaded by fetch, searched after the inputs directory")
+	benchtime := fs.Duration("benchtime", time.Second, "minimum run time of each benchmark")
+	savePath := fs.String("save", "", "merge results into this baseline file")
+	baselinePath := fs.String("baseline", "", "compare results with this baseline file")
+	threshold := fs.Float64("threshold", 0.1, "relative ns/op increase over the baseline reported as a regression")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	puzzles, err := selectPuzzles(positional)
+	if err != nil {
+		return err
+	}
+	var base *bench.Baseline
+	if *baselinePath != "" {
+		if base, err = bench.Load(*baselinePath); err != nil {
+			return err
+		}
+	}
+
+	results := make([]bench.Result, 0)
+	inputNames := make(map[string]string)
+	skipped := 0
+	for _, p := range puzzles {
+		var inputs []puzzleInput
+		if *useExample {
+			inputs = exampleInputs(p)
+		} else {
+			input, found, err := personalInput(p, *inputsDir, *cacheDir)
+			if err != nil {
+				return fmt.Errorf("%v: %w", p.Key(), err)
+			}
+			if found {
+				inputs = append(inputs, input)
+			}
+		}
+		if len(inputs) == 0 {
+			skipped++
+			continue
+		}
+		for _, input := range inputs {
+			for pt := 1; pt <= p.Parts; pt++ {
+				if (input.part != 0 && input.part != pt) || (*part != 0 && *part != pt) {
+					continue
+				}
+				r, err := bench.Run(ctx, p, pt, input.text, *benchtime)
+				if err != nil {
+					return fmt.Errorf("%v part %d: %w", p.Key(), pt, err)
+				}
+				inputNames[r.Input] = input.name
+				results = append(results, r)
+			}
+		}
+	}
+
+	w := tabwriter.NewWriter(s.stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	regressions := 0
+	if base == nil {
+		fmt.Fprintln(w, "YEAR\tDAY\tPART\tINPUT\tRUNS\tNS/OP\tB/OP\tALLOCS/OP\t")
+		for _, r := range results {
+			fmt.Fprintf(w, "%d\t%d\t%d\t%s\t%d\t%d\t%d\t%d\t\n",
+				r.Year, r.Day, r.Part, inputNames[r.Input], r.Runs, r.NsPerOp, r.BytesPerOp, r.AllocsPerOp)
+		}
+	} else {
+		fmt.Fprintln(w, "YEAR\tDAY\tPART\tINPUT\tBASE NS/OP\tNS/OP\tCHANGE\tB/OP\tALLOCS/OP\t\t")
+		for _, c := range bench.Compare(base, results, *threshold) {
+			r := c.Current
+			baseNs, change, note := "-", "-", "new"
+			if c.HasBase {
+				baseNs = fmt.Sprint(c.Base.NsPerOp)
+				change = fmt.Sprintf("%+.1f%%", c.Change*100)
+				note = ""
+			}
+			if c.Regression {
+				note = "SLOWER"
+				regressions++
+			}
+			fmt.Fprintf(w, "%d\t%d\t%d\t%s\t%s\t%d\t%s\t%d\t%d\t%s\t\n",
+				r.Year, r.Day, r.Part, inputNames[r.Input], baseNs, r.NsPerOp, change, r.BytesPerOp, r.AllocsPerOp, note)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if skipped > 0 {
+		fmt.Fprintf(s.stdout, "\nSkipped %d days without a puzzle input (use --example or --inputs DIR)\n", skipped)
+	}
+
+	if *savePath != "" {
+		saved, err := bench.Load(*savePath)
+		if errors.Is(err, os.ErrNotExist) {
+			saved = bench.NewBaseline()
+		} else if err != nil {
+			return err
+		}
+		env := bench.NewBaseline()
+		env.Results = saved.Results
+		env.Merge(results)
+		if err := env.Save(*savePath); err != nil {
+			return err
+		}
+		fmt.Fprintf(s.stdout, "Saved %d results to %s\n", len(results), *savePath)
+	}
+	if regressions > 0 {
+		return fmt.Errorf("%w: %d benchmarks more than %.0f%% slower than the baseline", bench.ErrRegression, regressions, *threshold*100)
+	}
+	return nil
+}
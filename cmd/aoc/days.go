@@ -0,0 +1,6 @@
+package main
+
+// Every year registers its solved days with the aoc registry when imported.
+import (
+	_ "github.com/rubinda/aoc/2022"
+)
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rubinda/aoc"
+	"github.com/rubinda/aoc/scaffold"
+)
+
+// examplesCmd adds the examples of a puzzle description (the day's readme by default) to the day's examples file.
+func examplesCmd(_ context.Context, args []string, s streams) error {
+	fs := flag.NewFlagSet("examples", flag.ContinueOnError)
+	from := fs.String("from", "", "puzzle description, a saved page or Markdown (default the day's readme.md)")
+	root := fs.String("root", ".", "repository root")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 2 {
+		return fmt.Errorf("%w: expected YEAR and DAY", errUsage)
+	}
+	year, day, err := parseYearDay(positional[0], positional[1])
+	if err != nil {
+		return err
+	}
+	dir := scaffold.Dir(*root, year, day)
+	if *from == "" {
+		*from = filepath.Join(dir, "readme.md")
+	}
+	description, err := os.ReadFile(*from)
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(dir, aoc.ExamplesFile)
+	known, err := aoc.LoadExamples(path)
+	if err != nil {
+		return err
+	}
+	before := len(known)
+	examples := scaffold.MergeExamples(dir, known, scaffold.ExtractExamples(string(description)))
+	if len(examples) == 0 {
+		return fmt.Errorf("%s: no examples with answers found", *from)
+	}
+	if err := aoc.SaveExamples(path, examples); err != nil {
+		return err
+	}
+	fmt.Fprintf(s.stdout, "%d examples in %s (%d new)\n", len(examples), path, len(examples)-before)
+	return nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/rubinda/aoc/client"
+)
+
+// defaultCacheDir is where fetch stores downloaded inputs. It is ignored by git.
+const defaultCacheDir = ".aoc-cache"
+
+// fetchCmd downloads the puzzle input of a day into the cache.
+func fetchCmd(ctx context.Context, args []string, s streams) error {
+	fs := flag.NewFlagSet("fetch", flag.ContinueOnError)
+	configPath := fs.String("config", "", "config file with the session cookie (default in the user config directory)")
+	cacheDir := fs.String("cache", defaultCacheDir, "directory for downloaded inputs")
+	force := fs.Bool("force", false, "download again even if the input is cached")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 2 {
+		return fmt.Errorf("%w: expected YEAR and DAY", errUsage)
+	}
+	year, day, err := parseYearDay(positional[0], positional[1])
+	if err != nil {
+		return err
+	}
+	c, err := newClient(*configPath, *cacheDir)
+	if err != nil {
+		return err
+	}
+
+	if *force {
+		_, err = c.Download(ctx, year, day)
+	} else {
+		_, err = c.Input(ctx, year, day)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(s.stdout, c.InputPath(year, day))
+	return nil
+}
+
+// newClient returns a website client configured from the config file (or the default one if path is empty).
+func newClient(configPath, cacheDir string) (*client.Client, error) {
+	if configPath == "" {
+		var err error
+		if configPath, err = client.DefaultConfigPath(); err != nil {
+			return nil, err
+		}
+	}
+	cfg, err := client.LoadConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Session == "" {
+		return nil, fmt.Errorf("%w: add it to %s or set %s", client.ErrNoSession, configPath, client.SessionEnv)
+	}
+	return client.New(cfg, cacheDir), nil
+}
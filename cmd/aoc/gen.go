@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rubinda/aoc/gen"
+	// Every year registers its input generators with the gen registry when imported.
+	_ "github.com/rubinda/aoc/gen/2022"
+)
+
+// genCmd writes a random puzzle input of a day, e.g. to stress test its solver.
+func genCmd(_ context.Context, args []string, s streams) error {
+	fs := flag.NewFlagSet("gen", flag.ContinueOnError)
+	size := fs.Float64("size", 1, "size of the input, relative to a personal puzzle input")
+	seed := fs.Int64("seed", 1, "seed of the random generator, the same seed results in the same input")
+	out := fs.String("out", "", "file to write the input to (default stdout)")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 2 {
+		return fmt.Errorf("%w: expected YEAR and DAY", errUsage)
+	}
+	year, day, err := parseYearDay(positional[0], positional[1])
+	if err != nil {
+		return err
+	}
+	input, err := gen.Generate(year, day, *size, *seed)
+	if err != nil {
+		return err
+	}
+	if !strings.HasSuffix(input, "\n") {
+		input += "\n"
+	}
+	if *out == "" {
+		_, err = fmt.Fprint(s.stdout, input)
+		return err
+	}
+	return os.WriteFile(*out, []byte(input), 0o644)
+}
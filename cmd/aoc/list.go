@@ -0,0 +1,29 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+)
+
+// listCmd prints all (or the selected) registered puzzles with the names of their other implementations.
+func listCmd(_ context.Context, args []string, s streams) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	puzzles, err := selectPuzzles(positional)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(s.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "YEAR\tDAY\tPARTS\tTITLE\tIMPLEMENTATIONS")
+	for _, p := range puzzles {
+		fmt.Fprintf(w, "%d\t%d\t%d\t%s\t%s\n", p.Year, p.Day, p.Parts, p.Title, strings.Join(p.ImplementationNames(), ", "))
+	}
+	return w.Flush()
+}
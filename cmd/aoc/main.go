@@ -0,0 +1,165 @@
+// Command aoc runs the Advent of Code solvers registered in this repository.
+//
+// Usage:
+//
+//	aoc run YEAR DAY [--part N] [--input PATH | --example] [--inputs DIR] [--cache DIR] [--impl NAME] [--trace LEVEL] [--trace-format F] [--trace-out PATH] [--profile cpu|mem|trace] [--profile-dir DIR] [--format text|json|ndjson] [--timeout D]
+//	aoc list [YEARS [DAYS]]
+//	aoc verify [YEARS [DAYS]] [--answers PATH] [--inputs DIR] [--cache DIR] [--examples=false] [--record] [--workers N] [--timeout D]
+//	aoc bench [YEARS [DAYS]] [--part N] [--example] [--inputs DIR] [--cache DIR] [--benchtime D] [--save PATH] [--baseline PATH] [--threshold F]
+//	aoc fetch YEAR DAY [--config PATH] [--cache DIR] [--force]
+//	aoc submit YEAR DAY PART [--answer A | --input PATH] [--inputs DIR] [--config PATH] [--cache DIR] [--answers PATH]
+//	aoc new YEAR DAY [--html PATH] [--title T] [--root DIR] [--force]
+//	aoc examples YEAR DAY [--from PATH] [--root DIR]
+//	aoc gen YEAR DAY [--size F] [--seed N] [--out PATH]
+//	aoc serve [--addr HOST:PORT] [--max-input BYTES] [--timeout D]
+//	aoc site [YEARS [DAYS]] [--out DIR] [--root DIR] [--answers PATH] [--bench PATH] [--viz DIR]
+//	aoc viz YEAR DAY [--part N] [--example | --input PATH] [--format gif|png] [--out DIR] [--scale N] [--every N]
+//
+// Without --input or --example the puzzle input is read from stdin if it is piped in,
+// otherwise from DIR/YEAR/DAY/input.txt (or challenge.in), or from the inputs downloaded by fetch (--cache DIR).
+// YEARS and DAYS select puzzles by a single number (2022) or an inclusive range (2015-2022).
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+)
+
+// streams are the standard streams available to a command.
+type streams struct {
+	stdin io.Reader
+	// stdinPiped is true if something was piped or redirected into stdin.
+	stdinPiped bool
+	stdout     io.Writer
+	stderr     io.Writer
+}
+
+// command is a single aoc subcommand.
+type command struct {
+	name  string
+	usage string
+	run   func(ctx context.Context, args []string, s streams) error
+}
+
+var commands = []command{
+	{name: "run", usage: "run YEAR DAY [--part N] [--input PATH | --example] [--inputs DIR] [--cache DIR] [--impl NAME] [--trace LEVEL] [--trace-format F] [--trace-out PATH] [--profile cpu|mem|trace] [--profile-dir DIR] [--format text|json|ndjson] [--timeout D]", run: runCmd},
+	{name: "list", usage: "list [YEARS [DAYS]]", run: listCmd},
+	{name: "verify", usage: "verify [YEARS [DAYS]] [--answers PATH] [--inputs DIR] [--cache DIR] [--examples=false] [--record] [--workers N] [--timeout D]", run: verifyCmd},
+	{name: "bench", usage: "bench [YEARS [DAYS]] [--part N] [--example] [--inputs DIR] [--cache DIR] [--benchtime D] [--save PATH] [--baseline PATH] [--threshold F]", run: benchCmd},
+	{name: "fetch", usage: "fetch YEAR DAY [--config PATH] [--cache DIR] [--force]", run: fetchCmd},
+	{name: "submit", usage: "submit YEAR DAY PART [--answer A | --input PATH] [--inputs DIR] [--config PATH] [--cache DIR] [--answers PATH]", run: submitCmd},
+	{name: "new", usage: "new YEAR DAY [--html PATH] [--title T] [--root DIR] [--force]", run: newCmd},
+	{name: "examples", usage: "examples YEAR DAY [--from PATH] [--root DIR]", run: examplesCmd},
+	{name: "gen", usage: "gen YEAR DAY [--size F] [--seed N] [--out PATH]", run: genCmd},
+	{name: "serve", usage: "serve [--addr HOST:PORT] [--max-input BYTES] [--timeout D]", run: serveCmd},
+	{name: "site", usage: "site [YEARS [DAYS]] [--out DIR] [--root DIR] [--answers PATH] [--bench PATH] [--viz DIR]", run: siteCmd},
+	{name: "viz", usage: "viz YEAR DAY [--part N] [--example | --input PATH] [--format gif|png] [--out DIR] [--scale N] [--every N]", run: vizCmd},
+}
+
+// errUsage signals that the command line arguments were invalid.
+var errUsage = errors.New("invalid usage")
+
+// usage prints the available subcommands.
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage:")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  aoc %s\n", c.usage)
+	}
+}
+
+// parseArgs parses flags that can be interspersed with positional arguments.
+// Returns the positional arguments in order.
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	positional := make([]string, 0)
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// parseYearDay converts the YEAR and DAY positional arguments to integers.
+func parseYearDay(yearArg, dayArg string) (year, day int, err error) {
+	if year, err = strconv.Atoi(yearArg); err != nil {
+		return 0, 0, fmt.Errorf("%w: year %q is not a number", errUsage, yearArg)
+	}
+	if day, err = strconv.Atoi(dayArg); err != nil {
+		return 0, 0, fmt.Errorf("%w: day %q is not a number", errUsage, dayArg)
+	}
+	return year, day, nil
+}
+
+// parseRange converts a positional argument with a single number (e.g. 2022) or an inclusive range (e.g. 2015-2022)
+// into the first and last number of the range.
+func parseRange(arg, name string) (from, to int, err error) {
+	fromArg, toArg, isRange := strings.Cut(arg, "-")
+	if from, err = strconv.Atoi(fromArg); err != nil {
+		return 0, 0, fmt.Errorf("%w: %s %q is not a number or range", errUsage, name, arg)
+	}
+	if !isRange {
+		return from, from, nil
+	}
+	if to, err = strconv.Atoi(toArg); err != nil || to < from {
+		return 0, 0, fmt.Errorf("%w: %s %q is not a number or range", errUsage, name, arg)
+	}
+	return from, to, nil
+}
+
+// execute runs the subcommand named by the first argument. Cancelling ctx stops long running commands.
+func execute(ctx context.Context, args []string, s streams, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return 2
+	}
+	for _, c := range commands {
+		if c.name != args[0] {
+			continue
+		}
+		err := c.run(ctx, args[1:], s)
+		switch {
+		case err == nil:
+			return 0
+		case errors.Is(err, flag.ErrHelp):
+			return 0
+		case errors.Is(err, errUsage):
+			fmt.Fprintf(stderr, "aoc %s: %v\nUsage: aoc %s\n", c.name, err, c.usage)
+			return 2
+		default:
+			fmt.Fprintf(stderr, "aoc %s: %v\n", c.name, err)
+			return 1
+		}
+	}
+	fmt.Fprintf(stderr, "aoc: unknown command %q\n", args[0])
+	usage(stderr)
+	return 2
+}
+
+// isPiped returns true if f is not an interactive terminal (or a device like /dev/null).
+func isPiped(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice == 0
+}
+
+func main() {
+	s := streams{stdin: os.Stdin, stdinPiped: isPiped(os.Stdin), stdout: os.Stdout, stderr: os.Stderr}
+	// An interrupt cancels the running solvers, a second one kills the program
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+	os.Exit(execute(ctx, os.Args[1:], s, os.Stderr))
+}
@@ -0,0 +1,625 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rubinda/aoc"
+	"github.com/rubinda/aoc/client"
+	"github.com/rubinda/aoc/client/clienttest"
+)
+
+// execTest runs the aoc command with given stdin (piped if not nil) and returns its outputs.
+func execTest(t *testing.T, stdin *string, args ...string) (code int, stdout, stderr string) {
+	t.Helper()
+	var out, errOut bytes.Buffer
+	s := streams{stdin: strings.NewReader(""), stdout: &out, stderr: &errOut}
+	if stdin != nil {
+		s.stdin = strings.NewReader(*stdin)
+		s.stdinPiped = true
+	}
+	code = execute(context.Background(), args, s, &errOut)
+	return code, out.String(), errOut.String()
+}
+
+func TestRunPart(t *testing.T) {
+	code, stdout, stderr := execTest(t, nil, "run", "2022", "5", "--part", "1", "--example")
+	if code != 0 {
+		t.Fatalf("Exit code %d: %s", code, stderr)
+	}
+	if expected := "CMZ\n"; stdout != expected {
+		t.Errorf("Wrong result! Expected: %q, actual: %q", expected, stdout)
+	}
+}
+
+func TestRunAllParts(t *testing.T) {
+	code, stdout, stderr := execTest(t, nil, "run", "--example", "2022", "1")
+	if code != 0 {
+		t.Fatalf("Exit code %d: %s", code, stderr)
+	}
+	if expected := "Part 1: 24000\nPart 2: 45000\n"; stdout != expected {
+		t.Errorf("Wrong result! Expected: %q, actual: %q", expected, stdout)
+	}
+}
+
+func TestRunInputSources(t *testing.T) {
+	// Day 6 example from part 2 of the description, with a trailing newline like downloaded inputs
+	input := "bvwbjplbgvbhsrlpgdmjqwftvncz\n"
+	root := t.TempDir()
+	path := filepath.Join(root, "2022", "6", "input.txt")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(input), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		stdin *string
+		args  []string
+	}{
+		"file":      {nil, []string{"--input", path}},
+		"stdin":     {&input, []string{"--input", "-"}},
+		"piped":     {&input, nil},
+		"directory": {nil, []string{"--inputs", root}},
+		"cache":     {nil, []string{"--inputs", t.TempDir(), "--cache", root}},
+	}
+	for name, tt := range tests {
+		args := append([]string{"run", "2022", "6", "--part", "1"}, tt.args...)
+		code, stdout, stderr := execTest(t, tt.stdin, args...)
+		if code != 0 {
+			t.Fatalf("%s: exit code %d: %s", name, code, stderr)
+		}
+		if expected := "5\n"; stdout != expected {
+			t.Errorf("%s: wrong result! Expected: %q, actual: %q", name, expected, stdout)
+		}
+	}
+}
+
+func TestRunImplementation(t *testing.T) {
+	code, stdout, stderr := execTest(t, nil, "run", "2022", "20", "--example", "--impl", "linked")
+	if code != 0 {
+		t.Fatalf("Exit code %d: %s", code, stderr)
+	}
+	if expected := "Part 1: 3\nPart 2: 1623178306\n"; stdout != expected {
+		t.Errorf("Wrong result! Expected: %q, actual: %q", expected, stdout)
+	}
+	if code, _, stderr := execTest(t, nil, "run", "2022", "20", "--example", "--impl", "naive"); code != 1 || !strings.Contains(stderr, "unknown implementation") {
+		t.Errorf("Expected an unknown implementation, got exit code %d: %s", code, stderr)
+	}
+}
+
+func TestRunTrace(t *testing.T) {
+	code, stdout, stderr := execTest(t, nil, "run", "2022", "17", "--example", "--part", "2", "--trace", "info")
+	if code != 0 {
+		t.Fatalf("Exit code %d: %s", code, stderr)
+	}
+	if !strings.HasPrefix(stderr, "2022/17 info period found ") || stdout != "1514285714288\n" {
+		t.Errorf("Wrong trace %q or answer %q", stderr, stdout)
+	}
+
+	path := filepath.Join(t.TempDir(), "trace.jsonl")
+	code, _, stderr = execTest(t, nil, "run", "2022", "6", "--example", "--trace", "debug", "--trace-format", "json", "--trace-out", path)
+	if code != 0 {
+		t.Fatalf("Exit code %d: %s", code, stderr)
+	}
+	trace, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"day":"2022/6","level":"info","event":"marker found","fields":{"marker":"zqfr","start":7,"end":11}}`
+	if lines := strings.Split(string(trace), "\n"); lines[0] != expected || strings.Contains(stderr, "marker") {
+		t.Errorf("Wrong result! Expected: %v, actual: %v", expected, lines[0])
+	}
+
+	// The tracer is disabled after the run
+	if code, _, stderr := execTest(t, nil, "run", "2022", "6", "--example"); code != 0 || strings.Contains(stderr, "marker") {
+		t.Errorf("Expected no trace, got exit code %d: %s", code, stderr)
+	}
+	if code, _, _ := execTest(t, nil, "run", "2022", "6", "--example", "--trace", "verbose"); code != 2 {
+		t.Errorf("Wrong exit code for an unknown level! Expected: %v, actual: %v", 2, code)
+	}
+}
+
+func TestRunProfile(t *testing.T) {
+	dir := t.TempDir()
+	for _, profile := range []string{"cpu", "mem", "trace"} {
+		code, _, stderr := execTest(t, nil, "run", "2022", "14", "--example", "--part", "2", "--profile", profile, "--profile-dir", dir)
+		if code != 0 {
+			t.Fatalf("Exit code %d: %s", code, stderr)
+		}
+		path := filepath.Join(dir, "2022-14-2."+profile+".pprof")
+		if profile == "trace" {
+			path = filepath.Join(dir, "2022-14-2.trace.out")
+		}
+		if info, err := os.Stat(path); err != nil || info.Size() == 0 {
+			t.Errorf("Missing %s profile: %v", profile, err)
+		}
+		if !strings.Contains(stderr, "2022/14 part 2: ") || !strings.Contains(stderr, " allocs, ") {
+			t.Errorf("Missing measurement in %q", stderr)
+		}
+	}
+	if code, _, _ := execTest(t, nil, "run", "2022", "14", "--example", "--profile", "block"); code != 2 {
+		t.Errorf("Wrong exit code for an unknown profile! Expected: %v, actual: %v", 2, code)
+	}
+}
+
+func TestRunFormat(t *testing.T) {
+	code, stdout, stderr := execTest(t, nil, "run", "2022", "10", "--example", "--format", "json")
+	if code != 0 {
+		t.Fatalf("Exit code %d: %s", code, stderr)
+	}
+	var results []aoc.Result
+	if err := json.Unmarshal([]byte(stdout), &results); err != nil {
+		t.Fatalf("Invalid JSON %q: %v", stdout, err)
+	}
+	if len(results) != 2 || results[0].Answer != 13140 || results[1].Kind() != aoc.KindArt {
+		t.Errorf("Wrong results! Actual: %v", results)
+	}
+
+	code, stdout, stderr = execTest(t, nil, "run", "2022", "1", "--example", "--format", "ndjson")
+	if code != 0 {
+		t.Fatalf("Exit code %d: %s", code, stderr)
+	}
+	lines := strings.Split(strings.TrimSuffix(stdout, "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Wrong number of lines! Expected: %v, actual: %v", 2, len(lines))
+	}
+	var result aoc.Result
+	if err := json.Unmarshal([]byte(lines[1]), &result); err != nil {
+		t.Fatal(err)
+	}
+	puzzle, _ := aoc.Lookup(2022, 1)
+	expected := aoc.Result{Year: 2022, Day: 1, Part: 2, Answer: 45000, Duration: result.Duration, Input: aoc.InputHash(puzzle.ExampleInput(2))}
+	if result != expected {
+		t.Errorf("Wrong result! Expected: %v, actual: %v", expected, result)
+	}
+
+	if code, _, _ := execTest(t, nil, "run", "2022", "1", "--example", "--format", "xml"); code != 2 {
+		t.Errorf("Wrong exit code for an unknown format! Expected: %v, actual: %v", 2, code)
+	}
+}
+
+func TestRunInputHash(t *testing.T) {
+	puzzle, _ := aoc.Lookup(2022, 1)
+	expected := aoc.InputHash(puzzle.Example)
+	// Inputs are hashed like in answers.json, without carriage returns and trailing newlines
+	crlf := strings.ReplaceAll(puzzle.Example, "\n", "\r\n") + "\r\n"
+	path := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(path, []byte(crlf), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	piped := puzzle.Example + "\n"
+	tests := []struct {
+		name  string
+		stdin *string
+		args  []string
+	}{
+		{"trailing newline", &piped, nil},
+		{"CRLF", nil, []string{"--input", path}},
+	}
+	for _, test := range tests {
+		args := append([]string{"run", "2022", "1", "--part", "1", "--format", "ndjson"}, test.args...)
+		code, stdout, stderr := execTest(t, test.stdin, args...)
+		if code != 0 {
+			t.Fatalf("%s: exit code %d: %s", test.name, code, stderr)
+		}
+		var result aoc.Result
+		if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+			t.Fatal(err)
+		}
+		if result.Input != expected || result.Answer != 24000 {
+			t.Errorf("%s: wrong result! Expected: %v, actual: %v", test.name, expected, result.Input)
+		}
+	}
+}
+
+func TestRunTimeout(t *testing.T) {
+	code, _, stderr := execTest(t, nil, "run", "2022", "24", "--example", "--timeout", "1ns")
+	if code != 1 || !strings.Contains(stderr, "2022/24 part 1: no answer within 1ns") {
+		t.Errorf("Exit code %d: %s", code, stderr)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	tests := []struct {
+		args []string
+		code int
+	}{
+		{[]string{}, 2},
+		{[]string{"nope"}, 2},
+		{[]string{"run", "2022"}, 2},
+		{[]string{"run", "2022", "x"}, 2},
+		{[]string{"run", "2022", "1", "--example", "--input", "x"}, 2},
+		{[]string{"run", "2022", "16"}, 1},
+		{[]string{"run", "2022", "25", "--part", "2", "--example"}, 1},
+		{[]string{"run", "2022", "1", "--inputs", "does-not-exist"}, 1},
+	}
+	for _, tt := range tests {
+		if code, _, _ := execTest(t, nil, tt.args...); code != tt.code {
+			t.Errorf("%v: wrong exit code! Expected: %d, actual: %d", tt.args, tt.code, code)
+		}
+	}
+}
+
+func TestRunParseError(t *testing.T) {
+	input := "1000\n2000\n\nlots\n"
+	code, stdout, stderr := execTest(t, &input, "run", "2022", "1", "--part", "1")
+	if code != 1 || stdout != "" {
+		t.Fatalf("Expected exit code 1 without output, got %d: %q", code, stdout)
+	}
+	if expected := `aoc run: 2022/1 part 1: invalid input on line 4 "lots"`; !strings.HasPrefix(stderr, expected) {
+		t.Errorf("Wrong error! Expected prefix: %q, actual: %q", expected, stderr)
+	}
+}
+
+func TestSolveInvalidInput(t *testing.T) {
+	inputs := []string{"", "x", "1\n\n#", "Monkey 0: 1 -> 2,3 =x"}
+	for _, p := range aoc.Puzzles() {
+		for part := 1; part <= p.Parts; part++ {
+			for _, input := range inputs {
+				if _, err := p.Solve(context.Background(), part, strings.NewReader(input)); errors.Is(err, aoc.ErrPanic) {
+					t.Errorf("%v part %d panicked on %q: %v", p.Key(), part, input, err)
+				}
+			}
+		}
+	}
+}
+
+func TestList(t *testing.T) {
+	code, stdout, stderr := execTest(t, nil, "list")
+	if code != 0 {
+		t.Fatalf("Exit code %d: %s", code, stderr)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	// Header and the 25 solved days of 2022
+	if len(lines) != 26 {
+		t.Errorf("Wrong number of lines! Expected: %d, actual: %d", 26, len(lines))
+	}
+
+	tests := []struct {
+		args  []string
+		code  int
+		lines int
+	}{
+		{[]string{"2022"}, 0, 26},
+		{[]string{"2015-2022", "24-25"}, 0, 3},
+		{[]string{"2022", "7"}, 0, 2},
+		{[]string{"2022", "5-3"}, 2, 0},
+		{[]string{"2023"}, 1, 0},
+		{[]string{"2022", "26-30"}, 1, 0},
+	}
+	for _, tt := range tests {
+		code, stdout, _ := execTest(t, nil, append([]string{"list"}, tt.args...)...)
+		if code != tt.code {
+			t.Errorf("%v: wrong exit code! Expected: %d, actual: %d", tt.args, tt.code, code)
+		}
+		if lines := strings.Count(stdout, "\n"); code == 0 && lines != tt.lines {
+			t.Errorf("%v: wrong number of lines! Expected: %d, actual: %d", tt.args, tt.lines, lines)
+		}
+	}
+}
+
+func TestVerifyKnownAnswers(t *testing.T) {
+	code, stdout, stderr := execTest(t, nil, "verify", "--answers", "../../answers.json", "--inputs", t.TempDir())
+	if code != 0 {
+		t.Fatalf("Exit code %d: %s%s", code, stdout, stderr)
+	}
+	if !strings.Contains(stdout, " 0 failed, 0 errors, 0 timeouts, 0 missing") {
+		t.Errorf("Every example should have a known answer:\n%s", stdout)
+	}
+}
+
+func TestVerifyTimeout(t *testing.T) {
+	args := []string{"verify", "2022", "14-15", "--answers", "../../answers.json", "--inputs", t.TempDir(), "--workers", "2", "--timeout", "1ns"}
+	code, stdout, _ := execTest(t, nil, args...)
+	if code != 1 || !strings.Contains(stdout, "0 failed, 0 errors, 4 timeouts") {
+		t.Errorf("Exit code %d:\n%s", code, stdout)
+	}
+	if code, _, _ := execTest(t, nil, "verify", "--workers", "0"); code != 2 {
+		t.Errorf("Wrong exit code for no workers! Expected: %v, actual: %v", 2, code)
+	}
+}
+
+func TestVerifyRecord(t *testing.T) {
+	answers := filepath.Join(t.TempDir(), "answers.json")
+	args := []string{"verify", "2022", "1", "--answers", answers, "--inputs", t.TempDir()}
+	if code, stdout, _ := execTest(t, nil, append(args, "--record")...); code != 0 || !strings.Contains(stdout, "2 missing") {
+		t.Fatalf("Exit code %d:\n%s", code, stdout)
+	}
+	if code, stdout, _ := execTest(t, nil, args...); code != 0 || !strings.Contains(stdout, "2 passed") {
+		t.Fatalf("Exit code %d:\n%s", code, stdout)
+	}
+
+	// A wrong known answer fails verification
+	if err := os.WriteFile(answers, []byte(strings.Replace(readFile(t, answers), "24000", "1", 1)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if code, stdout, _ := execTest(t, nil, args...); code != 1 || !strings.Contains(stdout, "got 24000, want 1") {
+		t.Errorf("Exit code %d:\n%s", code, stdout)
+	}
+}
+
+// readFile returns the file contents or fails the test.
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestBenchBaseline(t *testing.T) {
+	baseline := filepath.Join(t.TempDir(), "baseline.json")
+	args := []string{"bench", "2022", "4", "--example", "--benchtime", "1ms"}
+	if code, stdout, stderr := execTest(t, nil, append(args, "--save", baseline)...); code != 0 {
+		t.Fatalf("Exit code %d:\n%s%s", code, stdout, stderr)
+	}
+	// A negative threshold reports every benchmark as a regression
+	code, stdout, _ := execTest(t, nil, append(args, "--baseline", baseline, "--threshold", "-1")...)
+	if code != 1 || strings.Count(stdout, "SLOWER") != 2 {
+		t.Errorf("Exit code %d:\n%s", code, stdout)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	server := clienttest.NewServer("secret", map[aoc.Key]string{{Year: 2022, Day: 1}: "1000\n2000\n\n3000"})
+	defer server.Close()
+	t.Setenv(client.SessionEnv, "")
+	dir := t.TempDir()
+	config := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(config, []byte(`{"session": "secret", "base_url": "`+server.URL+`"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cache := filepath.Join(dir, "cache")
+
+	code, stdout, stderr := execTest(t, nil, "fetch", "2022", "1", "--config", config, "--cache", cache)
+	if code != 0 {
+		t.Fatalf("Exit code %d: %s", code, stderr)
+	}
+	if expected := filepath.Join(cache, "2022", "1", "input.txt") + "\n"; stdout != expected {
+		t.Errorf("Wrong path! Expected: %q, actual: %q", expected, stdout)
+	}
+	// The cache has the same layout as the inputs directory
+	code, stdout, stderr = execTest(t, nil, "run", "2022", "1", "--part", "1", "--inputs", cache)
+	if code != 0 || stdout != "3000\n" {
+		t.Errorf("Running the fetched input failed with exit code %d: %q %s", code, stdout, stderr)
+	}
+	// Commands fall back to the cache when the inputs directory doesn't have the input
+	code, stdout, stderr = execTest(t, nil, "verify", "2022", "1", "--answers", "../../answers.json", "--inputs", t.TempDir(), "--cache", cache, "--examples=false")
+	if !strings.Contains(stdout, "input.txt") {
+		t.Errorf("Verify didn't find the fetched input, got exit code %d: %q %s", code, stdout, stderr)
+	}
+
+	if code, _, stderr := execTest(t, nil, "fetch", "2022", "1", "--config", filepath.Join(dir, "missing.json")); code != 1 || !strings.Contains(stderr, "no session cookie") {
+		t.Errorf("Expected a missing session error, got exit code %d: %s", code, stderr)
+	}
+}
+
+func TestSubmit(t *testing.T) {
+	server := clienttest.NewServer("secret", nil)
+	defer server.Close()
+	server.SetAnswer(2022, 1, 1, "3000")
+	t.Setenv(client.SessionEnv, "")
+	dir := t.TempDir()
+	config := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(config, []byte(`{"session": "secret", "base_url": "`+server.URL+`"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	inputs := filepath.Join(dir, "inputs")
+	if err := os.MkdirAll(filepath.Join(inputs, "2022", "1"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(inputs, "2022", "1", "input.txt"), []byte("1000\n2000\n\n3000\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cache := filepath.Join(dir, "cache")
+	answers := filepath.Join(dir, "answers.json")
+	args := []string{"submit", "2022", "1", "1", "--config", config, "--cache", cache, "--inputs", inputs, "--answers", answers}
+
+	// A previous run guessed too high and has to wait
+	writeGuesses := func(until time.Time) {
+		t.Helper()
+		if err := os.MkdirAll(cache, 0o700); err != nil {
+			t.Fatal(err)
+		}
+		guess := fmt.Sprintf(`{"year":2022,"day":1,"part":1,"answer":"5000","verdict":"too high","until":%q}`, until.Format(time.RFC3339))
+		if err := os.WriteFile(filepath.Join(cache, "guesses.jsonl"), []byte(guess+"\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeGuesses(time.Now().Add(time.Minute))
+	if code, _, stderr := execTest(t, nil, args...); code != 1 || !strings.Contains(stderr, "too soon") {
+		t.Errorf("Expected the cooldown to hold, got exit code %d: %s", code, stderr)
+	}
+	writeGuesses(time.Now().Add(-time.Minute))
+	if code, _, stderr := execTest(t, nil, append(args, "--answer", "6000")...); code != 1 || !strings.Contains(stderr, "known to be wrong") {
+		t.Errorf("Expected a known wrong answer, got exit code %d: %s", code, stderr)
+	}
+	if len(server.Requests()) != 0 {
+		t.Errorf("Expected no requests, got %d", len(server.Requests()))
+	}
+
+	code, stdout, stderr := execTest(t, nil, args...)
+	if code != 0 || stdout != "2022/1 part 1: 3000 is correct\n" {
+		t.Fatalf("Exit code %d: %s%s", code, stdout, stderr)
+	}
+	known, err := aoc.LoadAnswers(answers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if answer, ok := known.Find(2022, 1, 1, aoc.InputHash("1000\n2000\n\n3000")); !ok || answer != "3000" {
+		t.Errorf("Correct answer wasn't recorded: %+v", known.Answers)
+	}
+}
+
+func TestNew(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "cmd", "aoc"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "cmd", "aoc", "days.go"), []byte(readFile(t, "days.go")), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// The saved page is of 2022, the same day of 2023 is piped in
+	page2022 := filepath.Join("..", "..", "scaffold", "testdata", "day2.html")
+	page := strings.ReplaceAll(readFile(t, page2022), "2022", "2023")
+	args := []string{"new", "2023", "2", "--html", "-", "--root", root}
+	code, stdout, stderr := execTest(t, &page, args...)
+	if code != 0 || !strings.Contains(stdout, "Updated "+filepath.Join(root, "2023", "year.go")) {
+		t.Fatalf("Exit code %d: %s%s", code, stdout, stderr)
+	}
+	if readme := readFile(t, filepath.Join(root, "2023", "2", "readme.md")); readme != "### --- [Day 2: Rock Paper Scissors](https://adventofcode.com/2023/day/2) ---\n" {
+		t.Errorf("Wrong readme: %q", readme)
+	}
+	if days := readFile(t, filepath.Join(root, "cmd", "aoc", "days.go")); !strings.Contains(days, `_ "github.com/rubinda/aoc/2023"`) {
+		t.Errorf("Year not registered:\n%s", days)
+	}
+
+	if code, _, stderr := execTest(t, &page, args...); code != 1 || !strings.Contains(stderr, "already exists") {
+		t.Errorf("Expected existing files to be kept, got exit code %d: %s", code, stderr)
+	}
+	if code, _, stderr := execTest(t, nil, "new", "2022", "3", "--html", page2022); code != 1 || !strings.Contains(stderr, "not 3") {
+		t.Errorf("Expected a day mismatch, got exit code %d: %s", code, stderr)
+	}
+	if code, _, stderr := execTest(t, nil, "new", "2023", "1", "--html", page2022); code != 1 || !strings.Contains(stderr, "year 2022, not 2023") {
+		t.Errorf("Expected a year mismatch, got exit code %d: %s", code, stderr)
+	}
+}
+
+func TestExamples(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "2022", "6")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "example.in"), []byte("mjqjpqmgbljsphdztnvjfqwrcgsmlb\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, aoc.ExamplesFile)
+	if err := aoc.SaveExamples(path, []aoc.Example{{Name: "example", File: "example.in", Answers: map[int]string{1: "7"}}}); err != nil {
+		t.Fatal(err)
+	}
+
+	readme := filepath.Join("..", "..", "scaffold", "testdata", "readme.md")
+	code, stdout, stderr := execTest(t, nil, "examples", "2022", "6", "--root", root, "--from", readme)
+	if code != 0 || !strings.Contains(stdout, "2 examples") || !strings.Contains(stdout, "(1 new)") {
+		t.Fatalf("Exit code %d: %s%s", code, stdout, stderr)
+	}
+	examples, err := aoc.LoadExamples(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The example from the file gets the second part's answer
+	if len(examples) != 2 || examples[0].Answers[2] != "19" || examples[1].Input != "bvwbjplbgvbhsrlpgdmjqwftvncz" {
+		t.Errorf("Wrong examples: %+v", examples)
+	}
+
+	if code, _, stderr := execTest(t, nil, "examples", "2022", "6", "--root", root); code != 1 || !strings.Contains(stderr, "readme.md") {
+		t.Errorf("Expected a missing readme, got exit code %d: %s", code, stderr)
+	}
+}
+
+func TestGen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "input.txt")
+	code, _, stderr := execTest(t, nil, "gen", "2022", "22", "--size", "0.1", "--seed", "7", "--out", path)
+	if code != 0 {
+		t.Fatalf("Exit code %d: %s", code, stderr)
+	}
+	input, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The same seed writes the same input to stdout
+	if _, stdout, _ := execTest(t, nil, "gen", "2022", "22", "--size", "0.1", "--seed", "7"); stdout != string(input) {
+		t.Errorf("Wrong result! Expected: %q, actual: %q", input, stdout)
+	}
+	if code, _, stderr := execTest(t, nil, "run", "2022", "22", "--input", path); code != 0 {
+		t.Errorf("Exit code %d: %s", code, stderr)
+	}
+
+	if code, _, _ := execTest(t, nil, "gen", "2022", "22", "--size", "0"); code != 1 {
+		t.Errorf("Wrong exit code for size 0! Expected: %v, actual: %v", 1, code)
+	}
+	if code, _, _ := execTest(t, nil, "gen", "2022"); code != 2 {
+		t.Errorf("Wrong exit code without a day! Expected: %v, actual: %v", 2, code)
+	}
+}
+
+func TestServe(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	logs, logWriter := io.Pipe()
+	s := streams{stdin: strings.NewReader(""), stdout: io.Discard, stderr: logWriter}
+	served := make(chan error, 1)
+	go func() {
+		served <- serveCmd(ctx, []string{"--addr", "127.0.0.1:0"}, s)
+	}()
+	line, err := bufio.NewReader(logs).ReadString('\n')
+	if err != nil {
+		t.Fatal(err)
+	}
+	url := strings.TrimSpace(line[strings.Index(line, "http://"):])
+
+	resp, err := http.Post(url+"/solve/2022/1/1", "text/plain", strings.NewReader("1000\n2000\n\n3000"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result aoc.Result
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	resp.Body.Close()
+	if err != nil || result.Answer != 3000 {
+		t.Errorf("Wrong result! Expected: %v, actual: %v (%v)", 3000, result.Answer, err)
+	}
+
+	// Interrupting shuts the server down
+	cancel()
+	if err := <-served; err != nil {
+		t.Errorf("Serve failed: %v", err)
+	}
+}
+
+func TestSite(t *testing.T) {
+	out := t.TempDir()
+	args := []string{"site", "2022", "1-2", "--out", out, "--root", "../..", "--answers", "../../answers.json", "--bench", filepath.Join(out, "missing.json")}
+	if code, stdout, stderr := execTest(t, nil, args...); code != 0 || !strings.Contains(stdout, "2 days in") {
+		t.Fatalf("Exit code %d:\n%s%s", code, stdout, stderr)
+	}
+	if index := readFile(t, filepath.Join(out, "index.html")); !strings.Contains(index, "Rock Paper Scissors") {
+		t.Errorf("Day 2 is missing from the index:\n%s", index)
+	}
+	if _, err := os.Stat(filepath.Join(out, "2022", "2", "index.html")); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestViz(t *testing.T) {
+	out := t.TempDir()
+	code, stdout, stderr := execTest(t, nil, "viz", "2022", "14", "--example", "--out", out, "--max-frames", "10")
+	if code != 0 || !strings.Contains(stdout, "2022/14 part 2: 11 frames") {
+		t.Fatalf("Exit code %d:\n%s%s", code, stdout, stderr)
+	}
+	for _, name := range []string{"part1.gif", "part2.gif"} {
+		if _, err := os.Stat(filepath.Join(out, name)); err != nil {
+			t.Error(err)
+		}
+	}
+	if code, _, stderr := execTest(t, nil, "viz", "2022", "1", "--example", "--out", out); code != 1 || !strings.Contains(stderr, "no visualization") {
+		t.Errorf("Exit code %d: %s", code, stderr)
+	}
+	if code, _, _ := execTest(t, nil, "viz", "2022", "14", "--format", "mp4"); code != 2 {
+		t.Errorf("Wrong exit code for an unknown format! Expected: %v, actual: %v", 2, code)
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/rubinda/aoc/scaffold"
+)
+
+// newCmd creates the package of a new day from templates and registers it with the runner.
+func newCmd(_ context.Context, args []string, s streams) error {
+	fs := flag.NewFlagSet("new", flag.ContinueOnError)
+	htmlPath := fs.String("html", "", "puzzle description page to take the title, example and answers from, - for stdin")
+	title := fs.String("title", "", "puzzle title (instead of the one in the description)")
+	root := fs.String("root", ".", "repository root")
+	force := fs.Bool("force", false, "overwrite existing files")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 2 {
+		return fmt.Errorf("%w: expected YEAR and DAY", errUsage)
+	}
+	year, day, err := parseYearDay(positional[0], positional[1])
+	if err != nil {
+		return err
+	}
+
+	d := scaffold.Day{}
+	if *htmlPath != "" {
+		var page []byte
+		if *htmlPath == "-" {
+			page, err = io.ReadAll(s.stdin)
+		} else {
+			page, err = os.ReadFile(*htmlPath)
+		}
+		if err != nil {
+			return err
+		}
+		if d, err = scaffold.ParseDescription(string(page)); err != nil {
+			return fmt.Errorf("%s: %w", *htmlPath, err)
+		}
+		if d.Year != 0 && d.Year != year {
+			return fmt.Errorf("%s: description is of year %d, not %d", *htmlPath, d.Year, year)
+		}
+		if d.Day != day {
+			return fmt.Errorf("%s: description is of day %d, not %d", *htmlPath, d.Day, day)
+		}
+	}
+	d.Year, d.Day = year, day
+	if *title != "" {
+		d.Title = *title
+	}
+	if d.Title == "" {
+		return fmt.Errorf("%w: --title or --html is required", errUsage)
+	}
+
+	paths, err := scaffold.Create(*root, d, *force)
+	if err != nil {
+		return err
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		fmt.Fprintln(s.stdout, path)
+	}
+	changed, err := scaffold.Register(*root, year, day)
+	if err != nil {
+		return err
+	}
+	for _, path := range changed {
+		fmt.Fprintf(s.stdout, "Updated %s\n", path)
+	}
+	return nil
+}
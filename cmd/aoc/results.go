@@ -0,0 +1,52 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/rubinda/aoc"
+)
+
+// resultFormats are the formats results can be written in.
+var resultFormats = []string{"text", "json", "ndjson"}
+
+// resultWriter writes results as text (one "Part N: answer" per result), a JSON array or JSON lines (ndjson).
+// JSON results are collected and written by Flush, the other formats are written right away.
+type resultWriter struct {
+	w       io.Writer
+	format  string
+	results []aoc.Result
+}
+
+// newResultWriter returns a writer of results in given format.
+func newResultWriter(w io.Writer, format string) (*resultWriter, error) {
+	switch format {
+	case "text", "json", "ndjson":
+		return &resultWriter{w: w, format: format, results: make([]aoc.Result, 0)}, nil
+	}
+	return nil, fmt.Errorf("%w: unknown format %q, expected one of %v", errUsage, format, resultFormats)
+}
+
+// Write writes (or collects) a result.
+func (rw *resultWriter) Write(r aoc.Result) error {
+	switch rw.format {
+	case "json":
+		rw.results = append(rw.results, r)
+		return nil
+	case "ndjson":
+		return json.NewEncoder(rw.w).Encode(r)
+	}
+	_, err := fmt.Fprintln(rw.w, r)
+	return err
+}
+
+// Flush writes the collected JSON results.
+func (rw *resultWriter) Flush() error {
+	if rw.format != "json" {
+		return nil
+	}
+	enc := json.NewEncoder(rw.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(rw.results)
+}
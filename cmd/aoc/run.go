@@ -0,0 +1,210 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/rubinda/aoc"
+	"github.com/rubinda/aoc/bench"
+	"github.com/rubinda/aoc/internal/tracing"
+	"golang.org/x/exp/slices"
+)
+
+// runCmd solves one or all parts of a single day.
+func runCmd(ctx context.Context, args []string, s streams) error {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	part := fs.Int("part", 0, "challenge part to solve (all parts if omitted)")
+	inputPath := fs.String("input", "", "puzzle input file, - for stdin")
+	useExample := fs.Bool("example", false, "solve the example from the puzzle description")
+	inputsDir := fs.String("inputs", ".", "directory with puzzle inputs in YEAR/DAY/ subdirectories")
+	cacheDir := fs.String("cache", defaultCacheDir, "directory with inputs downloaded by fetch, searched after the inputs directory")
+	impl := fs.String("impl", "", "solve with another implementation of the day (see list)")
+	traceLevel := fs.String("trace", "off", "trace level of the solver's events: off, info or debug")
+	traceFormat := fs.String("trace-format", "text", "format of the trace: text or json (lines)")
+	traceOut := fs.String("trace-out", "", "file to write the trace to (default stderr)")
+	profile := fs.String("profile", "", "profile each part: cpu, mem or trace")
+	profileDir := fs.String("profile-dir", ".", "directory for the profiles, named YEAR-DAY-PART.KIND")
+	format := fs.String("format", "text", "format of the results: text, json or ndjson")
+	timeout := fs.Duration("timeout", 0, "time limit for solving, 0 means no limit")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 2 {
+		return fmt.Errorf("%w: expected YEAR and DAY", errUsage)
+	}
+	if *inputPath != "" && *useExample {
+		return fmt.Errorf("%w: --input and --example are mutually exclusive", errUsage)
+	}
+	if *profile != "" && !slices.Contains(bench.Profiles, *profile) {
+		return fmt.Errorf("%w: unknown profile %q, expected one of %v", errUsage, *profile, bench.Profiles)
+	}
+	year, day, err := parseYearDay(positional[0], positional[1])
+	if err != nil {
+		return err
+	}
+	results, err := newResultWriter(s.stdout, *format)
+	if err != nil {
+		return err
+	}
+	puzzle, err := aoc.Lookup(year, day)
+	if err != nil {
+		return err
+	}
+	if *impl != "" {
+		if puzzle, err = puzzle.Implementation(*impl); err != nil {
+			return err
+		}
+	}
+	stopTrace, err := startTrace(puzzle.Key(), *traceLevel, *traceFormat, *traceOut, s)
+	if err != nil {
+		return err
+	}
+	defer stopTrace()
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+
+	// Some parts have their own example, other inputs are the same for all parts
+	var inputFor func(part int) string
+	if *useExample {
+		inputFor = puzzle.ExampleInput
+	} else {
+		input, err := loadInput(puzzle, *inputPath, *inputsDir, *cacheDir, s)
+		if err != nil {
+			return err
+		}
+		inputFor = func(int) string {
+			return input
+		}
+	}
+
+	// Wall time and allocations of every part (and where its profile went) are reported on stderr
+	solve := func(p int) (aoc.Result, error) {
+		result := aoc.Result{Year: puzzle.Year, Day: puzzle.Day, Part: p, Input: aoc.InputHash(inputFor(p))}
+		var stopProfile func() error
+		path := bench.ProfilePath(*profileDir, *profile, puzzle.Key(), p)
+		if *profile != "" {
+			if stopProfile, err = bench.StartProfile(*profile, path); err != nil {
+				return result, err
+			}
+		}
+		answer, m, err := bench.Solve(ctx, puzzle, p, strings.NewReader(inputFor(p)))
+		if stopProfile != nil {
+			if err := stopProfile(); err != nil {
+				return result, err
+			}
+			fmt.Fprintf(s.stderr, "%v part %d: %s profile written to %s\n", puzzle.Key(), p, *profile, path)
+		}
+		if errors.Is(err, context.DeadlineExceeded) {
+			return result, fmt.Errorf("%v part %d: no answer within %v: %w", puzzle.Key(), p, *timeout, err)
+		}
+		if err != nil {
+			return result, fmt.Errorf("%v part %d: %w", puzzle.Key(), p, err)
+		}
+		fmt.Fprintf(s.stderr, "%v part %d: %v\n", puzzle.Key(), p, m)
+		result.Answer, result.Duration = answer, m.Wall
+		return result, nil
+	}
+
+	// A single requested part prints only the answer as text so it can be used in scripts
+	if *part != 0 {
+		result, err := solve(*part)
+		if err != nil {
+			return err
+		}
+		if *format == "text" {
+			fmt.Fprintln(s.stdout, aoc.FormatAnswer(result.Answer))
+			return nil
+		}
+		if err := results.Write(result); err != nil {
+			return err
+		}
+		return results.Flush()
+	}
+	for p := 1; p <= puzzle.Parts; p++ {
+		result, err := solve(p)
+		if err != nil {
+			return err
+		}
+		if err := results.Write(result); err != nil {
+			return err
+		}
+	}
+	return results.Flush()
+}
+
+// startTrace enables the tracer of the day with given level, writing the events in given format to the out file
+// (or stderr). Returns a function that disables the tracer again.
+func startTrace(day aoc.Key, levelName, format, out string, s streams) (stop func(), err error) {
+	level, err := tracing.ParseLevel(levelName)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if level == tracing.Off {
+		return func() {}, nil
+	}
+	var w io.Writer = s.stderr
+	var f *os.File
+	if out != "" {
+		if f, err = os.Create(out); err != nil {
+			return nil, err
+		}
+		w = f
+	}
+	sink, err := tracing.NewSink(format, w)
+	if err != nil {
+		if f != nil {
+			f.Close()
+		}
+		return nil, fmt.Errorf("%w: %v", errUsage, err)
+	}
+	tracer := tracing.For(day.Year, day.Day)
+	tracer.Enable(level, sink)
+	return func() {
+		tracer.Disable()
+		if f != nil {
+			f.Close()
+		}
+	}, nil
+}
+
+// loadInput reads the puzzle input from (in order of preference) the --input file, piped stdin
+// or the input file found in the inputs or cache directory. The input is normalized like every other input
+// (see aoc.ReadInput), so its hash matches the one in answers.json.
+func loadInput(puzzle aoc.Puzzle, path, inputsDir, cacheDir string, s streams) (string, error) {
+	if path == "-" || (path == "" && s.stdinPiped) {
+		return aoc.ReadInput(s.stdin)
+	}
+	if path == "" {
+		var err error
+		if path, err = findInput(inputsDir, cacheDir, puzzle.Year, puzzle.Day); err != nil {
+			return "", fmt.Errorf("%w (use --input PATH, --example or fetch it first)", err)
+		}
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	return aoc.ReadInput(f)
+}
+
+// findInput returns the input file in the inputs directory, falling back to inputs downloaded by fetch into the
+// cache directory.
+func findInput(inputsDir, cacheDir string, year, day int) (string, error) {
+	path, err := aoc.FindInput(inputsDir, year, day)
+	if errors.Is(err, aoc.ErrNoInput) {
+		if cached, cacheErr := aoc.FindInput(cacheDir, year, day); cacheErr == nil {
+			return cached, nil
+		}
+	}
+	return path, err
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/rubinda/aoc/server"
+)
+
+// shutdownTimeout is how long requests still running when serve is interrupted get to finish.
+const shutdownTimeout = 5 * time.Second
+
+// serveCmd serves the registered solvers over HTTP until it is interrupted.
+func serveCmd(ctx context.Context, args []string, s streams) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := fs.String("addr", "localhost:8080", "address to listen on")
+	maxInput := fs.Int64("max-input", server.DefaultMaxInput, "most bytes of puzzle input a request can hold")
+	timeout := fs.Duration("timeout", server.DefaultTimeout, "time limit for solving a request, 0 means no limit")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return fmt.Errorf("%w: unexpected arguments %v", errUsage, positional)
+	}
+	if *maxInput < 1 {
+		return fmt.Errorf("%w: --max-input has to be at least 1, got %d", errUsage, *maxInput)
+	}
+
+	handler := server.New()
+	handler.MaxInput, handler.Timeout = *maxInput, *timeout
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+	srv := &http.Server{Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+	fmt.Fprintf(s.stderr, "Serving the solvers on http://%s\n", listener.Addr())
+	served := make(chan error, 1)
+	go func() {
+		served <- srv.Serve(listener)
+	}()
+	select {
+	case err := <-served:
+		return err
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return srv.Shutdown(shutdownCtx)
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rubinda/aoc"
+	"github.com/rubinda/aoc/bench"
+	"github.com/rubinda/aoc/site"
+)
+
+// siteCmd renders the static site of all (or selected) puzzles.
+func siteCmd(_ context.Context, args []string, s streams) error {
+	fs := flag.NewFlagSet("site", flag.ContinueOnError)
+	out := fs.String("out", "_site", "directory to write the site to")
+	root := fs.String("root", ".", "repository root")
+	answersPath := fs.String("answers", "answers.json", "file with known answers")
+	benchPath := fs.String("bench", "bench.json", "baseline saved by bench --save, skipped if missing")
+	vizDir := fs.String("viz", defaultVizDir, "directory with the animations written by viz")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	puzzles, err := selectPuzzles(positional)
+	if err != nil {
+		return err
+	}
+	known, err := aoc.LoadAnswers(*answersPath)
+	if err != nil {
+		return err
+	}
+	baseline, err := bench.Load(*benchPath)
+	if errors.Is(err, os.ErrNotExist) {
+		baseline, err = nil, nil
+	}
+	if err != nil {
+		return err
+	}
+
+	cfg := site.Config{Root: *root, Puzzles: puzzles, Answers: known, Baseline: baseline, VizDir: *vizDir}
+	if err := site.Build(*out, cfg); err != nil {
+		return err
+	}
+	fmt.Fprintf(s.stdout, "%d days in %s\n", len(puzzles), filepath.Join(*out, "index.html"))
+	return nil
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rubinda/aoc"
+	"github.com/rubinda/aoc/client"
+)
+
+// submitCmd sends the answer to a challenge part to the website, solving it on the puzzle input unless given.
+func submitCmd(ctx context.Context, args []string, s streams) error {
+	fs := flag.NewFlagSet("submit", flag.ContinueOnError)
+	answerArg := fs.String("answer", "", "answer to submit instead of solving the puzzle")
+	inputPath := fs.String("input", "", "puzzle input file, - for stdin")
+	inputsDir := fs.String("inputs", ".", "directory with puzzle inputs in YEAR/DAY/ subdirectories")
+	configPath := fs.String("config", "", "config file with the session cookie (default in the user config directory)")
+	cacheDir := fs.String("cache", defaultCacheDir, "directory for downloaded inputs and the log of submitted answers")
+	answersPath := fs.String("answers", "answers.json", "file with known answers, correct answers are recorded in it")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 3 {
+		return fmt.Errorf("%w: expected YEAR, DAY and PART", errUsage)
+	}
+	year, day, err := parseYearDay(positional[0], positional[1])
+	if err != nil {
+		return err
+	}
+	part, err := strconv.Atoi(positional[2])
+	if err != nil {
+		return fmt.Errorf("%w: part %q is not a number", errUsage, positional[2])
+	}
+	c, err := newClient(*configPath, *cacheDir)
+	if err != nil {
+		return err
+	}
+
+	// Don't bother solving (or downloading the input) while answers wouldn't be accepted anyway
+	if remaining, err := c.Cooldown(year, day); err != nil {
+		return err
+	} else if remaining > 0 {
+		return fmt.Errorf("%d/%d part %d: %w: %v left to wait", year, day, part, client.ErrCooldown, remaining.Round(time.Second))
+	}
+
+	answer, input := *answerArg, ""
+	if answer == "" {
+		puzzle, err := aoc.Lookup(year, day)
+		if err != nil {
+			return err
+		}
+		if input, err = submitInput(ctx, c, puzzle, *inputPath, *inputsDir, s); err != nil {
+			return err
+		}
+		solved, err := puzzle.Solve(ctx, part, strings.NewReader(input))
+		if err != nil {
+			return fmt.Errorf("%v part %d: %w", puzzle.Key(), part, err)
+		}
+		answer = aoc.FormatAnswer(solved)
+		if strings.Contains(answer, "\n") {
+			return fmt.Errorf("%d/%d part %d: the answer has several lines, read it and use --answer:\n%s", year, day, part, answer)
+		}
+	}
+
+	result, err := c.Submit(ctx, year, day, part, answer)
+	if err != nil {
+		return fmt.Errorf("%d/%d part %d: %w", year, day, part, err)
+	}
+	switch result.Verdict {
+	case client.Correct:
+		fmt.Fprintf(s.stdout, "%d/%d part %d: %s is correct\n", year, day, part, answer)
+		if input == "" {
+			return nil
+		}
+		known, err := aoc.LoadAnswers(*answersPath)
+		if err != nil {
+			return err
+		}
+		known.Set(aoc.Answer{Year: year, Day: day, Part: part, Input: aoc.InputHash(input), Answer: answer})
+		return known.Save(*answersPath)
+	case client.Unknown:
+		return fmt.Errorf("%d/%d part %d: unrecognized response: %s", year, day, part, result.Message)
+	}
+	msg := fmt.Sprintf("%d/%d part %d: %s is %v", year, day, part, answer, result.Verdict)
+	if result.Verdict == client.TooSoon || result.Verdict == client.WrongLevel {
+		msg = fmt.Sprintf("%d/%d part %d: %s was not checked (%v)", year, day, part, answer, result.Verdict)
+	}
+	if result.Wait > 0 {
+		msg += fmt.Sprintf(", wait %v before the next answer", result.Wait)
+	}
+	return errors.New(msg)
+}
+
+// submitInput returns the normalized puzzle input to solve, downloading it if it isn't found locally.
+func submitInput(ctx context.Context, c *client.Client, puzzle aoc.Puzzle, path, inputsDir string, s streams) (string, error) {
+	input, err := loadInput(puzzle, path, inputsDir, c.CacheDir, s)
+	if errors.Is(err, aoc.ErrNoInput) {
+		if input, err = c.Input(ctx, puzzle.Year, puzzle.Day); err != nil {
+			return "", err
+		}
+		return aoc.ReadInput(strings.NewReader(input))
+	}
+	return input, err
+}
@@ -0,0 +1,292 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/rubinda/aoc"
+)
+
+// Verification statuses of a single puzzle part.
+const (
+	statusPass    = "pass"
+	statusFail    = "fail"
+	statusMissing = "missing"
+	statusError   = "error"
+	statusTimeout = "timeout"
+)
+
+// errVerifyFailed is returned when at least one answer didn't match the known answer.
+var errVerifyFailed = errors.New("verification failed")
+
+// puzzleInput is a named puzzle input that a solver can be run on.
+type puzzleInput struct {
+	name string
+	text string
+	// part limits the input to a single challenge part, 0 means all parts.
+	part int
+}
+
+// verifyResult is the outcome of checking one challenge part against the known answers.
+type verifyResult struct {
+	puzzle   aoc.Puzzle
+	part     int
+	input    puzzleInput
+	hash     string
+	status   string
+	answer   string
+	expected string
+	err      error
+}
+
+// details describes why the result has its status.
+func (r verifyResult) details() string {
+	switch r.status {
+	case statusError:
+		return r.err.Error()
+	case statusFail:
+		if strings.Contains(r.answer+r.expected, "\n") {
+			return "multi-line answer differs"
+		}
+		return fmt.Sprintf("got %s, want %s", r.answer, r.expected)
+	case statusMissing:
+		return "no known answer"
+	case statusTimeout:
+		return "no answer within the day's timeout"
+	}
+	return ""
+}
+
+// selectPuzzles returns the registered puzzles matching optional YEARS and DAYS arguments.
+func selectPuzzles(positional []string) ([]aoc.Puzzle, error) {
+	if len(positional) > 2 {
+		return nil, fmt.Errorf("%w: expected at most YEARS and DAYS", errUsage)
+	}
+	if len(positional) == 0 {
+		return aoc.Puzzles(), nil
+	}
+	fromYear, toYear, err := parseRange(positional[0], "year")
+	if err != nil {
+		return nil, err
+	}
+	fromDay, toDay := 1, 25
+	if len(positional) == 2 {
+		if fromDay, toDay, err = parseRange(positional[1], "day"); err != nil {
+			return nil, err
+		}
+	}
+	// A single day keeps the registry's error
+	if fromYear == toYear && fromDay == toDay {
+		p, err := aoc.Lookup(fromYear, fromDay)
+		if err != nil {
+			return nil, err
+		}
+		return []aoc.Puzzle{p}, nil
+	}
+
+	puzzles := make([]aoc.Puzzle, 0)
+	for _, p := range aoc.Puzzles() {
+		if p.Year >= fromYear && p.Year <= toYear && p.Day >= fromDay && p.Day <= toDay {
+			puzzles = append(puzzles, p)
+		}
+	}
+	if len(puzzles) == 0 {
+		return nil, fmt.Errorf("%s: %w", strings.Join(positional, "/"), aoc.ErrNotFound)
+	}
+	return puzzles, nil
+}
+
+// puzzleInputs returns the examples (if wanted) and the personal input of a puzzle, if one exists.
+func puzzleInputs(p aoc.Puzzle, inputsDir, cacheDir string, withExample bool) ([]puzzleInput, error) {
+	inputs := make([]puzzleInput, 0, 2)
+	if withExample {
+		inputs = append(inputs, exampleInputs(p)...)
+	}
+	input, found, err := personalInput(p, inputsDir, cacheDir)
+	if err != nil || !found {
+		return inputs, err
+	}
+	return append(inputs, input), nil
+}
+
+// exampleInputs returns the examples of a puzzle. Parts with their own example get a separate input.
+func exampleInputs(p aoc.Puzzle) []puzzleInput {
+	if len(p.PartExamples) == 0 {
+		if p.Example == "" {
+			return nil
+		}
+		return []puzzleInput{{name: "example", text: p.Example}}
+	}
+	inputs := make([]puzzleInput, 0, p.Parts)
+	for part := 1; part <= p.Parts; part++ {
+		inputs = append(inputs, puzzleInput{name: "example", text: p.ExampleInput(part), part: part})
+	}
+	return inputs
+}
+
+// personalInput returns the puzzle input found in the inputs or cache directory. Returns false if there is none.
+func personalInput(p aoc.Puzzle, inputsDir, cacheDir string) (puzzleInput, bool, error) {
+	path, err := findInput(inputsDir, cacheDir, p.Year, p.Day)
+	if errors.Is(err, aoc.ErrNoInput) {
+		return puzzleInput{}, false, nil
+	}
+	if err != nil {
+		return puzzleInput{}, false, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return puzzleInput{}, false, err
+	}
+	defer f.Close()
+	text, err := aoc.ReadInput(f)
+	if err != nil {
+		return puzzleInput{}, false, err
+	}
+	return puzzleInput{name: filepath.Base(path), text: text}, true, nil
+}
+
+// verify solves every part of a puzzle on given input and compares answers with the known ones.
+func verify(ctx context.Context, p aoc.Puzzle, input puzzleInput, known *aoc.Answers) []verifyResult {
+	results := make([]verifyResult, 0, p.Parts)
+	hash := aoc.InputHash(input.text)
+	for part := 1; part <= p.Parts; part++ {
+		if input.part != 0 && input.part != part {
+			continue
+		}
+		r := verifyResult{puzzle: p, part: part, input: input, hash: hash}
+		answer, err := p.Solve(ctx, part, strings.NewReader(input.text))
+		expected, isKnown := known.Find(p.Year, p.Day, part, hash)
+		r.answer, r.expected, r.err = aoc.FormatAnswer(answer), expected, err
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			r.status = statusTimeout
+		case err != nil:
+			r.status = statusError
+		case !isKnown:
+			r.status = statusMissing
+		case r.answer == expected:
+			r.status = statusPass
+		default:
+			r.status = statusFail
+		}
+		results = append(results, r)
+	}
+	return results
+}
+
+// verifyPuzzle verifies a puzzle on all of its inputs within given timeout (0 means no limit).
+func verifyPuzzle(ctx context.Context, p aoc.Puzzle, inputs []puzzleInput, known *aoc.Answers, timeout time.Duration) []verifyResult {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	results := make([]verifyResult, 0, p.Parts*len(inputs))
+	for _, input := range inputs {
+		results = append(results, verify(ctx, p, input, known)...)
+	}
+	return results
+}
+
+// verifyAll verifies the puzzles on their inputs with given number of workers, each puzzle at most for given timeout
+// (0 means no limit). The results are in the order of the puzzles.
+func verifyAll(ctx context.Context, puzzles []aoc.Puzzle, inputs [][]puzzleInput, known *aoc.Answers, workers int, timeout time.Duration) []verifyResult {
+	perPuzzle := make([][]verifyResult, len(puzzles))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				perPuzzle[i] = verifyPuzzle(ctx, puzzles[i], inputs[i], known, timeout)
+			}
+		}()
+	}
+	for i := range puzzles {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	results := make([]verifyResult, 0)
+	for _, r := range perPuzzle {
+		results = append(results, r...)
+	}
+	return results
+}
+
+// verifyCmd checks the answers of all (or selected) puzzles against the known answers file.
+func verifyCmd(ctx context.Context, args []string, s streams) error {
+	fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+	answersPath := fs.String("answers", "answers.json", "file with known answers")
+	inputsDir := fs.String("inputs", ".", "directory with puzzle inputs in YEAR/DAY/ subdirectories")
+	cacheDir := fs.String("cache", defaultCacheDir, "directory with inputs downloaded by fetch, searched after the inputs directory")
+	withExamples := fs.Bool("examples", true, "also verify the examples from puzzle descriptions")
+	record := fs.Bool("record", false, "store answers that are missing in the answers file")
+	workers := fs.Int("workers", runtime.NumCPU(), "number of puzzles verified at the same time")
+	timeout := fs.Duration("timeout", time.Minute, "time limit for all parts of a puzzle, 0 means no limit")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	puzzles, err := selectPuzzles(positional)
+	if err != nil {
+		return err
+	}
+	if *workers < 1 {
+		return fmt.Errorf("%w: --workers has to be at least 1, got %d", errUsage, *workers)
+	}
+	known, err := aoc.LoadAnswers(*answersPath)
+	if err != nil {
+		return err
+	}
+
+	inputs := make([][]puzzleInput, len(puzzles))
+	for i, p := range puzzles {
+		if inputs[i], err = puzzleInputs(p, *inputsDir, *cacheDir, *withExamples); err != nil {
+			return fmt.Errorf("%v: %w", p.Key(), err)
+		}
+	}
+	results := verifyAll(ctx, puzzles, inputs, known, *workers, *timeout)
+
+	counts := make(map[string]int)
+	w := tabwriter.NewWriter(s.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "YEAR\tDAY\tPART\tINPUT\tHASH\tSTATUS\tDETAILS")
+	for _, r := range results {
+		counts[r.status]++
+		fmt.Fprintf(w, "%d\t%d\t%d\t%s\t%.12s\t%s\t%s\n", r.puzzle.Year, r.puzzle.Day, r.part, r.input.name, r.hash, r.status, r.details())
+		if *record && r.status == statusMissing {
+			known.Set(aoc.Answer{Year: r.puzzle.Year, Day: r.puzzle.Day, Part: r.part, Input: r.hash, Answer: r.answer})
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(s.stdout, "\n%d passed, %d failed, %d errors, %d timeouts, %d missing\n",
+		counts[statusPass], counts[statusFail], counts[statusError], counts[statusTimeout], counts[statusMissing])
+
+	if *record && counts[statusMissing] > 0 {
+		if err := known.Save(*answersPath); err != nil {
+			return err
+		}
+		fmt.Fprintf(s.stdout, "Recorded %d answers in %s\n", counts[statusMissing], *answersPath)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if counts[statusFail]+counts[statusError]+counts[statusTimeout] > 0 {
+		return fmt.Errorf("%w: %d failed, %d errors, %d timeouts",
+			errVerifyFailed, counts[statusFail], counts[statusError], counts[statusTimeout])
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rubinda/aoc"
+	"github.com/rubinda/aoc/internal/viz"
+	"github.com/rubinda/aoc/scaffold"
+	"golang.org/x/exp/slices"
+)
+
+// defaultVizDir is where viz writes the animations, in YEAR/DAY subdirectories. It is ignored by git.
+const defaultVizDir = "viz"
+
+// vizCmd records the simulation of a day while solving it and writes it as an animation.
+func vizCmd(ctx context.Context, args []string, s streams) error {
+	fs := flag.NewFlagSet("viz", flag.ContinueOnError)
+	part := fs.Int("part", 0, "challenge part to visualize (all parts if omitted)")
+	inputPath := fs.String("input", "", "puzzle input file, - for stdin")
+	useExample := fs.Bool("example", false, "visualize the example from the puzzle description")
+	inputsDir := fs.String("inputs", ".", "directory with puzzle inputs in YEAR/DAY/ subdirectories")
+	cacheDir := fs.String("cache", defaultCacheDir, "directory with inputs downloaded by fetch, searched after the inputs directory")
+	format := fs.String("format", "gif", "format of the animation: gif or png (a file per frame)")
+	out := fs.String("out", "", "directory to write the animation to, named partN (default viz/YEAR/DAY)")
+	scale := fs.Int("scale", 4, "pixels per cell of the simulation")
+	delay := fs.Duration("delay", 100*time.Millisecond, "time between frames of a GIF")
+	every := fs.Int("every", 1, "record every n-th step of the simulation")
+	maxFrames := fs.Int("max-frames", 500, "most frames recorded (the final state is always added), 0 for no limit")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 2 {
+		return fmt.Errorf("%w: expected YEAR and DAY", errUsage)
+	}
+	if *inputPath != "" && *useExample {
+		return fmt.Errorf("%w: --input and --example are mutually exclusive", errUsage)
+	}
+	if !slices.Contains(viz.Formats, *format) {
+		return fmt.Errorf("%w: unknown format %q, expected one of %v", errUsage, *format, viz.Formats)
+	}
+	if *scale < 1 || *every < 1 || *maxFrames < 0 {
+		return fmt.Errorf("%w: --scale and --every have to be positive, --max-frames can't be negative", errUsage)
+	}
+	year, day, err := parseYearDay(positional[0], positional[1])
+	if err != nil {
+		return err
+	}
+	puzzle, err := aoc.Lookup(year, day)
+	if err != nil {
+		return err
+	}
+	recorder, err := viz.Lookup(year, day)
+	if err != nil {
+		return err
+	}
+	if *out == "" {
+		*out = scaffold.Dir(defaultVizDir, year, day)
+	}
+
+	inputFor := puzzle.ExampleInput
+	if !*useExample {
+		input, err := loadInput(puzzle, *inputPath, *inputsDir, *cacheDir, s)
+		if err != nil {
+			return err
+		}
+		inputFor = func(int) string {
+			return input
+		}
+	}
+
+	visualize := func(p int) error {
+		path := filepath.Join(*out, fmt.Sprintf("part%d", p))
+		encoder, err := viz.NewEncoder(*format, path, *scale, *delay)
+		if err != nil {
+			return err
+		}
+		recorder.Enable(encoder, *every, *maxFrames)
+		_, err = puzzle.Solve(ctx, p, strings.NewReader(inputFor(p)))
+		recordErr := recorder.Disable()
+		if closeErr := encoder.Close(); recordErr == nil {
+			recordErr = closeErr
+		}
+		if err != nil {
+			return fmt.Errorf("%v part %d: %w", puzzle.Key(), p, err)
+		}
+		if recordErr != nil {
+			return fmt.Errorf("%v part %d: %w", puzzle.Key(), p, recordErr)
+		}
+		if *format == "png" {
+			path += "-*"
+		}
+		fmt.Fprintf(s.stdout, "%v part %d: %d frames written to %s.%s\n", puzzle.Key(), p, recorder.Frames(), path, *format)
+		return nil
+	}
+	if *part != 0 {
+		return visualize(*part)
+	}
+	for p := 1; p <= puzzle.Parts; p++ {
+		if err := visualize(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package aoc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ExamplesFile is the name of the file in a day's folder that lists its examples.
+const ExamplesFile = "examples.json"
+
+// Example is an example input from the puzzle description with the answers the description gives for it.
+type Example struct {
+	Name string `json:"name"`
+	// File is the example's input file in the day's folder, used instead of Input.
+	File  string `json:"file,omitempty"`
+	Input string `json:"input,omitempty"`
+	// Answers holds the answers by challenge part. Parts the description doesn't answer for the example are missing.
+	Answers map[int]string `json:"answers"`
+}
+
+// Text returns the (normalized, see ReadInput) input of the example. Files are looked up in dir.
+func (e Example) Text(dir string) (string, error) {
+	if e.File == "" {
+		return strings.TrimRight(e.Input, "\n"), nil
+	}
+	f, err := os.Open(filepath.Join(dir, e.File))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	return ReadInput(f)
+}
+
+// LoadExamples reads the examples from a JSON file. A missing file results in no examples.
+func LoadExamples(path string) ([]Example, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	examples := make([]Example, 0)
+	if err := json.Unmarshal(data, &examples); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	for i, e := range examples {
+		if e.Name == "" || (e.File == "") == (e.Input == "") {
+			return nil, fmt.Errorf("%s: example #%d needs a name and either a file or an input", path, i+1)
+		}
+	}
+	return examples, nil
+}
+
+// SaveExamples writes the examples to a JSON file.
+func SaveExamples(path string, examples []Example) error {
+	data, err := json.MarshalIndent(examples, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
@@ -0,0 +1,45 @@
+package aoc
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExamplesRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "example.in"), []byte("1\r\n2\n\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, ExamplesFile)
+	examples := []Example{
+		{Name: "example", File: "example.in", Answers: map[int]string{1: "3"}},
+		{Name: "example2", Input: "4\n", Answers: map[int]string{2: "4"}},
+	}
+	if err := SaveExamples(path, examples); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadExamples(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"1\n2", "4"}
+	for i, e := range loaded {
+		if text, err := e.Text(dir); err != nil || text != expected[i] {
+			t.Errorf("Wrong input of %s! Expected: %q, actual: %q (%v)", e.Name, expected[i], text, err)
+		}
+	}
+	if len(loaded) != 2 || loaded[1].Answers[2] != "4" {
+		t.Errorf("Wrong examples loaded! Actual: %v", loaded)
+	}
+}
+
+func TestLoadExamplesInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ExamplesFile)
+	if err := os.WriteFile(path, []byte(`[{"name": "both", "file": "example.in", "input": "1", "answers": {}}]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadExamples(path); err == nil {
+		t.Error("Expected an error for an example with a file and an input")
+	}
+}
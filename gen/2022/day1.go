@@ -0,0 +1,26 @@
+package gen2022
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/rubinda/aoc/gen"
+)
+
+// calories lists the calories of the food carried by each elf, elves are separated by an empty line.
+func calories(r *rand.Rand, size float64) string {
+	elves := make([]string, gen.Scale(250, size))
+	for i := range elves {
+		food := make([]string, gen.Between(r, 1, 15))
+		for j := range food {
+			food[j] = strconv.Itoa(gen.Between(r, 1000, 60000))
+		}
+		elves[i] = strings.Join(food, "\n")
+	}
+	return strings.Join(elves, "\n\n")
+}
+
+func init() {
+	gen.Register(2022, 1, gen.GeneratorFunc(calories))
+}
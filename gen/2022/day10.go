@@ -0,0 +1,45 @@
+package gen2022
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/rubinda/aoc/gen"
+)
+
+const (
+	// crtPixels is the number of pixels on the display, the program can't run for more cycles than that.
+	crtPixels = 40 * 6
+	// crtWidth is the width of the display, the sprite is kept on it.
+	crtWidth = 40
+)
+
+// cpuProgram returns a program running for exactly as many cycles as the display has pixels.
+// The display limits the program, so size is ignored.
+func cpuProgram(r *rand.Rand, _ float64) string {
+	program := make([]string, 0)
+	register := 1
+	for cycles := 0; cycles < crtPixels; {
+		if crtPixels-cycles == 1 || r.Intn(3) == 0 {
+			program = append(program, "noop")
+			cycles++
+			continue
+		}
+		next := gen.Between(r, 0, crtWidth-1)
+		for next == register {
+			next = gen.Between(r, 0, crtWidth-1)
+		}
+		if next-register > 10 || register-next > 10 {
+			next = register + gen.Clamp(next-register, -10, 10)
+		}
+		program = append(program, "addx "+strconv.Itoa(next-register))
+		register = next
+		cycles += 2
+	}
+	return strings.Join(program, "\n")
+}
+
+func init() {
+	gen.Register(2022, 10, gen.GeneratorFunc(cpuProgram))
+}
@@ -0,0 +1,123 @@
+package gen2022
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/rubinda/aoc/gen"
+)
+
+// monkey is a generated monkey of the keep away game.
+type monkey struct {
+	items []int
+	// operation is the sign of the worry level increase, square is "^".
+	operation string
+	operand   int
+	divisor   int
+	catchers  [2]int
+}
+
+// inspect returns the new worry level of an item.
+func (m monkey) inspect(worry int) int {
+	switch m.operation {
+	case "+":
+		return worry + m.operand
+	case "*":
+		return worry * m.operand
+	}
+	return worry * worry
+}
+
+// maxWorry is the highest worry level the first 20 rounds (without the modulus of part 2) may reach,
+// so that squaring it doesn't overflow.
+const maxWorry = 1 << 31
+
+// keepAway describes 8 monkeys with their items and how they decide where to throw them. Their test divisors are
+// different primes, so worry levels modulo their product fit into an int even when squared. The items are dealt
+// so that worry levels stay in range for the first 20 rounds.
+func keepAway(r *rand.Rand, size float64) string {
+	primes := []int{2, 3, 5, 7, 11, 13, 17, 19}
+	var monkeys []monkey
+	for attempt := 0; monkeys == nil; attempt++ {
+		r.Shuffle(len(primes), func(i, j int) { primes[i], primes[j] = primes[j], primes[i] })
+		// One monkey squares worry levels, two multiply them and the rest add to them.
+		// Squaring blows worry levels up quickly, it's dropped after a few attempts.
+		operations := []string{"^", "*", "*", "+", "+", "+", "+", "+"}
+		if attempt >= 100 {
+			operations[0] = "*"
+		}
+		r.Shuffle(len(operations), func(i, j int) { operations[i], operations[j] = operations[j], operations[i] })
+		monkeys = make([]monkey, len(primes))
+		for i := range monkeys {
+			m := &monkeys[i]
+			m.divisor = primes[i]
+			m.operation, m.operand = operations[i], gen.Between(r, 1, 8)
+			if m.operation == "*" {
+				m.operand = gen.Between(r, 2, 19)
+			}
+			first := (i + gen.Between(r, 1, len(primes)-1)) % len(primes)
+			second := (first + gen.Between(r, 1, len(primes)-2)) % len(primes)
+			if second == i {
+				second = (second + 1) % len(primes)
+			}
+			m.catchers = [2]int{first, second}
+			m.items = make([]int, gen.Between(r, 1, 2*gen.Scale(4, size)-1))
+			for j := range m.items {
+				m.items[j] = gen.Between(r, 50, 99)
+			}
+		}
+		if !keepsCalm(monkeys) {
+			monkeys = nil
+		}
+	}
+
+	descriptions := make([]string, len(monkeys))
+	for i, m := range monkeys {
+		items := make([]string, len(m.items))
+		for j, item := range m.items {
+			items[j] = strconv.Itoa(item)
+		}
+		operation := fmt.Sprintf("old %s %d", m.operation, m.operand)
+		if m.operation == "^" {
+			operation = "old * old"
+		}
+		descriptions[i] = fmt.Sprintf(`Monkey %d:
+  Starting items: %s
+  Operation: new = %s
+  Test: divisible by %d
+    If true: throw to monkey %d
+    If false: throw to monkey %d`, i, strings.Join(items, ", "), operation, m.divisor, m.catchers[0], m.catchers[1])
+	}
+	return strings.Join(descriptions, "\n\n")
+}
+
+// keepsCalm plays the first 20 rounds and returns true if worry levels stay below maxWorry.
+func keepsCalm(monkeys []monkey) bool {
+	items := make([][]int, len(monkeys))
+	for i, m := range monkeys {
+		items[i] = append([]int(nil), m.items...)
+	}
+	for round := 0; round < 20; round++ {
+		for i, m := range monkeys {
+			for _, worry := range items[i] {
+				worry = m.inspect(worry) / 3
+				if worry > maxWorry {
+					return false
+				}
+				catcher := m.catchers[1]
+				if worry%m.divisor == 0 {
+					catcher = m.catchers[0]
+				}
+				items[catcher] = append(items[catcher], worry)
+			}
+			items[i] = items[i][:0]
+		}
+	}
+	return true
+}
+
+func init() {
+	gen.Register(2022, 11, gen.GeneratorFunc(keepAway))
+}
@@ -0,0 +1,54 @@
+package gen2022
+
+import (
+	"math/rand"
+	"strings"
+
+	"github.com/rubinda/aoc/gen"
+)
+
+// heightmap returns a map of elevations (a-z) with a path climbing at most one level per step
+// from the start S in the leftmost column to the best signal E. The rest of the map is random.
+func heightmap(r *rand.Rand, size float64) string {
+	width, height := gen.Clamp(gen.Side(162, size), 35, 1<<16), gen.Clamp(gen.Side(41, size), 3, 1<<16)
+	rows := make([][]byte, height)
+	for y := range rows {
+		rows[y] = make([]byte, width)
+		for x := range rows[y] {
+			rows[y][x] = byte('a' + r.Intn(26))
+		}
+	}
+	// The path only moves right or along a column, so it never crosses itself
+	path := make([][2]int, 0)
+	x, y := 0, r.Intn(height)
+	goal := gen.Between(r, width*3/4, width-1)
+	for x <= goal {
+		path = append(path, [2]int{x, y})
+		next := gen.Clamp(y+gen.Between(r, -3, 3), 0, height-1)
+		for y != next && x < goal {
+			if y < next {
+				y++
+			} else {
+				y--
+			}
+			path = append(path, [2]int{x, y})
+		}
+		x++
+	}
+	for i, p := range path {
+		rows[p[1]][p[0]] = byte('a' + 25*i/(len(path)-1))
+	}
+	start, end := path[0], path[len(path)-1]
+	rows[start[1]][start[0]] = 'S'
+	rows[end[1]][end[0]] = 'E'
+
+	lines := make([]string, height)
+	for y, row := range rows {
+		lines[y] = string(row)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func init() {
+	gen.Register(2022, 12, gen.GeneratorFunc(heightmap))
+}
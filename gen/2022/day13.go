@@ -0,0 +1,104 @@
+package gen2022
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/rubinda/aoc/gen"
+)
+
+// packet is a list of integers and lists, or an integer if list is nil.
+type packet struct {
+	list  []packet
+	value int
+}
+
+// dividers are the packets added by the second part, the input must not hold packets in the same order.
+var dividers = []packet{
+	{list: []packet{{list: []packet{{value: 2}}}}},
+	{list: []packet{{list: []packet{{value: 6}}}}},
+}
+
+// String returns the packet as it is written in the input.
+func (p packet) String() string {
+	if p.list == nil {
+		return strconv.Itoa(p.value)
+	}
+	items := make([]string, len(p.list))
+	for i, item := range p.list {
+		items[i] = item.String()
+	}
+	return "[" + strings.Join(items, ",") + "]"
+}
+
+// compare returns -1, 0 or 1 if the packet is before, in the same place as or after the other one.
+func (p packet) compare(other packet) int {
+	switch {
+	case p.list == nil && other.list == nil:
+		switch {
+		case p.value < other.value:
+			return -1
+		case p.value > other.value:
+			return 1
+		}
+		return 0
+	case p.list == nil:
+		return packet{list: []packet{p}}.compare(other)
+	case other.list == nil:
+		return p.compare(packet{list: []packet{other}})
+	}
+	for i := 0; i < len(p.list) && i < len(other.list); i++ {
+		if order := p.list[i].compare(other.list[i]); order != 0 {
+			return order
+		}
+	}
+	switch {
+	case len(p.list) < len(other.list):
+		return -1
+	case len(p.list) > len(other.list):
+		return 1
+	}
+	return 0
+}
+
+// randomPacket returns a list with up to 5 items, nested at most depth levels deep.
+func randomPacket(r *rand.Rand, depth int) packet {
+	p := packet{list: make([]packet, r.Intn(6))}
+	for i := range p.list {
+		if depth > 0 && r.Intn(3) == 0 {
+			p.list[i] = randomPacket(r, depth-1)
+		} else {
+			p.list[i] = packet{value: r.Intn(11)}
+		}
+	}
+	return p
+}
+
+// distressSignal lists pairs of packets. The packets of a pair are never in the same order, nor are any packets
+// in the same order as the dividers.
+func distressSignal(r *rand.Rand, size float64) string {
+	pairs := make([]string, gen.Scale(150, size))
+	for i := range pairs {
+		var left, right packet
+		for left.compare(right) == 0 || isDivider(left) || isDivider(right) {
+			left, right = randomPacket(r, 4), randomPacket(r, 4)
+		}
+		pairs[i] = left.String() + "\n" + right.String()
+	}
+	return strings.Join(pairs, "\n\n")
+}
+
+// isDivider returns true if the packet is in the same order as one of the dividers.
+func isDivider(p packet) bool {
+	for _, divider := range dividers {
+		if p.compare(divider) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	gen.Register(2022, 13, gen.GeneratorFunc(distressSignal))
+}
@@ -0,0 +1,47 @@
+package gen2022
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/rubinda/aoc/gen"
+)
+
+const (
+	// sandSourceX is where sand pours into the cave, at the top.
+	sandSourceX = 500
+	// maxRockCoordinate is the largest coordinate of rock the solver accepts, it keeps the whole cave in memory.
+	maxRockCoordinate = 1000
+)
+
+// rockPaths lists paths of straight rock walls below the sand source. The cave gets deeper with size,
+// up to maxRockCoordinate.
+func rockPaths(r *rand.Rand, size float64) string {
+	depth := gen.Clamp(gen.Side(170, size), 10, maxRockCoordinate)
+	spread := depth / 3
+	paths := make([]string, gen.Scale(150, size))
+	for i := range paths {
+		x := gen.Between(r, sandSourceX-spread, sandSourceX+spread)
+		y := gen.Between(r, 2, depth)
+		if i == 0 {
+			// The deepest rock decides where the floor is
+			y = depth
+		}
+		points := []string{fmt.Sprintf("%d,%d", x, y)}
+		for segment := gen.Between(r, 1, 6); segment > 0; segment-- {
+			if segment%2 == 0 {
+				y = gen.Clamp(y+gen.Between(r, -8, 8), 2, depth)
+			} else {
+				x = gen.Clamp(x+gen.Between(r, -8, 8), 0, maxRockCoordinate)
+			}
+			points = append(points, fmt.Sprintf("%d,%d", x, y))
+		}
+		paths[i] = strings.Join(points, " -> ")
+	}
+	return strings.Join(paths, "\n")
+}
+
+func init() {
+	gen.Register(2022, 14, gen.GeneratorFunc(rockPaths))
+}
@@ -0,0 +1,126 @@
+package gen2022
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/rubinda/aoc/gen"
+)
+
+// searchMax bounds the coordinates of the distress beacon (the minimum is 0).
+const searchMax = 4000000
+
+// sensor is a generated sensor, it covers the points within radius (Manhattan distance).
+type sensor struct {
+	x, y, radius int
+}
+
+// within returns true if the point is closer to the sensor than its radius.
+func (s sensor) within(x, y int) bool {
+	return abs(s.x-x)+abs(s.y-y) < s.radius
+}
+
+// abs returns the absolute value of n.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// beaconSensors lists sensors with their closest beacons, that together cover every point of the search area but
+// the distress beacon. Rotated by 45 degrees (u = x+y, v = x-y) the area a sensor covers is a square. The squares
+// are laid in columns left and right of the distress beacon's u and in a column covering its u above and below it.
+func beaconSensors(r *rand.Rand, size float64) string {
+	hiddenX, hiddenY := r.Intn(searchMax+1), r.Intn(searchMax+1)
+	u, v := hiddenX+hiddenY, hiddenX-hiddenY
+	// An odd radius keeps the centers of squares on points with whole coordinates
+	radius := searchMax/gen.Side(5, size) | 1
+	step := 2 * radius
+	// rows returns the centers (v) of squares in a column covering all of the search area
+	rows := func() []int {
+		first := v
+		for first-step+radius >= -searchMax {
+			first -= step
+		}
+		centers := make([]int, 0)
+		for center := first; center-radius <= searchMax; center += step {
+			centers = append(centers, center)
+		}
+		return centers
+	}
+	squares := make([][2]int, 0)
+	for center := u - 1 - radius; center+radius >= 0; center -= step {
+		for _, row := range rows() {
+			squares = append(squares, [2]int{center, row})
+		}
+	}
+	for center := u + 1 + radius; center-radius <= 2*searchMax; center += step {
+		for _, row := range rows() {
+			squares = append(squares, [2]int{center, row})
+		}
+	}
+	for center := v - 1 - radius; center+radius >= -searchMax; center -= step {
+		squares = append(squares, [2]int{u, center})
+	}
+	for center := v + 1 + radius; center-radius <= searchMax; center += step {
+		squares = append(squares, [2]int{u, center})
+	}
+
+	sensors := make([]sensor, 0, len(squares))
+	for _, square := range squares {
+		s := sensor{x: (square[0] + square[1]) / 2, y: (square[0] - square[1]) / 2, radius: radius}
+		// Only sensors reaching into the search area are needed
+		nearX, nearY := gen.Clamp(s.x, 0, searchMax), gen.Clamp(s.y, 0, searchMax)
+		if abs(s.x-nearX)+abs(s.y-nearY) <= radius {
+			sensors = append(sensors, s)
+		}
+	}
+	r.Shuffle(len(sensors), func(i, j int) { sensors[i], sensors[j] = sensors[j], sensors[i] })
+
+	lines := make([]string, len(sensors))
+	for i, s := range sensors {
+		beaconX, beaconY := closestBeacon(r, s, sensors)
+		lines[i] = fmt.Sprintf("Sensor at x=%d, y=%d: closest beacon is at x=%d, y=%d", s.x, s.y, beaconX, beaconY)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// closestBeacon returns a point on the edge of the area the sensor covers. Points outside of the other sensors'
+// areas are preferred, there a beacon would be the closest one to a single sensor.
+func closestBeacon(r *rand.Rand, s sensor, sensors []sensor) (x, y int) {
+	corners := [][2]int{{s.radius, 0}, {0, s.radius}, {-s.radius, 0}, {0, -s.radius}}
+	for attempt := 0; attempt < 50; attempt++ {
+		side, offset := r.Intn(4), r.Intn(s.radius)
+		from, to := corners[side], corners[(side+1)%4]
+		x = s.x + from[0] + offset*sign(to[0]-from[0])
+		y = s.y + from[1] + offset*sign(to[1]-from[1])
+		alone := true
+		for _, other := range sensors {
+			if other != s && other.within(x, y) {
+				alone = false
+				break
+			}
+		}
+		if alone {
+			break
+		}
+	}
+	return x, y
+}
+
+// sign returns -1, 0 or 1 for negative, zero or positive n.
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
+
+func init() {
+	gen.Register(2022, 15, gen.GeneratorFunc(beaconSensors))
+}
@@ -0,0 +1,65 @@
+package gen2022
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/rubinda/aoc/gen"
+)
+
+const (
+	// startValve is where the search for the valves starts.
+	startValve = "AA"
+	// maxFlowValves is the most valves with a flow rate the solver can handle.
+	maxFlowValves = 20
+)
+
+// valveScan describes valves connected by tunnels. Valves with a flow rate (15 at size 1) are spread through
+// a cave of jammed valves. Every valve can be reached from AA, the tunnels form a tree with a few shortcuts.
+func valveScan(r *rand.Rand, size float64) string {
+	flowing := gen.Clamp(gen.Scale(15, size), 1, maxFlowValves)
+	names := append([]string{startValve}, gen.Names(r, flowing+gen.Scale(45, size), 2, "ABCDEFGHIJKLMNOPQRSTUVWXYZ", startValve)...)
+	tunnels := make([][]int, len(names))
+	connect := func(a, b int) {
+		for _, c := range tunnels[a] {
+			if c == b {
+				return
+			}
+		}
+		tunnels[a] = append(tunnels[a], b)
+		tunnels[b] = append(tunnels[b], a)
+	}
+	for i := 1; i < len(names); i++ {
+		connect(i, r.Intn(i))
+	}
+	for shortcuts := len(names) / 10; shortcuts > 0; shortcuts-- {
+		a, b := r.Intn(len(names)), r.Intn(len(names))
+		if a != b {
+			connect(a, b)
+		}
+	}
+	rates := make([]int, len(names))
+	for _, i := range r.Perm(len(names) - 1)[:flowing] {
+		rates[i+1] = gen.Between(r, 1, 25)
+	}
+
+	lines := make([]string, len(names))
+	for i, name := range names {
+		leads := make([]string, len(tunnels[i]))
+		for j, t := range tunnels[i] {
+			leads[j] = names[t]
+		}
+		tunnel := "tunnels lead to valves"
+		if len(leads) == 1 {
+			tunnel = "tunnel leads to valve"
+		}
+		lines[i] = fmt.Sprintf("Valve %s has flow rate=%d; %s %s", name, rates[i], tunnel, strings.Join(leads, ", "))
+	}
+	r.Shuffle(len(lines), func(i, j int) { lines[i], lines[j] = lines[j], lines[i] })
+	return strings.Join(lines, "\n")
+}
+
+func init() {
+	gen.Register(2022, 16, gen.GeneratorFunc(valveScan))
+}
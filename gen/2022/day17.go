@@ -0,0 +1,20 @@
+package gen2022
+
+import (
+	"math/rand"
+
+	"github.com/rubinda/aoc/gen"
+)
+
+// jetPattern returns the directions hot gas pushes the falling rocks in.
+func jetPattern(r *rand.Rand, size float64) string {
+	jets := make([]byte, gen.Scale(10091, size))
+	for i := range jets {
+		jets[i] = "<>"[r.Intn(2)]
+	}
+	return string(jets)
+}
+
+func init() {
+	gen.Register(2022, 17, gen.GeneratorFunc(jetPattern))
+}
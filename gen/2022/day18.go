@@ -0,0 +1,36 @@
+package gen2022
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+
+	"github.com/rubinda/aoc/gen"
+)
+
+// lavaDroplets lists cubes of a lava droplet: a rough ball with air pockets trapped inside.
+func lavaDroplets(r *rand.Rand, size float64) string {
+	side := gen.Scale(20, math.Cbrt(size))
+	center := float64(side-1) / 2
+	cubes := make([]string, 0)
+	for x := 0; x < side; x++ {
+		for y := 0; y < side; y++ {
+			for z := 0; z < side; z++ {
+				dx, dy, dz := float64(x)-center, float64(y)-center, float64(z)-center
+				if math.Sqrt(dx*dx+dy*dy+dz*dz) <= center+0.5 && r.Float64() < 0.6 {
+					cubes = append(cubes, fmt.Sprintf("%d,%d,%d", x, y, z))
+				}
+			}
+		}
+	}
+	if len(cubes) == 0 {
+		cubes = append(cubes, "0,0,0")
+	}
+	r.Shuffle(len(cubes), func(i, j int) { cubes[i], cubes[j] = cubes[j], cubes[i] })
+	return strings.Join(cubes, "\n")
+}
+
+func init() {
+	gen.Register(2022, 18, gen.GeneratorFunc(lavaDroplets))
+}
@@ -0,0 +1,25 @@
+package gen2022
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/rubinda/aoc/gen"
+)
+
+// blueprints lists the costs of robots of each robot factory blueprint.
+func blueprints(r *rand.Rand, size float64) string {
+	lines := make([]string, gen.Scale(30, size))
+	for i := range lines {
+		lines[i] = fmt.Sprintf("Blueprint %d: Each ore robot costs %d ore. Each clay robot costs %d ore. "+
+			"Each obsidian robot costs %d ore and %d clay. Each geode robot costs %d ore and %d obsidian.",
+			i+1, gen.Between(r, 2, 4), gen.Between(r, 2, 4),
+			gen.Between(r, 2, 4), gen.Between(r, 5, 20), gen.Between(r, 2, 4), gen.Between(r, 7, 20))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func init() {
+	gen.Register(2022, 19, gen.GeneratorFunc(blueprints))
+}
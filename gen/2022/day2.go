@@ -0,0 +1,21 @@
+package gen2022
+
+import (
+	"math/rand"
+	"strings"
+
+	"github.com/rubinda/aoc/gen"
+)
+
+// strategyGuide lists rounds of rock paper scissors, the opponent's shape and the response (or outcome).
+func strategyGuide(r *rand.Rand, size float64) string {
+	rounds := make([]string, gen.Scale(2500, size))
+	for i := range rounds {
+		rounds[i] = string(rune('A'+r.Intn(3))) + " " + string(rune('X'+r.Intn(3)))
+	}
+	return strings.Join(rounds, "\n")
+}
+
+func init() {
+	gen.Register(2022, 2, gen.GeneratorFunc(strategyGuide))
+}
@@ -0,0 +1,27 @@
+package gen2022
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/rubinda/aoc/gen"
+)
+
+// encryptedFile lists the numbers to mix, exactly one of them is 0. Other numbers may repeat.
+func encryptedFile(r *rand.Rand, size float64) string {
+	numbers := make([]string, gen.Clamp(gen.Scale(5000, size), 2, 1<<30))
+	zero := r.Intn(len(numbers))
+	for i := range numbers {
+		n := 0
+		for i != zero && n == 0 {
+			n = gen.Between(r, -10000, 10000)
+		}
+		numbers[i] = strconv.Itoa(n)
+	}
+	return strings.Join(numbers, "\n")
+}
+
+func init() {
+	gen.Register(2022, 20, gen.GeneratorFunc(encryptedFile))
+}
@@ -0,0 +1,157 @@
+package gen2022
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/rubinda/aoc/gen"
+)
+
+// monkeyTree builds the jobs of yelling monkeys. Every monkey is heard by exactly one other, so the jobs form
+// a tree with root at the top. Divisions always leave no remainder.
+type monkeyTree struct {
+	r     *rand.Rand
+	names []string
+	jobs  []string
+}
+
+// yell adds a monkey with given job and returns its name.
+func (t *monkeyTree) yell(job string) string {
+	name := t.names[0]
+	t.names = t.names[1:]
+	t.jobs = append(t.jobs, name+": "+job)
+	return name
+}
+
+// build adds a tree of about size monkeys that yells target (a positive number) and returns the name of its top.
+func (t *monkeyTree) build(target, size int) string {
+	if size < 3 {
+		return t.yell(strconv.Itoa(target))
+	}
+	leftSize := gen.Between(t.r, 1, size-2)
+	rightSize := size - 1 - leftSize
+	var left, right int
+	op := "+-*/"[t.r.Intn(4)]
+	if op == '*' {
+		if d := divisor(t.r, target); d > 1 {
+			left, right = d, target/d
+		} else {
+			op = '+'
+		}
+	}
+	if op == '/' && target > 1e12 || op == '+' && target < 2 {
+		op = '-'
+	}
+	switch op {
+	case '+':
+		left = gen.Between(t.r, 1, target-1)
+		right = target - left
+	case '-':
+		right = gen.Between(t.r, 1, 100)
+		left = target + right
+	case '/':
+		right = gen.Between(t.r, 2, 5)
+		left = target * right
+	}
+	if op == '+' || op == '*' {
+		if t.r.Intn(2) == 0 {
+			left, right = right, left
+		}
+	}
+	return t.yell(t.build(left, leftSize) + " " + string(op) + " " + t.build(right, rightSize))
+}
+
+// divisor returns a random divisor of n between 2 and 20, or 1 if there is none.
+func divisor(r *rand.Rand, n int) int {
+	divisors := []int{1}
+	for d := 2; d <= 20 && d < n; d++ {
+		if n%d == 0 {
+			divisors = append(divisors, d)
+		}
+	}
+	return divisors[r.Intn(len(divisors))]
+}
+
+// smallestFactor returns the smallest prime factor of n up to 7 (multipliers go up to 9), or 0 if there is none.
+func smallestFactor(n int) int {
+	for _, p := range []int{2, 3, 5, 7} {
+		if n%p == 0 {
+			return p
+		}
+	}
+	return 0
+}
+
+// mod returns the non-negative remainder of a / b.
+func mod(a, b int) int {
+	return (a%b + b) % b
+}
+
+// monkeyJobs lists the jobs of monkeys (about 2000 at size 1). The number humn yells passes through a chain of
+// operations up to one side of root. Along the chain it's kept as a*humn + b, with a positive in the end. Divisions
+// only happen when both a and b are divisible, so any number humn yells works for both parts and the number humn
+// has to yell for root's sides to be equal is a whole one.
+func monkeyJobs(r *rand.Rand, size float64) string {
+	total := gen.Clamp(gen.Scale(2000, size), 20, 1<<30)
+	steps := gen.Clamp(total/30, 2, 100)
+	t := &monkeyTree{r: r, names: gen.Names(r, 3*total, 4, lowercase, "root", "humn")}
+	constant := func(target int) string {
+		return t.build(target, gen.Between(r, 1, 2*total/(steps+2)))
+	}
+
+	a, b, path := 1, 0, "humn"
+	for i := 0; i < steps || a < 0; i++ {
+		p := smallestFactor(a)
+		var job string
+		switch choice := r.Intn(4); {
+		case abs(a) > 1000 && p > 0 && mod(b, p) == 0:
+			job = path + " / " + constant(p)
+			a, b = a/p, b/p
+		case abs(a) > 1000 && p > 0:
+			// Make b divisible, so the next step can divide
+			c := p - mod(b, p) + p*r.Intn(100)
+			job = path + " + " + constant(c)
+			b += c
+		case a < 0 && i >= steps-1 || choice == 0:
+			c := gen.Between(r, 1, 1000)
+			job = constant(c) + " - " + path
+			a, b = -a, c-b
+		case choice == 1:
+			c := gen.Between(r, 1, 1000)
+			job = path + " - " + constant(c)
+			b -= c
+		case choice == 2 && abs(a) <= 1000:
+			c := gen.Between(r, 2, 9)
+			job = path + " * " + constant(c)
+			if r.Intn(2) == 0 {
+				job = constant(c) + " * " + path
+			}
+			a, b = a*c, b*c
+		default:
+			c := gen.Between(r, 1, 1000)
+			job = path + " + " + constant(c)
+			if r.Intn(2) == 0 {
+				job = constant(c) + " + " + path
+			}
+			b += c
+		}
+		path = t.yell(job)
+	}
+	// Pick the number humn has to yell (up to about 10^12) and build the other side of root to match it
+	lowest := 1
+	if b < 1 {
+		lowest = (1-b)/a + 1
+	}
+	other := t.build(a*gen.Between(r, lowest, lowest+1e12/a)+b, total-len(t.jobs))
+	if r.Intn(2) == 0 {
+		path, other = other, path
+	}
+	t.jobs = append(t.jobs, "root: "+path+" + "+other, "humn: "+strconv.Itoa(gen.Between(r, 1, 5000)))
+	r.Shuffle(len(t.jobs), func(i, j int) { t.jobs[i], t.jobs[j] = t.jobs[j], t.jobs[i] })
+	return strings.Join(t.jobs, "\n")
+}
+
+func init() {
+	gen.Register(2022, 21, gen.GeneratorFunc(monkeyJobs))
+}
@@ -0,0 +1,48 @@
+package gen2022
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/rubinda/aoc/gen"
+)
+
+// monkeyMap draws a map folding into a cube (with sides 50 tiles long at size 1) followed by the path to walk.
+// The sides are always laid out in the net of the personal inputs, the only one the solver folds, none of the
+// other 10 cube nets is generated:
+//
+//	 01
+//	 2
+//	34
+//	5
+func monkeyMap(r *rand.Rand, size float64) string {
+	side := gen.Side(50, size)
+	// Sides in each row of the net and the column of the first one
+	layout := []struct{ column, sides int }{{1, 2}, {1, 1}, {0, 2}, {0, 1}}
+	lines := make([]string, 0, 4*side+2)
+	for _, row := range layout {
+		for y := 0; y < side; y++ {
+			tiles := make([]byte, row.sides*side)
+			for x := range tiles {
+				tiles[x] = '.'
+				if r.Intn(10) == 0 {
+					tiles[x] = '#'
+				}
+			}
+			lines = append(lines, strings.Repeat(" ", row.column*side)+string(tiles))
+		}
+	}
+	// The walk starts on the leftmost tile of the top row
+	lines[0] = lines[0][:side] + "." + lines[0][side+1:]
+
+	path := strconv.Itoa(gen.Between(r, 1, side))
+	for turns := gen.Scale(2000, size); turns > 0; turns-- {
+		path += string("RL"[r.Intn(2)]) + strconv.Itoa(gen.Between(r, 1, side))
+	}
+	return strings.Join(lines, "\n") + "\n\n" + path
+}
+
+func init() {
+	gen.Register(2022, 22, gen.GeneratorFunc(monkeyMap))
+}
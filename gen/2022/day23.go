@@ -0,0 +1,26 @@
+package gen2022
+
+import (
+	"math/rand"
+	"strings"
+
+	"github.com/rubinda/aoc/gen"
+)
+
+// elfGrove returns a square scan of the grove with about half of the spots taken by elves.
+func elfGrove(r *rand.Rand, size float64) string {
+	side := gen.Side(73, size)
+	rows := make([]string, side)
+	row := make([]byte, side)
+	for y := range rows {
+		for x := range row {
+			row[x] = ".#"[r.Intn(2)]
+		}
+		rows[y] = string(row)
+	}
+	return strings.Join(rows, "\n")
+}
+
+func init() {
+	gen.Register(2022, 23, gen.GeneratorFunc(elfGrove))
+}
@@ -0,0 +1,94 @@
+package gen2022
+
+import (
+	"math/rand"
+	"strings"
+
+	"github.com/rubinda/aoc/gen"
+)
+
+// valley holds the blizzards inside the walls of a valley, or '.' where there are none.
+type valley [][]byte
+
+// blizzardAt returns true if a blizzard covers the spot (inside the walls) at given minute.
+// Blizzards wrap around, so the initial map tells where a blizzard would have come from.
+func (v valley) blizzardAt(x, y, minute int) bool {
+	width, height := len(v[0]), len(v)
+	return v[y][mod(x-minute, width)] == '>' || v[y][mod(x+minute, width)] == '<' ||
+		v[mod(y-minute, height)][x] == 'v' || v[mod(y+minute, height)][x] == '^'
+}
+
+// cross returns the minute the expedition leaving from at departure arrives to, or false if it doesn't arrive
+// within limit minutes. The openings above and below the valley are (0, -1) and (width-1, height).
+func (v valley) cross(from, to [2]int, departure, limit int) (int, bool) {
+	width, height := len(v[0]), len(v)
+	start, goal := [2]int{0, -1}, [2]int{width - 1, height}
+	// Spots the expedition can be at, the row above and below the valley hold the openings
+	reached := make([]bool, width*(height+2))
+	index := func(p [2]int) int { return (p[1]+1)*width + p[0] }
+	reached[index(from)] = true
+	for minute := departure; minute <= departure+limit; minute++ {
+		if reached[index(to)] {
+			return minute, true
+		}
+		next := make([]bool, len(reached))
+		for i, ok := range reached {
+			if !ok {
+				continue
+			}
+			x, y := i%width, i/width-1
+			for _, d := range [][2]int{{0, 0}, {1, 0}, {-1, 0}, {0, 1}, {0, -1}} {
+				p := [2]int{x + d[0], y + d[1]}
+				inside := p[0] >= 0 && p[1] >= 0 && p[0] < width && p[1] < height
+				if p == start || p == goal || inside && !v.blizzardAt(p[0], p[1], minute+1) {
+					next[index(p)] = true
+				}
+			}
+		}
+		reached = next
+	}
+	return 0, false
+}
+
+// blizzardBasin draws a valley (120x25 at size 1) with blizzards, the expedition can cross it from the opening
+// in the top left to the one in the bottom right, back and there again. Blizzards in the columns of the openings
+// only blow sideways.
+func blizzardBasin(r *rand.Rand, size float64) string {
+	width, height := gen.Clamp(gen.Side(120, size), 2, 1<<16), gen.Clamp(gen.Side(25, size), 2, 1<<16)
+	start, goal := [2]int{0, -1}, [2]int{width - 1, height}
+	limit := 20 * (width + height)
+	density := 0.7
+	for {
+		v := make(valley, height)
+		for y := range v {
+			v[y] = make([]byte, width)
+			for x := range v[y] {
+				v[y][x] = '.'
+				if r.Float64() < density {
+					directions := "<>^v"
+					if x == 0 || x == width-1 {
+						directions = "<>"
+					}
+					v[y][x] = directions[r.Intn(len(directions))]
+				}
+			}
+		}
+		there, ok := v.cross(start, goal, 0, limit)
+		back, ok2 := v.cross(goal, start, there, limit)
+		_, ok3 := v.cross(start, goal, back, limit)
+		if ok && ok2 && ok3 {
+			lines := []string{"#." + strings.Repeat("#", width)}
+			for _, row := range v {
+				lines = append(lines, "#"+string(row)+"#")
+			}
+			lines = append(lines, strings.Repeat("#", width)+".#")
+			return strings.Join(lines, "\n")
+		}
+		// Thin the blizzards out until the valley can be crossed
+		density *= 0.95
+	}
+}
+
+func init() {
+	gen.Register(2022, 24, gen.GeneratorFunc(blizzardBasin))
+}
@@ -0,0 +1,26 @@
+package gen2022
+
+import (
+	"math/rand"
+	"strings"
+
+	"github.com/rubinda/aoc/gen"
+)
+
+// fuelRequirements lists positive SNAFU numbers of up to 20 digits.
+func fuelRequirements(r *rand.Rand, size float64) string {
+	numbers := make([]string, gen.Scale(120, size))
+	for i := range numbers {
+		// A leading 1 or 2 outweighs any negative digits after it
+		digits := []byte{"12"[r.Intn(2)]}
+		for n := r.Intn(20); n > 0; n-- {
+			digits = append(digits, "=-012"[r.Intn(5)])
+		}
+		numbers[i] = string(digits)
+	}
+	return strings.Join(numbers, "\n")
+}
+
+func init() {
+	gen.Register(2022, 25, gen.GeneratorFunc(fuelRequirements))
+}
@@ -0,0 +1,68 @@
+package gen2022
+
+import (
+	"math/rand"
+	"strings"
+
+	"github.com/rubinda/aoc/gen"
+)
+
+// items are the item types that can be packed in rucksacks.
+const items = lowercase + "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// rucksacks lists the items in rucksacks of groups of 3 elves. Both compartments of a rucksack share exactly one
+// item type and the rucksacks of a group exactly one, the badge.
+func rucksacks(r *rand.Rand, size float64) string {
+	lines := make([]string, 0, 3*gen.Scale(100, size))
+	for len(lines) < cap(lines) {
+		// Apart from the badge, every elf of the group packs items from a separate third of the item types
+		types := []byte(items)
+		r.Shuffle(len(types), func(i, j int) { types[i], types[j] = types[j], types[i] })
+		badge, types := types[0], types[1:]
+		third := len(types) / 3
+		for elf := 0; elf < 3; elf++ {
+			own := types[elf*third : (elf+1)*third]
+			lines = append(lines, rucksack(r, badge, own))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// rucksack packs a rucksack with the badge and items of own types. Items of one type are only in one compartment,
+// except for the shared one.
+func rucksack(r *rand.Rand, badge byte, own []byte) string {
+	shared := badge
+	if r.Intn(len(own)+1) > 0 {
+		shared = own[r.Intn(len(own))]
+	}
+	first, second := []byte{shared}, []byte{shared}
+	if shared != badge {
+		first = append(first, badge)
+	}
+	// Split the other types between the compartments
+	left, right := make([]byte, 0), make([]byte, 0)
+	for _, item := range own {
+		if item == shared {
+			continue
+		}
+		if len(left) <= len(right) {
+			left = append(left, item)
+		} else {
+			right = append(right, item)
+		}
+	}
+	half := gen.Between(r, 4, 16)
+	for len(first) < half {
+		first = append(first, left[r.Intn(len(left))])
+	}
+	for len(second) < half {
+		second = append(second, right[r.Intn(len(right))])
+	}
+	r.Shuffle(half, func(i, j int) { first[i], first[j] = first[j], first[i] })
+	r.Shuffle(half, func(i, j int) { second[i], second[j] = second[j], second[i] })
+	return string(first) + string(second)
+}
+
+func init() {
+	gen.Register(2022, 3, gen.GeneratorFunc(rucksacks))
+}
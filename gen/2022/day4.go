@@ -0,0 +1,29 @@
+package gen2022
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/rubinda/aoc/gen"
+)
+
+// sectionAssignments lists pairs of elves with the range of sections each of them cleans.
+func sectionAssignments(r *rand.Rand, size float64) string {
+	pairs := make([]string, gen.Scale(1000, size))
+	for i := range pairs {
+		a, b := sections(r), sections(r)
+		pairs[i] = fmt.Sprintf("%d-%d,%d-%d", a[0], a[1], b[0], b[1])
+	}
+	return strings.Join(pairs, "\n")
+}
+
+// sections returns a random range of sections.
+func sections(r *rand.Rand) [2]int {
+	from := gen.Between(r, 1, 99)
+	return [2]int{from, gen.Between(r, from, 99)}
+}
+
+func init() {
+	gen.Register(2022, 4, gen.GeneratorFunc(sectionAssignments))
+}
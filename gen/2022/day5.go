@@ -0,0 +1,60 @@
+package gen2022
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/rubinda/aoc/gen"
+)
+
+// crateStacks draws 9 stacks of crates followed by moves of the crane. Moves never empty a stack,
+// so every stack has a crate on top in the end.
+func crateStacks(r *rand.Rand, size float64) string {
+	stacks := make([][]byte, 9)
+	highest := 0
+	for i := range stacks {
+		stacks[i] = make([]byte, gen.Between(r, 1, 8))
+		for j := range stacks[i] {
+			stacks[i][j] = byte('A' + r.Intn(26))
+		}
+		if len(stacks[i]) > highest {
+			highest = len(stacks[i])
+		}
+	}
+
+	lines := make([]string, 0)
+	for level := highest - 1; level >= 0; level-- {
+		crates := make([]string, len(stacks))
+		for i, stack := range stacks {
+			crates[i] = "   "
+			if level < len(stack) {
+				crates[i] = "[" + string(stack[level]) + "]"
+			}
+		}
+		lines = append(lines, strings.Join(crates, " "))
+	}
+	numbers := make([]string, len(stacks))
+	for i := range stacks {
+		numbers[i] = fmt.Sprintf(" %d ", i+1)
+	}
+	lines = append(lines, strings.Join(numbers, " "), "")
+
+	for moves := gen.Scale(500, size); moves > 0; moves-- {
+		from := r.Intn(len(stacks))
+		for len(stacks[from]) < 2 {
+			from = r.Intn(len(stacks))
+		}
+		to := (from + gen.Between(r, 1, len(stacks)-1)) % len(stacks)
+		n := gen.Between(r, 1, len(stacks[from])-1)
+		moved := stacks[from][len(stacks[from])-n:]
+		stacks[to] = append(stacks[to], moved...)
+		stacks[from] = stacks[from][:len(stacks[from])-n]
+		lines = append(lines, fmt.Sprintf("move %d from %d to %d", n, from+1, to+1))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func init() {
+	gen.Register(2022, 5, gen.GeneratorFunc(crateStacks))
+}
@@ -0,0 +1,54 @@
+package gen2022
+
+import (
+	"math/rand"
+
+	"github.com/rubinda/aoc/gen"
+)
+
+// datastream returns a signal with a start-of-packet marker (4 different characters) somewhere in the first
+// quarter and the first start-of-message marker (14 different characters) in the last quarter.
+func datastream(r *rand.Rand, size float64) string {
+	length := gen.Clamp(gen.Scale(4096, size), 64, 1<<30)
+	packet := gen.Between(r, 4, length/4)
+	// The solver doesn't look at a marker ending with the last character
+	message := gen.Between(r, 3*length/4, length-15)
+	signal := make([]byte, 0, length)
+	// Before the start-of-packet marker the signal only uses 3 characters
+	for len(signal) < packet-1 {
+		signal = append(signal, lowercase[r.Intn(3)])
+	}
+	for len(signal) < message {
+		c := lowercase[r.Intn(len(lowercase))]
+		if distinctTail(append(signal, c), 14) {
+			c = signal[len(signal)-1]
+		}
+		signal = append(signal, c)
+	}
+	for _, i := range r.Perm(len(lowercase))[:14] {
+		signal = append(signal, lowercase[i])
+	}
+	for len(signal) < length {
+		signal = append(signal, lowercase[r.Intn(len(lowercase))])
+	}
+	return string(signal)
+}
+
+// distinctTail returns true if the last n characters of the signal are all different.
+func distinctTail(signal []byte, n int) bool {
+	if len(signal) < n {
+		return false
+	}
+	seen := make(map[byte]bool, n)
+	for _, c := range signal[len(signal)-n:] {
+		if seen[c] {
+			return false
+		}
+		seen[c] = true
+	}
+	return true
+}
+
+func init() {
+	gen.Register(2022, 6, gen.GeneratorFunc(datastream))
+}
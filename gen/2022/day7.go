@@ -0,0 +1,71 @@
+package gen2022
+
+import (
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/rubinda/aoc/gen"
+)
+
+// directory is a directory of the generated file system.
+type directory struct {
+	name    string
+	subdirs []*directory
+	files   []string
+}
+
+// terminalOutput browses a random file system with cd and ls. The files take up 42-50M of the 70M disk,
+// so some have to be deleted to make room for the 30M update.
+func terminalOutput(r *rand.Rand, size float64) string {
+	dirs := []*directory{{name: "/"}}
+	names := gen.Names(r, gen.Scale(200, size), 3, lowercase)
+	for _, name := range names {
+		parent := dirs[r.Intn(len(dirs))]
+		dir := &directory{name: name}
+		parent.subdirs = append(parent.subdirs, dir)
+		dirs = append(dirs, dir)
+	}
+	// File sizes vary a lot, scale them so they add up to the wanted total
+	weights := make([]float64, gen.Scale(300, size))
+	total := 0.0
+	for i := range weights {
+		weights[i] = math.Exp(8 * r.Float64())
+		total += weights[i]
+	}
+	used := float64(gen.Between(r, 42_000_000, 50_000_000))
+	for i, name := range gen.Names(r, len(weights), 4, lowercase) {
+		if r.Intn(2) == 0 {
+			name += "." + gen.Names(r, 1, 3, lowercase)[0]
+		}
+		fileSize := int(math.Max(1, weights[i]*used/total))
+		dir := dirs[r.Intn(len(dirs))]
+		dir.files = append(dir.files, strconv.Itoa(fileSize)+" "+name)
+	}
+
+	lines := []string{"$ cd /"}
+	var browse func(dir *directory)
+	browse = func(dir *directory) {
+		lines = append(lines, "$ ls")
+		for _, sub := range dir.subdirs {
+			lines = append(lines, "dir "+sub.name)
+		}
+		lines = append(lines, dir.files...)
+		for _, sub := range dir.subdirs {
+			lines = append(lines, "$ cd "+sub.name)
+			browse(sub)
+			lines = append(lines, "$ cd ..")
+		}
+	}
+	browse(dirs[0])
+	// There's no need to go back up after the last listing
+	for lines[len(lines)-1] == "$ cd .." {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func init() {
+	gen.Register(2022, 7, gen.GeneratorFunc(terminalOutput))
+}
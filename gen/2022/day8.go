@@ -0,0 +1,26 @@
+package gen2022
+
+import (
+	"math/rand"
+	"strings"
+
+	"github.com/rubinda/aoc/gen"
+)
+
+// treeHeights returns a square map of tree heights (0-9).
+func treeHeights(r *rand.Rand, size float64) string {
+	side := gen.Side(99, size)
+	rows := make([]string, side)
+	row := make([]byte, side)
+	for y := range rows {
+		for x := range row {
+			row[x] = byte('0' + r.Intn(10))
+		}
+		rows[y] = string(row)
+	}
+	return strings.Join(rows, "\n")
+}
+
+func init() {
+	gen.Register(2022, 8, gen.GeneratorFunc(treeHeights))
+}
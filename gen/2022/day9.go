@@ -0,0 +1,22 @@
+package gen2022
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/rubinda/aoc/gen"
+)
+
+// headMotions lists the moves of the rope's head, a direction and the number of steps.
+func headMotions(r *rand.Rand, size float64) string {
+	moves := make([]string, gen.Scale(2000, size))
+	for i := range moves {
+		moves[i] = fmt.Sprintf("%c %d", "UDLR"[r.Intn(4)], gen.Between(r, 1, 20))
+	}
+	return strings.Join(moves, "\n")
+}
+
+func init() {
+	gen.Register(2022, 9, gen.GeneratorFunc(headMotions))
+}
@@ -0,0 +1,5 @@
+// Package gen2022 registers the input generators of Advent of Code 2022 when imported.
+package gen2022
+
+// lowercase are the letters of names in the puzzle inputs.
+const lowercase = "abcdefghijklmnopqrstuvwxyz"
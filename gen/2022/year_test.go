@@ -0,0 +1,45 @@
+package gen2022
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rubinda/aoc"
+	_ "github.com/rubinda/aoc/2022"
+	"github.com/rubinda/aoc/gen"
+)
+
+// testSize keeps the generated inputs small enough for every solver to finish quickly.
+const testSize = 0.1
+
+// TestGenerators solves every part on a generated input, which has to be valid and have an answer.
+func TestGenerators(t *testing.T) {
+	for day := 1; day <= 25; day++ {
+		day := day
+		t.Run(fmt.Sprint(day), func(t *testing.T) {
+			t.Parallel()
+			puzzle, err := aoc.Lookup(2022, day)
+			if err != nil {
+				t.Fatal(err)
+			}
+			input, err := gen.Generate(2022, day, testSize, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if again, _ := gen.Generate(2022, day, testSize, 1); again != input {
+				t.Errorf("Same seed, different inputs")
+			}
+			for part := 1; part <= puzzle.Parts; part++ {
+				answer, err := puzzle.Solve(context.Background(), part, strings.NewReader(input))
+				if err != nil {
+					t.Fatalf("Part %d: %v\n%s", part, err, input)
+				}
+				if fmt.Sprint(answer) == "-1" {
+					t.Errorf("Part %d has no answer", part)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+// Package gen holds the registry of puzzle input generators.
+// A generator produces random inputs that satisfy the constraints of a day's puzzle, so the solvers can be
+// stress tested on inputs many times bigger than the personal ones. Each year's generators register on init.
+package gen
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+
+	"github.com/rubinda/aoc"
+)
+
+// ErrNotFound is returned when no generator is registered for the requested year and day.
+var ErrNotFound = errors.New("generator not found")
+
+// Generator produces random puzzle inputs.
+type Generator interface {
+	// Generate returns an input of given size, where size 1 is about as big as a personal puzzle input.
+	// Generators scale the input as far as the puzzle allows, some (e.g. a program for a fixed display) can't.
+	Generate(r *rand.Rand, size float64) string
+}
+
+// GeneratorFunc allows the use of ordinary functions as generators.
+type GeneratorFunc func(r *rand.Rand, size float64) string
+
+// Generate calls f(r, size).
+func (f GeneratorFunc) Generate(r *rand.Rand, size float64) string {
+	return f(r, size)
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[aoc.Key]Generator)
+)
+
+// Register makes a generator available for given year and day.
+// Register panics if called twice for the same year and day or if the generator is nil.
+func Register(year, day int, g Generator) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	key := aoc.Key{Year: year, Day: day}
+	if g == nil {
+		panic(fmt.Sprintf("gen: Register generator is nil for %v", key))
+	}
+	if _, dup := registry[key]; dup {
+		panic(fmt.Sprintf("gen: Register called twice for %v", key))
+	}
+	registry[key] = g
+}
+
+// Lookup returns the generator registered for given year and day.
+func Lookup(year, day int) (Generator, error) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	key := aoc.Key{Year: year, Day: day}
+	g, ok := registry[key]
+	if !ok {
+		return nil, fmt.Errorf("%v: %w", key, ErrNotFound)
+	}
+	return g, nil
+}
+
+// Keys returns the years and days with a registered generator, sorted.
+func Keys() []aoc.Key {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	keys := make([]aoc.Key, 0, len(registry))
+	for key := range registry {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Year != keys[j].Year {
+			return keys[i].Year < keys[j].Year
+		}
+		return keys[i].Day < keys[j].Day
+	})
+	return keys
+}
+
+// Generate returns an input of given size for the puzzle of given year and day.
+// The same seed always results in the same input.
+func Generate(year, day int, size float64, seed int64) (string, error) {
+	if !(size > 0) || math.IsInf(size, 1) {
+		return "", fmt.Errorf("size has to be a positive number, got %v", size)
+	}
+	g, err := Lookup(year, day)
+	if err != nil {
+		return "", err
+	}
+	return g.Generate(rand.New(rand.NewSource(seed)), size), nil
+}
+
+// Scale returns n scaled by size (rounded), but at least 1.
+func Scale(n int, size float64) int {
+	return int(math.Max(1, math.Round(float64(n)*size)))
+}
+
+// Side scales the side n of a square, so that its area scales by size.
+func Side(n int, size float64) int {
+	return Scale(n, math.Sqrt(size))
+}
+
+// Clamp returns n limited to the range from min to max.
+func Clamp(n, min, max int) int {
+	if n < min {
+		return min
+	}
+	if n > max {
+		return max
+	}
+	return n
+}
+
+// Between returns a random number from min to max (both included).
+func Between(r *rand.Rand, min, max int) int {
+	return min + r.Intn(max-min+1)
+}
+
+// Names returns n distinct random names of given length made of the letters in alphabet, none of them in exclude.
+// Names get longer when there aren't enough of given length.
+func Names(r *rand.Rand, n, length int, alphabet string, exclude ...string) []string {
+	for math.Pow(float64(len(alphabet)), float64(length)) < float64(2*(n+len(exclude))) {
+		length++
+	}
+	taken := make(map[string]bool, n+len(exclude))
+	for _, name := range exclude {
+		taken[name] = true
+	}
+	names := make([]string, 0, n)
+	name := make([]byte, length)
+	for len(names) < n {
+		for i := range name {
+			name[i] = alphabet[r.Intn(len(alphabet))]
+		}
+		if !taken[string(name)] {
+			taken[string(name)] = true
+			names = append(names, string(name))
+		}
+	}
+	return names
+}
@@ -0,0 +1,78 @@
+package gen
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// Registry tests use year 1 so they don't collide with real generators.
+const testYear = 1
+
+func init() {
+	Register(testYear, 2, GeneratorFunc(func(r *rand.Rand, size float64) string {
+		return strings.Repeat("x", Scale(10, size))
+	}))
+	Register(testYear, 1, GeneratorFunc(func(r *rand.Rand, size float64) string {
+		return strings.Join(Names(r, Scale(5, size), 2, "ab"), ",")
+	}))
+}
+
+func TestGenerate(t *testing.T) {
+	actual, err := Generate(testYear, 2, 0.5, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "xxxxx"; actual != expected {
+		t.Errorf("Wrong result! Expected: %v, actual: %v", expected, actual)
+	}
+
+	if _, err := Generate(testYear, 3, 1, 1); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Wrong error! Expected: %v, actual: %v", ErrNotFound, err)
+	}
+	for _, size := range []float64{0, -1} {
+		if _, err := Generate(testYear, 2, size, 1); err == nil {
+			t.Errorf("Expected an error for size %v", size)
+		}
+	}
+}
+
+func TestGenerateSeed(t *testing.T) {
+	first, _ := Generate(testYear, 1, 1, 42)
+	second, _ := Generate(testYear, 1, 1, 42)
+	if first != second {
+		t.Errorf("Same seed, different inputs: %q and %q", first, second)
+	}
+}
+
+func TestNames(t *testing.T) {
+	// Only 4 names of length 2 exist, they have to get longer
+	names := Names(rand.New(rand.NewSource(1)), 10, 2, "ab", "aaa")
+	seen := make(map[string]bool)
+	for _, name := range names {
+		if seen[name] || name == "aaa" || len(name) < 2 {
+			t.Errorf("Invalid name %q in %v", name, names)
+		}
+		seen[name] = true
+	}
+	if len(names) != 10 {
+		t.Errorf("Wrong result! Expected: %v, actual: %v", 10, len(names))
+	}
+}
+
+func TestKeysSorted(t *testing.T) {
+	keys := Keys()
+	if len(keys) != 2 || keys[0].Day != 1 || keys[1].Day != 2 {
+		t.Errorf("Wrong order! Actual: %v", keys)
+	}
+}
+
+func TestRegisterTwice(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected Register to panic on duplicate key")
+		}
+	}()
+	Register(testYear, 1, GeneratorFunc(nil))
+}
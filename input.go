@@ -0,0 +1,49 @@
+package aoc
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ErrNoInput is returned when no puzzle input file exists for a day.
+var ErrNoInput = errors.New("no puzzle input found")
+
+// InputFiles are the file names searched for in a day's directory, in order of preference.
+var InputFiles = []string{"input.txt", "challenge.in"}
+
+// InputDir returns the directory holding the files of given year and day (e.g. root/2022/14).
+func InputDir(root string, year, day int) string {
+	return filepath.Join(root, strconv.Itoa(year), strconv.Itoa(day))
+}
+
+// FindInput returns the path of the first existing InputFiles entry in the directory of given year and day.
+func FindInput(root string, year, day int) (string, error) {
+	dir := InputDir(root, year, day)
+	for _, name := range InputFiles {
+		path := filepath.Join(dir, name)
+		info, err := os.Stat(path)
+		if err == nil && !info.IsDir() {
+			return path, nil
+		}
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+	}
+	return "", fmt.Errorf("%w in %s (looked for %s)", ErrNoInput, dir, strings.Join(InputFiles, ", "))
+}
+
+// ReadInput reads the whole puzzle input. Windows line endings are converted and the trailing newline
+// is removed, so downloaded inputs look the same as the example.in files.
+func ReadInput(r io.Reader) (string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("reading input: %w", err)
+	}
+	input := strings.ReplaceAll(string(data), "\r\n", "\n")
+	return strings.TrimRight(input, "\n"), nil
+}
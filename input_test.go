@@ -0,0 +1,51 @@
+package aoc
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadInput(t *testing.T) {
+	tests := map[string]string{
+		"1\n2\n3":         "1\n2\n3",
+		"1\n2\n3\n":       "1\n2\n3",
+		"1\r\n2\r\n3\r\n": "1\n2\n3",
+		"    ...#\n\n":    "    ...#",
+	}
+	for in, expected := range tests {
+		actual, err := ReadInput(strings.NewReader(in))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual != expected {
+			t.Errorf("Wrong result! Expected: %q, actual: %q", expected, actual)
+		}
+	}
+}
+
+func TestFindInput(t *testing.T) {
+	root := t.TempDir()
+	if _, err := FindInput(root, 2022, 14); !errors.Is(err, ErrNoInput) {
+		t.Errorf("Wrong error! Expected: %v, actual: %v", ErrNoInput, err)
+	}
+
+	dir := InputDir(root, 2022, 14)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"challenge.in", "input.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		actual, err := FindInput(root, 2022, 14)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := filepath.Join(dir, name); actual != expected {
+			t.Errorf("Wrong result! Expected: %v, actual: %v", expected, actual)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+// Package aoctest helps testing the days: it runs a day's registered solver on the examples in its examples.json,
+// compares it with the day's other implementations and guards the fuzz targets of the parsers against panics and hangs.
+package aoctest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rubinda/aoc"
+)
+
+// Examples runs a subtest for every example and answered part in the examples file of the tested package
+// and compares the answer of the registered solver with the expected one.
+func Examples(t *testing.T, year, day int) {
+	t.Helper()
+	puzzle, err := aoc.Lookup(year, day)
+	if err != nil {
+		t.Fatal(err)
+	}
+	examples, err := aoc.LoadExamples(aoc.ExamplesFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(examples) == 0 {
+		t.Fatalf("No examples in %s", aoc.ExamplesFile)
+	}
+
+	for _, e := range examples {
+		input, err := e.Text(".")
+		if err != nil {
+			t.Fatal(err)
+		}
+		for part := 1; part <= puzzle.Parts; part++ {
+			expected, ok := e.Answers[part]
+			if !ok {
+				continue
+			}
+			t.Run(fmt.Sprintf("%s/part%d", e.Name, part), func(t *testing.T) {
+				answer, err := puzzle.Solve(context.Background(), part, strings.NewReader(input))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if actual := aoc.FormatAnswer(answer); actual != expected {
+					t.Errorf("Wrong result! Expected: %v, actual: %v", expected, actual)
+				}
+			})
+		}
+	}
+}
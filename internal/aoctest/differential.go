@@ -0,0 +1,72 @@
+package aoctest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rubinda/aoc"
+	"github.com/rubinda/aoc/gen"
+)
+
+// Differential compares every other implementation of a day with its registered solver. Each part is solved
+// on the examples and on inputs generated at given sizes with seeds 1 to seeds, a subtest per implementation and
+// input fails if the answers (or whether solving fails) differ. Generated inputs are valid, so the registered solver
+// failing on one fails the test too. The day's generators have to be registered.
+func Differential(t *testing.T, year, day, seeds int, sizes ...float64) {
+	t.Helper()
+	puzzle, err := aoc.Lookup(year, day)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(puzzle.Implementations) == 0 {
+		t.Fatalf("%v has no other implementations", puzzle.Key())
+	}
+
+	type namedInput struct {
+		name, text string
+		generated  bool
+	}
+	inputs := make([]namedInput, 0, puzzle.Parts+seeds*len(sizes))
+	for part := 1; part <= puzzle.Parts; part++ {
+		inputs = append(inputs, namedInput{fmt.Sprintf("example%d", part), puzzle.ExampleInput(part), false})
+	}
+	for _, size := range sizes {
+		for seed := 1; seed <= seeds; seed++ {
+			input, err := gen.Generate(year, day, size, int64(seed))
+			if err != nil {
+				t.Fatal(err)
+			}
+			inputs = append(inputs, namedInput{fmt.Sprintf("size%v/seed%d", size, seed), input, true})
+		}
+	}
+
+	for _, name := range puzzle.ImplementationNames() {
+		other, _ := puzzle.Implementation(name)
+		for _, input := range inputs {
+			t.Run(name+"/"+input.name, func(t *testing.T) {
+				for part := 1; part <= puzzle.Parts; part++ {
+					expected, expectedErr := solveText(puzzle, part, input.text)
+					if input.generated && expectedErr != nil {
+						t.Fatalf("Part %d fails on a generated input: %v", part, expectedErr)
+					}
+					actual, err := solveText(other, part, input.text)
+					if actual != expected || (err == nil) != (expectedErr == nil) {
+						t.Errorf("Part %d disagrees! Expected: %v (error %v), actual: %v (error %v)",
+							part, expected, expectedErr, actual, err)
+					}
+				}
+			})
+		}
+	}
+}
+
+// solveText solves a part of the puzzle and returns the answer as the runner prints it.
+func solveText(puzzle aoc.Puzzle, part int, input string) (string, error) {
+	answer, err := puzzle.Solve(context.Background(), part, strings.NewReader(input))
+	if err != nil {
+		return "", err
+	}
+	return aoc.FormatAnswer(answer), nil
+}
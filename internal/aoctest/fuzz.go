@@ -0,0 +1,43 @@
+package aoctest
+
+import (
+	"runtime/debug"
+	"testing"
+	"time"
+)
+
+// ParseTimeout is the longest a parser may take on a fuzzed input before it is considered to hang.
+// Inputs are small, every parser is at most quadratic in their size.
+var ParseTimeout = 2 * time.Second
+
+// parsePanic is the error of a parser that panicked.
+type parsePanic struct {
+	value any
+	stack []byte
+}
+
+// Parses runs parse and fails the test if it panics or doesn't return within ParseTimeout.
+// Otherwise it returns the error of parse, a nil error means the caller should check the parsed structure.
+func Parses(t *testing.T, parse func() error) error {
+	t.Helper()
+	done := make(chan any, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- parsePanic{r, debug.Stack()}
+			}
+		}()
+		done <- parse()
+	}()
+	select {
+	case result := <-done:
+		if p, ok := result.(parsePanic); ok {
+			t.Fatalf("Parser panicked: %v\n%s", p.value, p.stack)
+		}
+		err, _ := result.(error)
+		return err
+	case <-time.After(ParseTimeout):
+		t.Fatalf("Parser didn't return within %v", ParseTimeout)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+// Package graph implements shortest path searches (BFS, Dijkstra, A*) over graphs with comparable nodes.
+// Graphs can be stored explicitly (see Adjacency) or defined implicitly by a function listing the neighbours
+// of a node, which allows searching state spaces that are too big (or infinite) to build up front.
+package graph
+
+// Edge is a weighted connection to a neighbouring node.
+type Edge[N comparable] struct {
+	To     N
+	Weight int
+}
+
+// Graph lists the nodes reachable in one step from a node. Every step costs 1.
+type Graph[N comparable] interface {
+	Neighbours(n N) []N
+}
+
+// WeightedGraph lists the weighted edges leaving a node. Weights must not be negative.
+type WeightedGraph[N comparable] interface {
+	Edges(n N) []Edge[N]
+}
+
+// Visited is the set of nodes a search reached.
+type Visited[N comparable] interface {
+	// Visit adds n to the set, it returns false if n already was in it.
+	Visit(n N) bool
+}
+
+// Tracker is a graph that keeps the reached nodes of a search in its own set, e.g. a bit per node if the nodes can be
+// numbered, which is cheaper than the map searches use otherwise.
+type Tracker[N comparable] interface {
+	// NewVisited returns an empty set for a search.
+	NewVisited() Visited[N]
+}
+
+// visitedMap is the set of reached nodes of graphs that aren't a Tracker.
+type visitedMap[N comparable] map[N]struct{}
+
+// Visit adds n to the set.
+func (v visitedMap[N]) Visit(n N) bool {
+	if _, ok := v[n]; ok {
+		return false
+	}
+	v[n] = struct{}{}
+	return true
+}
+
+// newVisited returns the set of reached nodes for a search of g.
+func newVisited[N comparable](g Graph[N]) Visited[N] {
+	if t, ok := any(g).(Tracker[N]); ok {
+		return t.NewVisited()
+	}
+	return make(visitedMap[N])
+}
+
+// Unweighted is an implicit graph defined by a function returning the neighbours of a node.
+type Unweighted[N comparable] func(n N) []N
+
+// Neighbours returns f(n).
+func (f Unweighted[N]) Neighbours(n N) []N {
+	return f(n)
+}
+
+// Edges returns the neighbours of n as edges with weight 1.
+func (f Unweighted[N]) Edges(n N) []Edge[N] {
+	neighbours := f(n)
+	edges := make([]Edge[N], len(neighbours))
+	for i, to := range neighbours {
+		edges[i] = Edge[N]{to, 1}
+	}
+	return edges
+}
+
+// Weighted is an implicit graph defined by a function returning the edges leaving a node.
+type Weighted[N comparable] func(n N) []Edge[N]
+
+// Edges returns f(n).
+func (f Weighted[N]) Edges(n N) []Edge[N] {
+	return f(n)
+}
+
+// Adjacency is an explicitly stored directed graph.
+type Adjacency[N comparable] struct {
+	nodes []N
+	edges map[N][]Edge[N]
+}
+
+// NewAdjacency returns an empty graph.
+func NewAdjacency[N comparable]() *Adjacency[N] {
+	return &Adjacency[N]{edges: make(map[N][]Edge[N])}
+}
+
+// AddNode adds a node to the graph unless it was already added.
+func (a *Adjacency[N]) AddNode(n N) {
+	if _, ok := a.edges[n]; ok {
+		return
+	}
+	a.nodes = append(a.nodes, n)
+	a.edges[n] = nil
+}
+
+// AddEdge adds a directed edge between two nodes, missing nodes are added to the graph.
+func (a *Adjacency[N]) AddEdge(from, to N, weight int) {
+	a.AddNode(from)
+	a.AddNode(to)
+	a.edges[from] = append(a.edges[from], Edge[N]{to, weight})
+}
+
+// Nodes returns all nodes in the order they were added.
+func (a *Adjacency[N]) Nodes() []N {
+	return a.nodes
+}
+
+// Edges returns the edges leaving n.
+func (a *Adjacency[N]) Edges(n N) []Edge[N] {
+	return a.edges[n]
+}
+
+// Neighbours returns the nodes reachable from n in one step (ignoring weights).
+func (a *Adjacency[N]) Neighbours(n N) []N {
+	edges := a.edges[n]
+	neighbours := make([]N, len(edges))
+	for i, e := range edges {
+		neighbours[i] = e.To
+	}
+	return neighbours
+}
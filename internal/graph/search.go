@@ -0,0 +1,193 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/rubinda/aoc/internal/pqueue"
+)
+
+// Heuristic estimates the remaining distance from a node to the goal. A* only finds shortest paths if
+// the heuristic never overestimates.
+type Heuristic[N comparable] func(n N) int
+
+// Result holds the outcome of a search.
+type Result[N comparable] struct {
+	// Goal is the first goal node the search reached (only valid if Found).
+	Goal N
+	// Found is true if the search reached a goal.
+	Found bool
+
+	// dist holds the distance from the closest source to every reached node (Dijkstra and A*). If the search
+	// stopped at a goal, nodes that were reached but not expanded yet can have a longer distance than the shortest.
+	dist map[N]int
+	// prev holds the previous node on the shortest path to every reached node (sources have none).
+	prev map[N]N
+	// layers hold the nodes BFS reached at every distance, each with the index of its previous node in the layer
+	// before. They are only indexed by node (in at) when a node other than the goal is looked up.
+	layers [][]step[N]
+	at     map[N]position
+	goalAt position
+}
+
+// step is a node reached by BFS and the index of its previous node in the layer before (-1 for sources).
+type step[N comparable] struct {
+	node N
+	prev int
+}
+
+// position is the layer (distance) and the index in the layer of a node reached by BFS.
+type position struct {
+	layer, index int
+}
+
+// newResult prepares a result with sources at distance 0.
+func newResult[N comparable](sources []N) *Result[N] {
+	r := &Result[N]{dist: make(map[N]int), prev: make(map[N]N)}
+	for _, s := range sources {
+		r.dist[s] = 0
+	}
+	return r
+}
+
+// find returns the position of a node reached by BFS.
+func (r *Result[N]) find(n N) (position, bool) {
+	if r.Found && n == r.Goal {
+		return r.goalAt, true
+	}
+	if r.at == nil {
+		r.at = make(map[N]position)
+		for layer, steps := range r.layers {
+			for index, s := range steps {
+				r.at[s.node] = position{layer, index}
+			}
+		}
+	}
+	p, ok := r.at[n]
+	return p, ok
+}
+
+// Distance returns the distance to n and whether n was reached.
+func (r *Result[N]) Distance(n N) (int, bool) {
+	if r.layers != nil {
+		p, ok := r.find(n)
+		return p.layer, ok
+	}
+	d, ok := r.dist[n]
+	return d, ok
+}
+
+// Reached returns the number of nodes the search reached.
+func (r *Result[N]) Reached() int {
+	if r.layers != nil {
+		reached := 0
+		for _, steps := range r.layers {
+			reached += len(steps)
+		}
+		return reached
+	}
+	return len(r.dist)
+}
+
+// Path returns the nodes on the shortest path from a source to n (both included), nil if n wasn't reached.
+func (r *Result[N]) Path(n N) []N {
+	if r.layers != nil {
+		p, ok := r.find(n)
+		if !ok {
+			return nil
+		}
+		path := make([]N, p.layer+1)
+		for index, layer := p.index, p.layer; layer >= 0; layer-- {
+			s := r.layers[layer][index]
+			path[layer], index = s.node, s.prev
+		}
+		return path
+	}
+	if _, ok := r.dist[n]; !ok {
+		return nil
+	}
+	path := []N{n}
+	for {
+		prev, ok := r.prev[n]
+		if !ok {
+			break
+		}
+		path = append(path, prev)
+		n = prev
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// BFS searches the graph breadth first from all sources at once until a node satisfying goal is reached.
+// A nil goal explores every reachable node. The search stops with ctx's error once ctx is done.
+// The reached nodes are kept in a map, unless the graph brings its own set (see Tracker).
+func BFS[N comparable](ctx context.Context, g Graph[N], goal func(N) bool, sources ...N) (*Result[N], error) {
+	r := &Result[N]{}
+	visited := newVisited(g)
+	layer := make([]step[N], 0, len(sources))
+	for _, s := range sources {
+		if visited.Visit(s) {
+			layer = append(layer, step[N]{s, -1})
+		}
+	}
+	for len(layer) > 0 {
+		if err := ctx.Err(); err != nil {
+			return r, err
+		}
+		r.layers = append(r.layers, layer)
+		next := make([]step[N], 0, len(layer))
+		for i, s := range layer {
+			if goal != nil && goal(s.node) {
+				r.Goal, r.Found, r.goalAt = s.node, true, position{len(r.layers) - 1, i}
+				return r, nil
+			}
+			for _, neighbour := range g.Neighbours(s.node) {
+				if visited.Visit(neighbour) {
+					next = append(next, step[N]{neighbour, i})
+				}
+			}
+		}
+		layer = next
+	}
+	return r, nil
+}
+
+// Dijkstra searches the weighted graph from all sources at once until a node satisfying goal is reached.
+// A nil goal explores every reachable node. The search stops with ctx's error once ctx is done.
+func Dijkstra[N comparable](ctx context.Context, g WeightedGraph[N], goal func(N) bool, sources ...N) (*Result[N], error) {
+	return AStar(ctx, g, func(N) int { return 0 }, goal, sources...)
+}
+
+// AStar searches the weighted graph from all sources at once, expanding nodes closest to the goal
+// according to h first. The search ends when a node satisfying goal is reached, a nil goal explores
+// every reachable node. The search stops with ctx's error once ctx is done.
+func AStar[N comparable](ctx context.Context, g WeightedGraph[N], h Heuristic[N], goal func(N) bool, sources ...N) (*Result[N], error) {
+	r := newResult(sources)
+	pq := pqueue.NewMin[N, int]()
+	for _, s := range sources {
+		pq.Push(s, h(s))
+	}
+	for !pq.IsEmpty() {
+		if err := ctx.Err(); err != nil {
+			return r, err
+		}
+		n, _ := pq.Pop()
+		if goal != nil && goal(n) {
+			r.Goal, r.Found = n, true
+			return r, nil
+		}
+		for _, e := range g.Edges(n) {
+			d := r.dist[n] + e.Weight
+			if known, ok := r.dist[e.To]; ok && known <= d {
+				continue
+			}
+			r.dist[e.To] = d
+			r.prev[e.To] = n
+			// Queues the node again if a shorter path was found after it was expanded (inconsistent heuristic)
+			pq.Push(e.To, d+h(e.To))
+		}
+	}
+	return r, nil
+}
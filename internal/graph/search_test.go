@@ -0,0 +1,166 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rubinda/aoc/internal/grid"
+)
+
+const maze = `S...#....
+.##.#.##.
+.#..#..#.
+.#.###.#.
+.......#G`
+
+// mazeGraph returns the open neighbours of a point in the maze.
+func mazeGraph(m *grid.Grid[string]) Unweighted[grid.Point] {
+	return func(p grid.Point) []grid.Point {
+		open := make([]grid.Point, 0, 4)
+		for _, n := range m.Neighbours(p, grid.Directions4) {
+			if m.Get(n) != "#" {
+				open = append(open, n)
+			}
+		}
+		return open
+	}
+}
+
+func TestSearchesAgree(t *testing.T) {
+	m := grid.ParseStrings(maze)
+	start, goal := grid.Point{X: 0, Y: 0}, grid.Point{X: 8, Y: 4}
+	isGoal := func(p grid.Point) bool { return p == goal }
+	g := mazeGraph(m)
+
+	ctx := context.Background()
+	bfs, _ := BFS[grid.Point](ctx, g, isGoal, start)
+	dijkstra, _ := Dijkstra[grid.Point](ctx, g, isGoal, start)
+	astar, _ := AStar[grid.Point](ctx, g, goal.Manhattan, isGoal, start)
+	const expected = 22
+	for name, r := range map[string]*Result[grid.Point]{"BFS": bfs, "Dijkstra": dijkstra, "A*": astar} {
+		if !r.Found || r.Goal != goal {
+			t.Errorf("%s didn't find the goal", name)
+			continue
+		}
+		if actual, _ := r.Distance(goal); actual != expected {
+			t.Errorf("Wrong %s distance! Expected: %v, actual: %v", name, expected, actual)
+		}
+		path := r.Path(goal)
+		if len(path) != expected+1 || path[0] != start || path[len(path)-1] != goal {
+			t.Errorf("Wrong %s path: %v", name, path)
+		}
+		for i := 1; i < len(path); i++ {
+			if path[i].Manhattan(path[i-1]) != 1 || m.Get(path[i]) == "#" {
+				t.Errorf("Invalid %s step %v -> %v", name, path[i-1], path[i])
+			}
+		}
+	}
+	if astar.Reached() > bfs.Reached() {
+		t.Errorf("A* reached more nodes (%d) than BFS (%d)", astar.Reached(), bfs.Reached())
+	}
+}
+
+func TestMultiSource(t *testing.T) {
+	m := grid.ParseStrings(strings.ReplaceAll(maze, "S", "."))
+	sources := []grid.Point{{X: 0, Y: 0}, {X: 5, Y: 4}}
+	r, _ := BFS[grid.Point](context.Background(), mazeGraph(m), nil, sources...)
+	if d, _ := r.Distance(grid.Point{X: 8, Y: 4}); d != 13 {
+		t.Errorf("Wrong distance from closest source! Expected: %v, actual: %v", 13, d)
+	}
+	if d, _ := r.Distance(grid.Point{X: 2, Y: 4}); d != 3 {
+		t.Errorf("Wrong distance from closest source! Expected: %v, actual: %v", 3, d)
+	}
+	if path := r.Path(grid.Point{X: 0, Y: 3}); !reflect.DeepEqual(path, []grid.Point{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 0, Y: 2}, {X: 0, Y: 3}}) {
+		t.Errorf("Wrong path: %v", path)
+	}
+	if path := r.Path(grid.Point{X: 4, Y: 0}); path != nil {
+		t.Errorf("Expected no path to a wall, got %v", path)
+	}
+}
+
+// countedMaze is the maze graph with its own set of reached points, counting the visits.
+type countedMaze struct {
+	Unweighted[grid.Point]
+	visits *int
+}
+
+// countedVisits is a set of points counting every visit.
+type countedVisits struct {
+	visited visitedMap[grid.Point]
+	visits  *int
+}
+
+func (m countedMaze) NewVisited() Visited[grid.Point] {
+	return countedVisits{make(visitedMap[grid.Point]), m.visits}
+}
+
+func (v countedVisits) Visit(p grid.Point) bool {
+	*v.visits++
+	return v.visited.Visit(p)
+}
+
+func TestTracker(t *testing.T) {
+	m := grid.ParseStrings(maze)
+	goal := grid.Point{X: 8, Y: 4}
+	visits := 0
+	r, _ := BFS[grid.Point](context.Background(), countedMaze{mazeGraph(m), &visits}, func(p grid.Point) bool {
+		return p == goal
+	}, grid.Point{X: 0, Y: 0})
+	if d, _ := r.Distance(goal); !r.Found || d != 22 {
+		t.Errorf("Wrong distance! Expected: %v, actual: %v", 22, d)
+	}
+	if visits < r.Reached() {
+		t.Errorf("The graph's set saw %d visits for %d reached nodes", visits, r.Reached())
+	}
+}
+
+func TestDijkstraWeights(t *testing.T) {
+	g := NewAdjacency[string]()
+	g.AddEdge("a", "b", 7)
+	g.AddEdge("a", "c", 2)
+	g.AddEdge("c", "b", 3)
+	g.AddEdge("b", "d", 1)
+	g.AddEdge("c", "d", 9)
+	g.AddNode("e")
+
+	r, _ := Dijkstra[string](context.Background(), g, nil, "a")
+	expected := map[string]int{"a": 0, "b": 5, "c": 2, "d": 6}
+	if !reflect.DeepEqual(r.dist, expected) {
+		t.Errorf("Wrong distances! Expected: %v, actual: %v", expected, r.dist)
+	}
+	if path := r.Path("d"); !reflect.DeepEqual(path, []string{"a", "c", "b", "d"}) {
+		t.Errorf("Wrong path: %v", path)
+	}
+	if r.Found {
+		t.Error("No goal given, nothing should be found")
+	}
+	if nodes := g.Nodes(); !reflect.DeepEqual(nodes, []string{"a", "b", "c", "d", "e"}) {
+		t.Errorf("Wrong nodes: %v", nodes)
+	}
+
+	weighted := Weighted[int](func(n int) []Edge[int] {
+		// Infinite graph, the goal has to end the search
+		return []Edge[int]{{n + 1, 1}, {n * 2, 1}}
+	})
+	r2, _ := Dijkstra[int](context.Background(), weighted, func(n int) bool { return n == 100 }, 1)
+	if d, _ := r2.Distance(100); !r2.Found || d != 8 {
+		t.Errorf("Wrong distance to 100! Expected: %v, actual: %v", 8, d)
+	}
+}
+
+func TestSearchCancelled(t *testing.T) {
+	// Without a goal the search of an infinite graph only ends with the context
+	infinite := Unweighted[int](func(n int) []int { return []int{n + 1, n * 2} })
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := BFS[int](ctx, infinite, nil, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wrong BFS error! Expected: %v, actual: %v", context.DeadlineExceeded, err)
+	}
+	if _, err := Dijkstra[int](ctx, infinite, nil, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wrong Dijkstra error! Expected: %v, actual: %v", context.DeadlineExceeded, err)
+	}
+}
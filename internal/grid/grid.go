@@ -0,0 +1,222 @@
+package grid
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rubinda/aoc"
+)
+
+// Grid is a 2D map of values. It either stores every point inside fixed bounds (dense, see NewDense)
+// or only the points that were set (sparse, see NewSparse).
+// Points that were never set hold the zero value of T.
+type Grid[T any] struct {
+	bounds Rect
+	dense  []T
+	sparse map[Point]T
+}
+
+// NewDense returns a grid storing all points within bounds. Reading outside of the bounds returns the
+// zero value, setting outside of the bounds panics.
+func NewDense[T any](bounds Rect) *Grid[T] {
+	return &Grid[T]{bounds: bounds, dense: make([]T, bounds.Area())}
+}
+
+// NewSparse returns an unbounded grid storing only the points that were set.
+func NewSparse[T any]() *Grid[T] {
+	return &Grid[T]{sparse: make(map[Point]T)}
+}
+
+// IsSparse returns true if the grid only stores points that were set.
+func (g *Grid[T]) IsSparse() bool {
+	return g.sparse != nil
+}
+
+// index returns the position of p in dense storage.
+func (g *Grid[T]) index(p Point) int {
+	return (p.Y-g.bounds.Min.Y)*g.bounds.Width() + p.X - g.bounds.Min.X
+}
+
+// Bounds returns the rectangle of a dense grid or the bounding box of all set points in a sparse grid.
+func (g *Grid[T]) Bounds() Rect {
+	if !g.IsSparse() {
+		return g.bounds
+	}
+	r := BoundingBox()
+	for p := range g.sparse {
+		r = r.Extend(p)
+	}
+	return r
+}
+
+// InBounds returns true if the point can be stored in the grid. A sparse grid is unbounded.
+func (g *Grid[T]) InBounds(p Point) bool {
+	return g.IsSparse() || g.bounds.Contains(p)
+}
+
+// Lookup returns the value at p and whether the point is stored in the grid.
+func (g *Grid[T]) Lookup(p Point) (T, bool) {
+	if g.IsSparse() {
+		v, ok := g.sparse[p]
+		return v, ok
+	}
+	if !g.bounds.Contains(p) {
+		var zero T
+		return zero, false
+	}
+	return g.dense[g.index(p)], true
+}
+
+// Get returns the value at p (the zero value if the point was never set or is out of bounds).
+func (g *Grid[T]) Get(p Point) T {
+	v, _ := g.Lookup(p)
+	return v
+}
+
+// Set stores the value at p. Setting a point outside a dense grid's bounds panics.
+func (g *Grid[T]) Set(p Point, v T) {
+	if g.IsSparse() {
+		g.sparse[p] = v
+		return
+	}
+	if !g.bounds.Contains(p) {
+		panic("grid: Set point out of bounds")
+	}
+	g.dense[g.index(p)] = v
+}
+
+// Fill sets every point of a dense grid (or every stored point of a sparse grid) to v.
+func (g *Grid[T]) Fill(v T) {
+	if g.IsSparse() {
+		for p := range g.sparse {
+			g.sparse[p] = v
+		}
+		return
+	}
+	for i := range g.dense {
+		g.dense[i] = v
+	}
+}
+
+// Delete removes the point from a sparse grid or resets it to the zero value in a dense grid.
+func (g *Grid[T]) Delete(p Point) {
+	if g.IsSparse() {
+		delete(g.sparse, p)
+		return
+	}
+	if g.bounds.Contains(p) {
+		var zero T
+		g.dense[g.index(p)] = zero
+	}
+}
+
+// Len returns the number of stored points.
+func (g *Grid[T]) Len() int {
+	if g.IsSparse() {
+		return len(g.sparse)
+	}
+	return len(g.dense)
+}
+
+// Points returns the stored points in reading order (row by row, left to right).
+func (g *Grid[T]) Points() []Point {
+	points := make([]Point, 0, g.Len())
+	if g.IsSparse() {
+		for p := range g.sparse {
+			points = append(points, p)
+		}
+		sort.Slice(points, func(i, j int) bool {
+			if points[i].Y != points[j].Y {
+				return points[i].Y < points[j].Y
+			}
+			return points[i].X < points[j].X
+		})
+		return points
+	}
+	for y := g.bounds.Min.Y; y <= g.bounds.Max.Y; y++ {
+		for x := g.bounds.Min.X; x <= g.bounds.Max.X; x++ {
+			points = append(points, Point{x, y})
+		}
+	}
+	return points
+}
+
+// Each calls fn for every stored point in reading order.
+func (g *Grid[T]) Each(fn func(p Point, v T)) {
+	for _, p := range g.Points() {
+		fn(p, g.Get(p))
+	}
+}
+
+// Neighbours returns the points next to p in given directions that lie within the grid's bounds.
+func (g *Grid[T]) Neighbours(p Point, directions []Point) []Point {
+	n := make([]Point, 0, len(directions))
+	for _, d := range directions {
+		if next := p.Add(d); g.InBounds(next) {
+			n = append(n, next)
+		}
+	}
+	return n
+}
+
+// Clone returns a copy of the grid.
+func (g *Grid[T]) Clone() *Grid[T] {
+	if g.IsSparse() {
+		c := NewSparse[T]()
+		for p, v := range g.sparse {
+			c.sparse[p] = v
+		}
+		return c
+	}
+	c := &Grid[T]{bounds: g.bounds, dense: make([]T, len(g.dense))}
+	copy(c.dense, g.dense)
+	return c
+}
+
+// Render draws the grid within its bounds row by row, cell returns the text for a single point.
+func (g *Grid[T]) Render(cell func(p Point, v T) string) string {
+	bounds := g.Bounds()
+	var sb strings.Builder
+	for y := bounds.Min.Y; y <= bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x <= bounds.Max.X; x++ {
+			p := Point{x, y}
+			sb.WriteString(cell(p, g.Get(p)))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// Parse converts a character map (one row per line) into a dense grid with the upper left character at (0,0).
+// The grid is as wide as the longest line, missing characters of shorter lines hold the zero value.
+// Conversion errors are returned as an aoc.ParseError for the offending line.
+func Parse[T any](text string, convert func(p Point, r rune) (T, error)) (*Grid[T], error) {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	width := 0
+	for _, line := range lines {
+		if n := len([]rune(line)); n > width {
+			width = n
+		}
+	}
+	g := NewDense[T](NewRect(width, len(lines)))
+	for y, line := range lines {
+		for x, r := range []rune(line) {
+			p := Point{x, y}
+			v, err := convert(p, r)
+			if err != nil {
+				return nil, aoc.LineError(y, line, fmt.Errorf("column %d: %w", x+1, err))
+			}
+			g.Set(p, v)
+		}
+	}
+	return g, nil
+}
+
+// ParseStrings converts a character map into a dense grid holding every character as a string.
+func ParseStrings(text string) *Grid[string] {
+	g, _ := Parse(text, func(_ Point, r rune) (string, error) {
+		return string(r), nil
+	})
+	return g
+}
@@ -0,0 +1,135 @@
+package grid
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/rubinda/aoc"
+)
+
+func TestPointDistances(t *testing.T) {
+	p, q := Point{1, 2}, Point{-3, 4}
+	if d := p.Manhattan(q); d != 6 {
+		t.Errorf("Wrong Manhattan distance! Expected: %v, actual: %v", 6, d)
+	}
+	if d := p.Chebyshev(q); d != 4 {
+		t.Errorf("Wrong Chebyshev distance! Expected: %v, actual: %v", 4, d)
+	}
+}
+
+func TestTurning(t *testing.T) {
+	for i, d := range Directions4 {
+		right := Directions4[(i+1)%len(Directions4)]
+		if actual := d.TurnRight(); actual != right {
+			t.Errorf("Wrong right turn from %v! Expected: %v, actual: %v", d, right, actual)
+		}
+		if actual := right.TurnLeft(); actual != d {
+			t.Errorf("Wrong left turn from %v! Expected: %v, actual: %v", right, d, actual)
+		}
+		opposite := Directions4[(i+2)%len(Directions4)]
+		if actual := d.Opposite(); actual != opposite {
+			t.Errorf("Wrong opposite of %v! Expected: %v, actual: %v", d, opposite, actual)
+		}
+	}
+}
+
+func TestBoundingBox(t *testing.T) {
+	r := BoundingBox(Point{2, 3}, Point{-1, 5}, Point{0, 0})
+	expected := Rect{Point{-1, 0}, Point{2, 5}}
+	if r != expected {
+		t.Errorf("Wrong bounding box! Expected: %v, actual: %v", expected, r)
+	}
+	if r.Width() != 4 || r.Height() != 6 || r.Area() != 24 {
+		t.Errorf("Wrong size of %v: %dx%d", r, r.Width(), r.Height())
+	}
+	if !r.Contains(Point{-1, 5}) || r.Contains(Point{3, 0}) {
+		t.Errorf("Wrong containment for %v", r)
+	}
+	if empty := BoundingBox(); !empty.Empty() || empty.Extend(Point{7, 7}) != (Rect{Point{7, 7}, Point{7, 7}}) {
+		t.Errorf("Wrong empty bounding box: %v", empty)
+	}
+}
+
+func TestDense(t *testing.T) {
+	g := NewDense[int](Rect{Point{-2, -1}, Point{2, 1}})
+	g.Set(Point{-2, -1}, 1)
+	g.Set(Point{2, 1}, 2)
+	if g.Get(Point{-2, -1}) != 1 || g.Get(Point{2, 1}) != 2 || g.Get(Point{0, 0}) != 0 {
+		t.Errorf("Wrong values stored: %v", g.dense)
+	}
+	if _, ok := g.Lookup(Point{3, 0}); ok {
+		t.Error("Expected point out of bounds")
+	}
+	if n := g.Neighbours(Point{2, 1}, Directions8); len(n) != 3 {
+		t.Errorf("Wrong neighbours of the corner: %v", n)
+	}
+	if g.Len() != 15 || len(g.Points()) != 15 {
+		t.Errorf("Wrong number of points: %d", g.Len())
+	}
+	c := g.Clone()
+	c.Fill(7)
+	if c.Get(Point{0, 0}) != 7 || g.Get(Point{0, 0}) != 0 {
+		t.Errorf("Fill should only change the clone: %v, %v", c.dense, g.dense)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected Set out of bounds to panic")
+		}
+	}()
+	g.Set(Point{3, 0}, 3)
+}
+
+func TestSparse(t *testing.T) {
+	g := NewSparse[bool]()
+	g.Set(Point{5, -3}, true)
+	g.Set(Point{-1, 2}, true)
+	g.Set(Point{0, -3}, true)
+	expected := []Point{{0, -3}, {5, -3}, {-1, 2}}
+	if points := g.Points(); !reflect.DeepEqual(points, expected) {
+		t.Errorf("Wrong point order! Expected: %v, actual: %v", expected, points)
+	}
+	if b := g.Bounds(); b != (Rect{Point{-1, -3}, Point{5, 2}}) {
+		t.Errorf("Wrong bounds: %v", b)
+	}
+	c := g.Clone()
+	g.Delete(Point{5, -3})
+	if g.Len() != 2 || c.Len() != 3 {
+		t.Errorf("Wrong number of points after delete: %d, clone: %d", g.Len(), c.Len())
+	}
+	if n := g.Neighbours(Point{}, Directions4); len(n) != 4 {
+		t.Errorf("Sparse grid should be unbounded, got neighbours %v", n)
+	}
+}
+
+func TestParse(t *testing.T) {
+	g := ParseStrings("#.#\n.\n##.#\n")
+	if b := g.Bounds(); b != NewRect(4, 3) {
+		t.Errorf("Wrong bounds: %v", b)
+	}
+	expected := "#.# \n.   \n##.#\n"
+	actual := g.Render(func(_ Point, v string) string {
+		if v == "" {
+			return " "
+		}
+		return v
+	})
+	if actual != expected {
+		t.Errorf("Wrong render! Expected: %q, actual: %q", expected, actual)
+	}
+
+	errBad := errors.New("bad")
+	_, err := Parse("aa\nab", func(p Point, r rune) (int, error) {
+		if r == 'b' {
+			return 0, errBad
+		}
+		return int(r), nil
+	})
+	if !errors.Is(err, errBad) {
+		t.Errorf("Expected conversion error, got %v", err)
+	}
+	var parseErr *aoc.ParseError
+	if !errors.As(err, &parseErr) || parseErr.Line != 2 {
+		t.Errorf("Expected a parse error on line 2, got %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+// Package grid contains 2D geometry helpers and a generic grid shared by the challenges.
+// Coordinates follow the puzzle maps: (0,0) is the upper left corner, X grows to the right and Y grows downwards.
+package grid
+
+// Point holds 2D coordinates. It doubles as a relative direction (see Up, Right, Down, Left).
+type Point struct {
+	X int
+	Y int
+}
+
+// Relative directions based on (0, 0) being the upper left corner.
+var (
+	Up        = Point{0, -1}
+	UpRight   = Point{1, -1}
+	Right     = Point{1, 0}
+	DownRight = Point{1, 1}
+	Down      = Point{0, 1}
+	DownLeft  = Point{-1, 1}
+	Left      = Point{-1, 0}
+	UpLeft    = Point{-1, -1}
+)
+
+var (
+	// Directions4 are the orthogonal directions in clockwise order starting with Up (4 DOF movement).
+	Directions4 = []Point{Up, Right, Down, Left}
+	// Directions8 are all neighbouring directions in clockwise order starting with Up (8 DOF movement).
+	Directions8 = []Point{Up, UpRight, Right, DownRight, Down, DownLeft, Left, UpLeft}
+	// Diagonals are the diagonal directions in clockwise order starting with UpRight.
+	Diagonals = []Point{UpRight, DownRight, DownLeft, UpLeft}
+)
+
+// abs returns the absolute value of an integer.
+func abs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+// Add returns the coordinate-wise sum of two points.
+func (p Point) Add(d Point) Point {
+	return Point{p.X + d.X, p.Y + d.Y}
+}
+
+// Sub returns the coordinate-wise difference of two points.
+func (p Point) Sub(d Point) Point {
+	return Point{p.X - d.X, p.Y - d.Y}
+}
+
+// Scale multiplies both coordinates with k.
+func (p Point) Scale(k int) Point {
+	return Point{p.X * k, p.Y * k}
+}
+
+// Manhattan returns the taxicab distance between two points.
+func (p Point) Manhattan(q Point) int {
+	return abs(p.X-q.X) + abs(p.Y-q.Y)
+}
+
+// Chebyshev returns the chessboard distance (number of king moves) between two points.
+func (p Point) Chebyshev(q Point) int {
+	dx, dy := abs(p.X-q.X), abs(p.Y-q.Y)
+	if dx > dy {
+		return dx
+	}
+	return dy
+}
+
+// TurnRight rotates a direction 90 degrees clockwise (e.g. Up -> Right).
+func (p Point) TurnRight() Point {
+	return Point{-p.Y, p.X}
+}
+
+// TurnLeft rotates a direction 90 degrees counterclockwise (e.g. Up -> Left).
+func (p Point) TurnLeft() Point {
+	return Point{p.Y, -p.X}
+}
+
+// Opposite returns the reversed direction (e.g. Up -> Down).
+func (p Point) Opposite() Point {
+	return Point{-p.X, -p.Y}
+}
+
+// Neighbours returns the points next to p in given directions (e.g. Directions4 or Directions8).
+func (p Point) Neighbours(directions []Point) []Point {
+	n := make([]Point, len(directions))
+	for i, d := range directions {
+		n[i] = p.Add(d)
+	}
+	return n
+}
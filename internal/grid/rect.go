@@ -0,0 +1,81 @@
+package grid
+
+// Rect is an axis aligned rectangle with inclusive Min (upper left) and Max (lower right) corners.
+type Rect struct {
+	Min Point
+	Max Point
+}
+
+// NewRect returns the rectangle spanning from (0,0) with given width and height.
+func NewRect(width, height int) Rect {
+	return Rect{Max: Point{width - 1, height - 1}}
+}
+
+// BoundingBox returns the smallest rectangle containing all points.
+// The bounding box of no points is empty.
+func BoundingBox(points ...Point) Rect {
+	if len(points) == 0 {
+		return Rect{Max: Point{-1, -1}}
+	}
+	r := Rect{points[0], points[0]}
+	for _, p := range points[1:] {
+		r = r.Extend(p)
+	}
+	return r
+}
+
+// Width returns the number of columns in the rectangle.
+func (r Rect) Width() int {
+	if r.Max.X < r.Min.X {
+		return 0
+	}
+	return r.Max.X - r.Min.X + 1
+}
+
+// Height returns the number of rows in the rectangle.
+func (r Rect) Height() int {
+	if r.Max.Y < r.Min.Y {
+		return 0
+	}
+	return r.Max.Y - r.Min.Y + 1
+}
+
+// Area returns the number of points in the rectangle.
+func (r Rect) Area() int {
+	return r.Width() * r.Height()
+}
+
+// Empty returns true if the rectangle contains no points.
+func (r Rect) Empty() bool {
+	return r.Area() == 0
+}
+
+// Contains returns true if the point is inside the rectangle (edges included).
+func (r Rect) Contains(p Point) bool {
+	return p.X >= r.Min.X && p.Y >= r.Min.Y && p.X <= r.Max.X && p.Y <= r.Max.Y
+}
+
+// Extend returns the smallest rectangle that contains r and p.
+func (r Rect) Extend(p Point) Rect {
+	if r.Empty() {
+		return Rect{p, p}
+	}
+	if p.X < r.Min.X {
+		r.Min.X = p.X
+	}
+	if p.Y < r.Min.Y {
+		r.Min.Y = p.Y
+	}
+	if p.X > r.Max.X {
+		r.Max.X = p.X
+	}
+	if p.Y > r.Max.Y {
+		r.Max.Y = p.Y
+	}
+	return r
+}
+
+// Pad returns the rectangle grown by n points on every side.
+func (r Rect) Pad(n int) Rect {
+	return Rect{r.Min.Sub(Point{n, n}), r.Max.Add(Point{n, n})}
+}
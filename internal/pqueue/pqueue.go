@@ -0,0 +1,140 @@
+// Package pqueue implements a generic priority queue on top of container/heap.
+// Items are unique, so the priority of a queued item can be changed (e.g. decrease-key in Dijkstra).
+package pqueue
+
+import (
+	"container/heap"
+
+	"golang.org/x/exp/constraints"
+)
+
+// entry is an item in the heap together with its priority and position in the heap.
+type entry[T comparable, P constraints.Ordered] struct {
+	item     T
+	priority P
+	index    int
+}
+
+// entries implements heap.Interface and keeps the position of every item up to date.
+type entries[T comparable, P constraints.Ordered] struct {
+	items  []*entry[T, P]
+	queued map[T]*entry[T, P]
+	less   func(a, b P) bool
+}
+
+func (e *entries[T, P]) Len() int {
+	return len(e.items)
+}
+
+func (e *entries[T, P]) Less(i, j int) bool {
+	return e.less(e.items[i].priority, e.items[j].priority)
+}
+
+func (e *entries[T, P]) Swap(i, j int) {
+	e.items[i], e.items[j] = e.items[j], e.items[i]
+	e.items[i].index = i
+	e.items[j].index = j
+}
+
+func (e *entries[T, P]) Push(x any) {
+	en := x.(*entry[T, P])
+	en.index = len(e.items)
+	e.items = append(e.items, en)
+	e.queued[en.item] = en
+}
+
+func (e *entries[T, P]) Pop() any {
+	last := e.items[len(e.items)-1]
+	e.items[len(e.items)-1] = nil
+	e.items = e.items[:len(e.items)-1]
+	delete(e.queued, last.item)
+	return last
+}
+
+// Queue is a priority queue of unique items.
+type Queue[T comparable, P constraints.Ordered] struct {
+	entries *entries[T, P]
+}
+
+// New returns an empty queue where less decides which priority comes out first.
+func New[T comparable, P constraints.Ordered](less func(a, b P) bool) *Queue[T, P] {
+	return &Queue[T, P]{&entries[T, P]{queued: make(map[T]*entry[T, P]), less: less}}
+}
+
+// NewMin returns an empty queue that pops the lowest priority first.
+func NewMin[T comparable, P constraints.Ordered]() *Queue[T, P] {
+	return New[T](func(a, b P) bool { return a < b })
+}
+
+// NewMax returns an empty queue that pops the highest priority first.
+func NewMax[T comparable, P constraints.Ordered]() *Queue[T, P] {
+	return New[T](func(a, b P) bool { return a > b })
+}
+
+// Len returns the number of items in the queue.
+func (q *Queue[T, P]) Len() int {
+	return q.entries.Len()
+}
+
+// IsEmpty returns true if no items are in the queue.
+func (q *Queue[T, P]) IsEmpty() bool {
+	return q.Len() == 0
+}
+
+// Push adds an item to the queue. If the item is already queued, its priority is replaced.
+func (q *Queue[T, P]) Push(item T, priority P) {
+	if e, ok := q.entries.queued[item]; ok {
+		e.priority = priority
+		heap.Fix(q.entries, e.index)
+		return
+	}
+	heap.Push(q.entries, &entry[T, P]{item: item, priority: priority})
+}
+
+// Improve changes the priority of an item only if the new priority comes out sooner (decrease-key for
+// a minimum queue). Items that are not queued yet are added. Returns true if the queue changed.
+func (q *Queue[T, P]) Improve(item T, priority P) bool {
+	if current, ok := q.Priority(item); ok && !q.entries.less(priority, current) {
+		return false
+	}
+	q.Push(item, priority)
+	return true
+}
+
+// Pop removes and returns the item that comes out first and its priority. Panics on an empty queue.
+func (q *Queue[T, P]) Pop() (T, P) {
+	e := heap.Pop(q.entries).(*entry[T, P])
+	return e.item, e.priority
+}
+
+// Peek returns the item that comes out first and its priority without removing it. Panics on an empty queue.
+func (q *Queue[T, P]) Peek() (T, P) {
+	e := q.entries.items[0]
+	return e.item, e.priority
+}
+
+// Priority returns the priority of a queued item.
+func (q *Queue[T, P]) Priority(item T) (P, bool) {
+	e, ok := q.entries.queued[item]
+	if !ok {
+		var zero P
+		return zero, false
+	}
+	return e.priority, true
+}
+
+// Contains returns true if the item is queued.
+func (q *Queue[T, P]) Contains(item T) bool {
+	_, ok := q.entries.queued[item]
+	return ok
+}
+
+// Remove takes the item out of the queue. Returns false if it wasn't queued.
+func (q *Queue[T, P]) Remove(item T) bool {
+	e, ok := q.entries.queued[item]
+	if !ok {
+		return false
+	}
+	heap.Remove(q.entries, e.index)
+	return true
+}
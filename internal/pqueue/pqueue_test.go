@@ -0,0 +1,88 @@
+package pqueue
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestMinOrder(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	q := NewMin[int, int]()
+	priorities := make([]int, 1000)
+	for i := range priorities {
+		priorities[i] = r.Intn(100)
+		q.Push(i, priorities[i])
+	}
+	sort.Ints(priorities)
+	for i, expected := range priorities {
+		item, actual := q.Pop()
+		if actual != expected {
+			t.Fatalf("Wrong priority at %d! Expected: %v, actual: %v (item %d)", i, expected, actual, item)
+		}
+	}
+	if !q.IsEmpty() {
+		t.Errorf("Expected empty queue, got %d items", q.Len())
+	}
+}
+
+func TestMax(t *testing.T) {
+	q := NewMax[string, float64]()
+	q.Push("low", 0.5)
+	q.Push("high", 2.5)
+	q.Push("mid", 1)
+	for _, expected := range []string{"high", "mid", "low"} {
+		if actual, _ := q.Pop(); actual != expected {
+			t.Errorf("Wrong item! Expected: %v, actual: %v", expected, actual)
+		}
+	}
+}
+
+func TestDecreaseKey(t *testing.T) {
+	q := NewMin[string, int]()
+	q.Push("a", 10)
+	q.Push("b", 5)
+	q.Push("c", 7)
+	if q.Improve("a", 20) {
+		t.Error("Worse priority shouldn't change the queue")
+	}
+	if !q.Improve("a", 1) {
+		t.Error("Better priority should change the queue")
+	}
+	if item, priority := q.Peek(); item != "a" || priority != 1 {
+		t.Errorf("Wrong first item: %v (%d)", item, priority)
+	}
+	q.Push("a", 6)
+	if q.Len() != 3 {
+		t.Errorf("Pushing a queued item shouldn't add it twice, got %d items", q.Len())
+	}
+	if !q.Remove("b") || q.Remove("b") || q.Contains("b") {
+		t.Error("Wrong removal of b")
+	}
+	if p, ok := q.Priority("a"); !ok || p != 6 {
+		t.Errorf("Wrong priority of a: %d", p)
+	}
+	for _, expected := range []string{"a", "c"} {
+		if actual, _ := q.Pop(); actual != expected {
+			t.Errorf("Wrong item! Expected: %v, actual: %v", expected, actual)
+		}
+	}
+}
+
+func BenchmarkPushPop(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	priorities := make([]int, 10000)
+	for i := range priorities {
+		priorities[i] = r.Int()
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		q := NewMin[int, int]()
+		for item, p := range priorities {
+			q.Push(item, p)
+		}
+		for !q.IsEmpty() {
+			q.Pop()
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// writerSink writes every event on its own line of the writer.
+type writerSink struct {
+	mu     sync.Mutex
+	w      io.Writer
+	format func(buf *bytes.Buffer, e Event)
+}
+
+// Emit writes the event.
+func (s *writerSink) Emit(e Event) {
+	var buf bytes.Buffer
+	s.format(&buf, e)
+	buf.WriteByte('\n')
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.w.Write(buf.Bytes())
+}
+
+// NewText returns a sink writing events as text lines, e.g. `2022/17 info period found rocks=35 height=53`.
+func NewText(w io.Writer) Sink {
+	return &writerSink{w: w, format: formatText}
+}
+
+// NewJSON returns a sink writing events as JSON lines,
+// e.g. `{"day":"2022/17","level":"info","event":"period found","fields":{"rocks":35,"height":53}}`.
+func NewJSON(w io.Writer) Sink {
+	return &writerSink{w: w, format: formatJSON}
+}
+
+// NewSink returns the sink of given format (text or json).
+func NewSink(format string, w io.Writer) (Sink, error) {
+	switch format {
+	case "text":
+		return NewText(w), nil
+	case "json":
+		return NewJSON(w), nil
+	}
+	return nil, fmt.Errorf("unknown trace format %q, expected text or json", format)
+}
+
+// formatText writes the event as text, values with spaces are quoted.
+func formatText(buf *bytes.Buffer, e Event) {
+	fmt.Fprintf(buf, "%v %v %s", e.Day, e.Level, e.Name)
+	for _, f := range e.Fields {
+		value := fmt.Sprint(f.Value)
+		if value == "" || strings.ContainsAny(value, " \t\n\"=") {
+			value = strconv.Quote(value)
+		}
+		fmt.Fprintf(buf, " %s=%s", f.Key, value)
+	}
+}
+
+// formatJSON writes the event as a JSON object, the fields keep their order.
+// Values that can't be encoded as JSON are written as text.
+func formatJSON(buf *bytes.Buffer, e Event) {
+	day, _ := json.Marshal(e.Day.String())
+	name, _ := json.Marshal(e.Name)
+	fmt.Fprintf(buf, `{"day":%s,"level":"%v","event":%s,"fields":{`, day, e.Level, name)
+	for i, f := range e.Fields {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(f.Key)
+		value, err := json.Marshal(f.Value)
+		if err != nil {
+			value, _ = json.Marshal(fmt.Sprint(f.Value))
+		}
+		fmt.Fprintf(buf, "%s:%s", key, value)
+	}
+	buf.WriteString("}}")
+}
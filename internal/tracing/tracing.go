@@ -0,0 +1,132 @@
+// Package tracing lets the solvers emit events about what they are doing (e.g. "period found") instead of printing
+// them. Every day has its own tracer, which the runner enables with a level and a sink writing the events as text
+// or JSON lines. A disabled tracer costs an atomic load per event.
+package tracing
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/rubinda/aoc"
+)
+
+// Level controls which events a tracer emits.
+type Level int32
+
+const (
+	// Off disables tracing.
+	Off Level = iota
+	// Info events mark milestones of a solver, e.g. a period found or an equation solved.
+	Info
+	// Debug events follow every step, e.g. each comparison or move.
+	Debug
+)
+
+var levelNames = []string{"off", "info", "debug"}
+
+// String returns the name of the level.
+func (l Level) String() string {
+	if l < Off || int(l) >= len(levelNames) {
+		return fmt.Sprintf("Level(%d)", int32(l))
+	}
+	return levelNames[l]
+}
+
+// ParseLevel returns the level of given name (off, info or debug).
+func ParseLevel(name string) (Level, error) {
+	for l, levelName := range levelNames {
+		if strings.EqualFold(name, levelName) {
+			return Level(l), nil
+		}
+	}
+	return Off, fmt.Errorf("unknown trace level %q, expected one of %s", name, strings.Join(levelNames, ", "))
+}
+
+// Field is a named value attached to an event.
+type Field struct {
+	Key   string
+	Value any
+}
+
+// F returns a field with given key and value.
+func F(key string, value any) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Event is something a solver reports while solving a day.
+type Event struct {
+	Day    aoc.Key
+	Level  Level
+	Name   string
+	Fields []Field
+}
+
+// Sink writes the events of enabled tracers. Sinks have to be safe for concurrent use.
+type Sink interface {
+	Emit(e Event)
+}
+
+// Tracer emits the events of a single day.
+type Tracer struct {
+	day   aoc.Key
+	level atomic.Int32
+	sink  atomic.Pointer[Sink]
+}
+
+var (
+	tracersMu sync.Mutex
+	tracers   = make(map[aoc.Key]*Tracer)
+)
+
+// For returns the tracer of given year and day. Days keep it in a package variable.
+func For(year, day int) *Tracer {
+	tracersMu.Lock()
+	defer tracersMu.Unlock()
+	key := aoc.Key{Year: year, Day: day}
+	t, ok := tracers[key]
+	if !ok {
+		t = &Tracer{day: key}
+		tracers[key] = t
+	}
+	return t
+}
+
+// Enable makes the tracer emit events up to given level into the sink.
+func (t *Tracer) Enable(level Level, sink Sink) {
+	t.sink.Store(&sink)
+	t.level.Store(int32(level))
+}
+
+// Disable stops the tracer from emitting events.
+func (t *Tracer) Disable() {
+	t.level.Store(int32(Off))
+}
+
+// Enabled returns true if events of given level are emitted. Events in hot loops should be guarded by it,
+// so their fields aren't even built when tracing is off.
+func (t *Tracer) Enabled(level Level) bool {
+	return level <= Level(t.level.Load())
+}
+
+// Info emits an Info event with given name and fields.
+func (t *Tracer) Info(name string, fields ...Field) {
+	if t.Enabled(Info) {
+		t.emit(Info, name, fields)
+	}
+}
+
+// Debug emits a Debug event with given name and fields.
+func (t *Tracer) Debug(name string, fields ...Field) {
+	if t.Enabled(Debug) {
+		t.emit(Debug, name, fields)
+	}
+}
+
+// emit passes the event to the sink.
+func (t *Tracer) emit(level Level, name string, fields []Field) {
+	if sink := t.sink.Load(); sink != nil {
+		(*sink).Emit(Event{Day: t.day, Level: level, Name: name, Fields: append([]Field(nil), fields...)})
+	}
+}
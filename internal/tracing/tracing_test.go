@@ -0,0 +1,66 @@
+package tracing
+
+import (
+	"bytes"
+	"testing"
+)
+
+// Tests use year 1 so they don't collide with the tracers of real days.
+const testYear = 1
+
+func TestText(t *testing.T) {
+	var out bytes.Buffer
+	tracer := For(testYear, 1)
+	tracer.Enable(Info, NewText(&out))
+	defer tracer.Disable()
+
+	tracer.Info("period found", F("rocks", 35), F("surface", "0123|45 6"))
+	tracer.Debug("rock moved", F("x", 2))
+	if expected := "1/1 info period found rocks=35 surface=\"0123|45 6\"\n"; out.String() != expected {
+		t.Errorf("Wrong result! Expected: %q, actual: %q", expected, out.String())
+	}
+}
+
+func TestJSON(t *testing.T) {
+	var out bytes.Buffer
+	tracer := For(testYear, 2)
+	tracer.Enable(Debug, NewJSON(&out))
+	defer tracer.Disable()
+
+	tracer.Debug("comparing packets", F("left", "[1,[2]]"), F("index", 3), F("point", struct{ X, Y int }{1, 2}))
+	expected := `{"day":"1/2","level":"debug","event":"comparing packets","fields":{"left":"[1,[2]]","index":3,"point":{"X":1,"Y":2}}}` + "\n"
+	if out.String() != expected {
+		t.Errorf("Wrong result! Expected: %q, actual: %q", expected, out.String())
+	}
+}
+
+func TestDisabled(t *testing.T) {
+	var out bytes.Buffer
+	tracer := For(testYear, 3)
+	if tracer != For(testYear, 3) {
+		t.Error("Expected the same tracer for the same day")
+	}
+	tracer.Enable(Debug, NewText(&out))
+	tracer.Disable()
+
+	allocs := testing.AllocsPerRun(100, func() {
+		tracer.Info("period found", F("rocks", 35))
+		if tracer.Enabled(Debug) {
+			tracer.Debug("rock moved", F("x", out.Len()))
+		}
+	})
+	if allocs != 0 || out.Len() != 0 {
+		t.Errorf("Disabled tracer wrote %q with %v allocations", out.String(), allocs)
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	for _, l := range []Level{Off, Info, Debug} {
+		if actual, err := ParseLevel(l.String()); err != nil || actual != l {
+			t.Errorf("Wrong result! Expected: %v, actual: %v (%v)", l, actual, err)
+		}
+	}
+	if _, err := ParseLevel("verbose"); err == nil {
+		t.Error("Expected an error for an unknown level")
+	}
+}
@@ -0,0 +1,153 @@
+package viz
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/gif"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Formats are the animation formats of NewEncoder.
+var Formats = []string{"gif", "png"}
+
+// gifEncoder collects the frames and writes them as an animated GIF when closed, since all frames have to fit into
+// the same canvas.
+type gifEncoder struct {
+	w      io.Writer
+	scale  int
+	delay  int
+	frames []*image.Paletted
+}
+
+// NewGIF returns an encoder writing an animated GIF that shows every frame for delay and loops forever. A cell of
+// a frame is scale by scale pixels. The canvas covers the bounds of all frames, each one is drawn at its place.
+func NewGIF(w io.Writer, scale int, delay time.Duration) Encoder {
+	return &gifEncoder{w: w, scale: scale, delay: int(delay / (10 * time.Millisecond))}
+}
+
+// Encode adds the frame to the animation.
+func (e *gifEncoder) Encode(frame *image.Paletted) error {
+	e.frames = append(e.frames, frame)
+	return nil
+}
+
+// Close writes the animation.
+func (e *gifEncoder) Close() error {
+	if len(e.frames) == 0 {
+		return errors.New("no frames to animate")
+	}
+	canvas := image.Rectangle{}
+	for _, frame := range e.frames {
+		canvas = canvas.Union(frame.Bounds())
+	}
+	animation := &gif.GIF{
+		Image: make([]*image.Paletted, len(e.frames)),
+		Delay: make([]int, len(e.frames)),
+	}
+	for i, frame := range e.frames {
+		animation.Image[i] = scaled(frame, canvas, e.scale)
+		animation.Delay[i] = e.delay
+	}
+	return gif.EncodeAll(e.w, animation)
+}
+
+// pngEncoder writes every frame into its own PNG file.
+type pngEncoder struct {
+	prefix string
+	scale  int
+	frames int
+}
+
+// NewPNGs returns an encoder writing every frame into a PNG file named after the prefix and the frame number
+// (e.g. 2022/14/part1-0001.png for prefix 2022/14/part1). A cell of a frame is scale by scale pixels.
+func NewPNGs(prefix string, scale int) Encoder {
+	return &pngEncoder{prefix: prefix, scale: scale}
+}
+
+// Encode writes the frame into the next file.
+func (e *pngEncoder) Encode(frame *image.Paletted) error {
+	e.frames++
+	f, err := os.Create(fmt.Sprintf("%s-%04d.png", e.prefix, e.frames))
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, scaled(frame, frame.Bounds(), e.scale)); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Close does nothing, every frame is already written.
+func (e *pngEncoder) Close() error {
+	return nil
+}
+
+// NewEncoder returns an encoder of given format (gif or png) writing to path without its extension, creating the
+// directory of the path if needed.
+func NewEncoder(format, path string, scale int, delay time.Duration) (Encoder, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	switch format {
+	case "gif":
+		f, err := os.Create(path + ".gif")
+		if err != nil {
+			return nil, err
+		}
+		return &closingEncoder{Encoder: NewGIF(f, scale, delay), f: f}, nil
+	case "png":
+		return NewPNGs(path, scale), nil
+	}
+	return nil, fmt.Errorf("unknown animation format %q, expected one of %v", format, Formats)
+}
+
+// closingEncoder closes the file of the animation with the encoder and removes it if the animation failed.
+type closingEncoder struct {
+	Encoder
+	f *os.File
+}
+
+// Close finishes the animation and closes its file.
+func (e *closingEncoder) Close() error {
+	err := e.Encoder.Close()
+	if closeErr := e.f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(e.f.Name())
+	}
+	return err
+}
+
+// scaled returns the frame drawn onto a canvas of given bounds (translated to start at 0,0), every cell as
+// scale by scale pixels. The canvas outside of the frame is the first color of the palette.
+func scaled(frame *image.Paletted, canvas image.Rectangle, scale int) *image.Paletted {
+	if scale < 1 {
+		scale = 1
+	}
+	size := canvas.Size().Mul(scale)
+	out := image.NewPaletted(image.Rectangle{Max: size}, frame.Palette)
+	bounds := frame.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			index := frame.ColorIndexAt(x, y)
+			if index == 0 {
+				continue
+			}
+			top := image.Pt(x-canvas.Min.X, y-canvas.Min.Y).Mul(scale)
+			for dy := 0; dy < scale; dy++ {
+				row := out.PixOffset(top.X, top.Y+dy)
+				for dx := 0; dx < scale; dx++ {
+					out.Pix[row+dx] = index
+				}
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,173 @@
+// Package viz records the state of the days' simulations as frames, which encoders turn into animations (see
+// aoc viz). Every day that can be visualized has its own recorder with a palette of its materials and markers,
+// its simulation records the state after every step. A disabled recorder costs an atomic load per step.
+package viz
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"sync"
+	"sync/atomic"
+
+	"github.com/rubinda/aoc"
+	"github.com/rubinda/aoc/internal/grid"
+)
+
+// ErrNoRecorder is returned for days without a visualization.
+var ErrNoRecorder = errors.New("no visualization")
+
+// Framer is the state of a simulation that can be drawn as a frame.
+type Framer interface {
+	// Frame draws the current state with a palette index per cell. The bounds of the frame are in the
+	// simulation's coordinates, so frames of an area that grows or moves line up in an animation.
+	Frame() *image.Paletted
+}
+
+// Encoder turns the recorded frames into an animation. Close finishes the animation.
+type Encoder interface {
+	Encode(frame *image.Paletted) error
+	Close() error
+}
+
+// Recorder records the steps of a single day's simulation.
+type Recorder struct {
+	day     aoc.Key
+	palette color.Palette
+	enabled atomic.Bool
+
+	mu        sync.Mutex
+	encoder   Encoder
+	every     int
+	maxFrames int
+	steps     int
+	frames    int
+	// last is the state of a step that was skipped, it is recorded when the recorder is disabled.
+	last Framer
+	err  error
+}
+
+var (
+	recordersMu sync.Mutex
+	recorders   = make(map[aoc.Key]*Recorder)
+)
+
+// For returns the recorder of given year and day drawing with the palette. Days keep it in a package variable.
+// Panics if the day already has a recorder or the palette doesn't fit into a GIF (at most 256 colors).
+func For(year, day int, palette color.Palette) *Recorder {
+	recordersMu.Lock()
+	defer recordersMu.Unlock()
+	key := aoc.Key{Year: year, Day: day}
+	if _, ok := recorders[key]; ok {
+		panic(fmt.Sprintf("viz: %v already has a recorder", key))
+	}
+	if len(palette) == 0 || len(palette) > 256 {
+		panic(fmt.Sprintf("viz: %v has a palette of %d colors, expected 1 to 256", key, len(palette)))
+	}
+	r := &Recorder{day: key, palette: palette}
+	recorders[key] = r
+	return r
+}
+
+// Lookup returns the recorder of given year and day, or ErrNoRecorder if the day has no visualization.
+func Lookup(year, day int) (*Recorder, error) {
+	recordersMu.Lock()
+	defer recordersMu.Unlock()
+	key := aoc.Key{Year: year, Day: day}
+	r, ok := recorders[key]
+	if !ok {
+		return nil, fmt.Errorf("%v: %w", key, ErrNoRecorder)
+	}
+	return r, nil
+}
+
+// Palette returns the colors the recorder draws with.
+func (r *Recorder) Palette() color.Palette {
+	return r.palette
+}
+
+// Enable makes the recorder pass every n-th step to the encoder, until maxFrames were recorded (0 for no limit).
+func (r *Recorder) Enable(e Encoder, every, maxFrames int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if every < 1 {
+		every = 1
+	}
+	r.encoder, r.every, r.maxFrames = e, every, maxFrames
+	r.steps, r.frames, r.last, r.err = 0, 0, nil, nil
+	r.enabled.Store(true)
+}
+
+// Disable stops recording. The final state is recorded if its step was skipped, so an animation always ends with
+// the outcome of the simulation. Returns the first error of the encoder.
+func (r *Recorder) Disable() error {
+	r.enabled.Store(false)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.last != nil && r.err == nil {
+		r.record(r.last)
+	}
+	r.encoder, r.last = nil, nil
+	return r.err
+}
+
+// Enabled returns true if the steps are recorded. Days that have to build the state for a step should check it first.
+func (r *Recorder) Enabled() bool {
+	return r.enabled.Load()
+}
+
+// Frames returns the number of frames recorded since the recorder was enabled.
+func (r *Recorder) Frames() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.frames
+}
+
+// Step records the state after a step of the simulation, if the step is due.
+func (r *Recorder) Step(state Framer) {
+	if !r.Enabled() {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.encoder == nil || r.err != nil {
+		return
+	}
+	r.steps++
+	if (r.steps-1)%r.every != 0 || (r.maxFrames > 0 && r.frames >= r.maxFrames) {
+		r.last = state
+		return
+	}
+	r.record(state)
+}
+
+// record passes the frame of the state to the encoder.
+func (r *Recorder) record(state Framer) {
+	r.last = nil
+	frame := state.Frame()
+	if len(frame.Palette) == 0 {
+		frame.Palette = r.palette
+	}
+	if r.err = r.encoder.Encode(frame); r.err == nil {
+		r.frames++
+	}
+}
+
+// NewFrame returns a frame of the cells within bounds, all of them the first color of the palette.
+func NewFrame(bounds grid.Rect, palette color.Palette) *image.Paletted {
+	return image.NewPaletted(image.Rect(bounds.Min.X, bounds.Min.Y, bounds.Max.X+1, bounds.Max.Y+1), palette)
+}
+
+// GridFrame returns a frame of the grid's bounds with the palette index of every point.
+func GridFrame[T any](g *grid.Grid[T], palette color.Palette, index func(p grid.Point, v T) uint8) *image.Paletted {
+	bounds := g.Bounds()
+	frame := NewFrame(bounds, palette)
+	for y := bounds.Min.Y; y <= bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x <= bounds.Max.X; x++ {
+			p := grid.Point{X: x, Y: y}
+			frame.SetColorIndex(x, y, index(p, g.Get(p)))
+		}
+	}
+	return frame
+}
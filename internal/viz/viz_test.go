@@ -0,0 +1,164 @@
+package viz
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rubinda/aoc/internal/grid"
+)
+
+var (
+	testPalette  = color.Palette{color.Black, color.White}
+	testRecorder = For(0, 1, testPalette)
+)
+
+// counter is a simulation of a growing line of cells.
+type counter struct {
+	steps int
+}
+
+// Frame draws a line as long as the number of steps.
+func (c *counter) Frame() *image.Paletted {
+	frame := NewFrame(grid.NewRect(c.steps, 1), testPalette)
+	for x := 0; x < c.steps; x++ {
+		frame.SetColorIndex(x, 0, 1)
+	}
+	return frame
+}
+
+// frameRecorder keeps the widths of the encoded frames.
+type frameRecorder struct {
+	widths []int
+}
+
+func (e *frameRecorder) Encode(frame *image.Paletted) error {
+	e.widths = append(e.widths, frame.Bounds().Dx())
+	return nil
+}
+
+func (e *frameRecorder) Close() error {
+	return nil
+}
+
+// simulate runs the counter for given steps with the recorder enabled and returns the recorded frame widths.
+func simulate(t *testing.T, r *Recorder, steps, every, maxFrames int) []int {
+	t.Helper()
+	e := &frameRecorder{}
+	r.Enable(e, every, maxFrames)
+	c := &counter{}
+	for c.steps < steps {
+		c.steps++
+		r.Step(c)
+	}
+	if err := r.Disable(); err != nil {
+		t.Fatal(err)
+	}
+	return e.widths
+}
+
+func TestRecorder(t *testing.T) {
+	r := testRecorder
+	if found, err := Lookup(0, 1); err != nil || found != r {
+		t.Fatalf("Wrong result! Expected: %v, actual: %v (%v)", r, found, err)
+	}
+	if _, err := Lookup(0, 2); err == nil {
+		t.Errorf("Expected an error for a day without a recorder")
+	}
+
+	tests := []struct {
+		steps, every, maxFrames int
+		expected                []int
+	}{
+		{4, 1, 0, []int{1, 2, 3, 4}},
+		{5, 2, 0, []int{1, 3, 5}},
+		// The final state is recorded after the skipped steps
+		{6, 2, 0, []int{1, 3, 5, 6}},
+		{10, 1, 3, []int{1, 2, 3, 10}},
+	}
+	for _, test := range tests {
+		actual := simulate(t, r, test.steps, test.every, test.maxFrames)
+		if len(actual) != len(test.expected) || r.Frames() != len(test.expected) {
+			t.Errorf("Wrong result! Expected: %v, actual: %v", test.expected, actual)
+			continue
+		}
+		for i := range actual {
+			if actual[i] != test.expected[i] {
+				t.Errorf("Wrong result! Expected: %v, actual: %v", test.expected, actual)
+				break
+			}
+		}
+	}
+
+	// Steps of a disabled recorder are not recorded
+	r.Step(&counter{steps: 1})
+	if r.Frames() != len(tests[len(tests)-1].expected) {
+		t.Errorf("Disabled recorder recorded a step")
+	}
+}
+
+func TestGIF(t *testing.T) {
+	var buf bytes.Buffer
+	e := NewGIF(&buf, 2, 50*time.Millisecond)
+	// The second frame lies left of the first, so the canvas covers both
+	second := NewFrame(grid.Rect{Min: grid.Point{X: -2}, Max: grid.Point{X: 0, Y: 1}}, testPalette)
+	second.SetColorIndex(-2, 0, 1)
+	for _, frame := range []*image.Paletted{(&counter{steps: 3}).Frame(), second} {
+		if err := e.Encode(frame); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := e.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	animation, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(animation.Image) != 2 || animation.Delay[0] != 5 {
+		t.Fatalf("Wrong result! Expected: %v frames shown for %v, actual: %v for %v", 2, 5, len(animation.Image), animation.Delay)
+	}
+	// Cells from x=-2 to x=2 and y=0 to y=1, 2 by 2 pixels each
+	if size := animation.Image[0].Bounds().Size(); size != image.Pt(10, 4) {
+		t.Errorf("Wrong result! Expected: %v, actual: %v", image.Pt(10, 4), size)
+	}
+	if index := animation.Image[0].ColorIndexAt(4, 0); index != 1 {
+		t.Errorf("Wrong result! Expected: %v, actual: %v", 1, index)
+	}
+	if index := animation.Image[1].ColorIndexAt(1, 1); index != 1 {
+		t.Errorf("Wrong result! Expected: %v, actual: %v", 1, index)
+	}
+	if err := NewGIF(&buf, 1, 0).Close(); err == nil {
+		t.Errorf("Expected an error for an animation without frames")
+	}
+}
+
+func TestPNGs(t *testing.T) {
+	prefix := filepath.Join(t.TempDir(), "frames", "part1")
+	e, err := NewEncoder("png", prefix, 3, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for steps := 1; steps <= 2; steps++ {
+		if err := e.Encode((&counter{steps: steps}).Frame()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := e.Close(); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"part1-0001.png", "part1-0002.png"} {
+		if _, err := os.Stat(filepath.Join(filepath.Dir(prefix), name)); err != nil {
+			t.Error(err)
+		}
+	}
+	if _, err := NewEncoder("apng", prefix, 1, 0); err == nil {
+		t.Errorf("Expected an error for an unknown format")
+	}
+}
@@ -0,0 +1,72 @@
+package aoc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxErrorText is the most characters of offending input shown in a parse error.
+const maxErrorText = 60
+
+// ParseError is returned by solvers when the puzzle input is invalid.
+type ParseError struct {
+	// Line is the 1-based number of the offending line, 0 if the error isn't tied to a single line.
+	Line int
+	// Text is the offending input.
+	Text string
+	Err  error
+}
+
+// Error reports the line and (shortened) offending text.
+func (e *ParseError) Error() string {
+	text := e.Text
+	if len(text) > maxErrorText {
+		text = text[:maxErrorText] + "..."
+	}
+	if e.Line > 0 {
+		return fmt.Sprintf("invalid input on line %d %q: %v", e.Line, text, e.Err)
+	}
+	return fmt.Sprintf("invalid input %q: %v", text, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// LineError returns a ParseError for given 0-based line index (as returned by ranging over lines).
+func LineError(index int, line string, err error) error {
+	return &ParseError{Line: index + 1, Text: line, Err: err}
+}
+
+// ParseLines calls parse for every line of input and collects the results.
+// The first error is returned as a ParseError with the line number and text.
+func ParseLines[T any](input string, parse func(line string) (T, error)) ([]T, error) {
+	lines := strings.Split(input, "\n")
+	parsed := make([]T, len(lines))
+	for i, line := range lines {
+		v, err := parse(line)
+		if err != nil {
+			return nil, LineError(i, line, err)
+		}
+		parsed[i] = v
+	}
+	return parsed, nil
+}
+
+// ParseBlocks calls parse for every block of input, blocks are separated by an empty line.
+// The first error is returned as a ParseError with the line the block starts on.
+func ParseBlocks[T any](input string, parse func(block string) (T, error)) ([]T, error) {
+	blocks := strings.Split(input, "\n\n")
+	parsed := make([]T, len(blocks))
+	line := 0
+	for i, block := range blocks {
+		v, err := parse(block)
+		if err != nil {
+			return nil, LineError(line, block, err)
+		}
+		parsed[i] = v
+		line += strings.Count(block, "\n") + 2
+	}
+	return parsed, nil
+}
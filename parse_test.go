@@ -0,0 +1,55 @@
+package aoc
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestParseLines(t *testing.T) {
+	numbers, err := ParseLines("1\n2\n3", strconv.Atoi)
+	if err != nil || len(numbers) != 3 || numbers[2] != 3 {
+		t.Errorf("Wrong result: %v, %v", numbers, err)
+	}
+
+	_, err = ParseLines("1\ntwo\n3", strconv.Atoi)
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("Expected a ParseError, got %v", err)
+	}
+	if parseErr.Line != 2 || parseErr.Text != "two" || !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("Wrong parse error: %+v", parseErr)
+	}
+	if expected := `invalid input on line 2 "two"`; !strings.HasPrefix(err.Error(), expected) {
+		t.Errorf("Wrong message! Expected prefix: %v, actual: %v", expected, err)
+	}
+}
+
+func TestParseBlocks(t *testing.T) {
+	sum := func(block string) (int, error) {
+		numbers, err := ParseLines(block, strconv.Atoi)
+		total := 0
+		for _, n := range numbers {
+			total += n
+		}
+		return total, err
+	}
+	sums, err := ParseBlocks("1\n2\n\n3", sum)
+	if err != nil || len(sums) != 2 || sums[0] != 3 || sums[1] != 3 {
+		t.Errorf("Wrong result: %v, %v", sums, err)
+	}
+
+	_, err = ParseBlocks("1\n2\n\n3\n\nfour\n5", sum)
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) || parseErr.Line != 6 {
+		t.Errorf("Expected a ParseError on line 6, got %v", err)
+	}
+}
+
+func TestParseErrorShortensText(t *testing.T) {
+	err := &ParseError{Text: strings.Repeat("<", 100), Err: errors.New("bad")}
+	if expected := `invalid input "` + strings.Repeat("<", maxErrorText) + `...": bad`; err.Error() != expected {
+		t.Errorf("Wrong message! Expected: %v, actual: %v", expected, err)
+	}
+}
@@ -0,0 +1,110 @@
+package aoc
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Kinds of answers, see Result.Kind.
+const (
+	KindNumber = "number"
+	KindText   = "text"
+	KindArt    = "art"
+)
+
+// Result is the answer to a challenge part as the runner reports it.
+type Result struct {
+	Year int
+	Day  int
+	Part int
+	// Answer is what the solver returned, a number or text (which can be multi-line art, e.g. letters on a display).
+	Answer any
+	// Duration is the wall time solving took.
+	Duration time.Duration
+	// Input is the InputHash of the puzzle input.
+	Input string
+}
+
+// Key returns the registry key of the result's puzzle.
+func (r Result) Key() Key {
+	return Key{r.Year, r.Day}
+}
+
+// Kind returns KindNumber for integer answers, KindArt for multi-line answers and KindText for any other.
+func (r Result) Kind() string {
+	switch r.Answer.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return KindNumber
+	}
+	if strings.Contains(strings.TrimRight(FormatAnswer(r.Answer), "\n"), "\n") {
+		return KindArt
+	}
+	return KindText
+}
+
+// String returns the result as text, e.g. "Part 1: 24". Art starts on a new line.
+func (r Result) String() string {
+	answer := FormatAnswer(r.Answer)
+	if r.Kind() == KindArt {
+		return fmt.Sprintf("Part %d:\n%s", r.Part, strings.TrimRight(answer, "\n"))
+	}
+	return fmt.Sprintf("Part %d: %s", r.Part, answer)
+}
+
+// resultJSON is the JSON encoding of a result. Numbers stay numbers, other answers become text.
+type resultJSON struct {
+	Year       int    `json:"year"`
+	Day        int    `json:"day"`
+	Part       int    `json:"part"`
+	Kind       string `json:"kind"`
+	Answer     any    `json:"answer"`
+	DurationNs int64  `json:"duration_ns"`
+	Input      string `json:"input"`
+}
+
+// MarshalJSON encodes the result as an object with year, day, part, kind, answer, duration_ns and input.
+func (r Result) MarshalJSON() ([]byte, error) {
+	encoded := resultJSON{
+		Year:       r.Year,
+		Day:        r.Day,
+		Part:       r.Part,
+		Kind:       r.Kind(),
+		Answer:     r.Answer,
+		DurationNs: r.Duration.Nanoseconds(),
+		Input:      r.Input,
+	}
+	if encoded.Kind != KindNumber {
+		encoded.Answer = strings.TrimRight(FormatAnswer(r.Answer), "\n")
+	}
+	return json.Marshal(encoded)
+}
+
+// UnmarshalJSON decodes a result encoded by MarshalJSON. Numbers are decoded as int, other answers as string.
+func (r *Result) UnmarshalJSON(data []byte) error {
+	var decoded struct {
+		resultJSON
+		Answer json.RawMessage `json:"answer"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*r = Result{
+		Year:     decoded.Year,
+		Day:      decoded.Day,
+		Part:     decoded.Part,
+		Duration: time.Duration(decoded.DurationNs),
+		Input:    decoded.Input,
+	}
+	if decoded.Kind == KindNumber {
+		var n int
+		err := json.Unmarshal(decoded.Answer, &n)
+		r.Answer = n
+		return err
+	}
+	var text string
+	err := json.Unmarshal(decoded.Answer, &text)
+	r.Answer = text
+	return err
+}
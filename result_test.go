@@ -0,0 +1,46 @@
+package aoc
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestResultString(t *testing.T) {
+	tests := []struct {
+		result   Result
+		kind     string
+		expected string
+	}{
+		{Result{Part: 1, Answer: 24000}, KindNumber, "Part 1: 24000"},
+		{Result{Part: 1, Answer: "CMZ"}, KindText, "Part 1: CMZ"},
+		{Result{Part: 2, Answer: "##..\n#..#\n"}, KindArt, "Part 2:\n##..\n#..#"},
+	}
+	for _, tt := range tests {
+		if kind := tt.result.Kind(); kind != tt.kind {
+			t.Errorf("Wrong kind! Expected: %v, actual: %v", tt.kind, kind)
+		}
+		if actual := tt.result.String(); actual != tt.expected {
+			t.Errorf("Wrong result! Expected: %q, actual: %q", tt.expected, actual)
+		}
+	}
+}
+
+func TestResultJSONRoundTrip(t *testing.T) {
+	for _, r := range []Result{
+		{Year: 2022, Day: 1, Part: 1, Answer: 24000, Duration: 1500 * time.Microsecond, Input: InputHash("1")},
+		{Year: 2022, Day: 10, Part: 2, Answer: "##..\n#..#", Duration: time.Millisecond, Input: InputHash("noop")},
+	} {
+		data, err := json.Marshal(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded Result
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		if decoded != r {
+			t.Errorf("Wrong result! Expected: %v, actual: %v (%s)", r, decoded, data)
+		}
+	}
+}
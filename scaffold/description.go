@@ -0,0 +1,64 @@
+package scaffold
+
+import (
+	"errors"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrNoDescription is returned when a page doesn't contain a puzzle description.
+var ErrNoDescription = errors.New("no puzzle description found")
+
+var (
+	titlePattern = regexp.MustCompile(`<h2[^>]*>--- Day (\d+): (.*?) ---</h2>`)
+	// yearPatterns find the year in the page's title or its links to the puzzle, the first match is used.
+	yearPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`<title>[^<]*Advent of Code (\d{4})[^<]*</title>`),
+		regexp.MustCompile(`href="(?:https://adventofcode\.com)?/(\d{4})/day/\d+`),
+	}
+	articlePattern = regexp.MustCompile(`(?s)<article class="day-desc">(.*?)</article>`)
+	examplePattern = regexp.MustCompile(`(?s)<pre><code>(.*?)</code></pre>`)
+	// answerPattern matches the highlighted code the descriptions use for results, the last one is the answer.
+	answerPattern = regexp.MustCompile(`<code><em>([^<]*)</em></code>|<em><code>([^<]*)</code></em>`)
+	tagPattern    = regexp.MustCompile(`<[^>]*>`)
+)
+
+// ParseDescription extracts the year, day, title, examples and their answers from the HTML of a puzzle page.
+// The example is the first code block of the description. The page only holds the second part
+// (and its answer) once the first one is solved. The year is taken from the page's title or links to the
+// puzzle, it is 0 if the page has neither.
+func ParseDescription(page string) (Day, error) {
+	m := titlePattern.FindStringSubmatch(page)
+	articles := articlePattern.FindAllStringSubmatch(page, -1)
+	if m == nil || len(articles) == 0 {
+		return Day{}, ErrNoDescription
+	}
+	day, _ := strconv.Atoi(m[1])
+	d := Day{Day: day, Title: text(m[2]), Answers: make(map[int]string)}
+	for _, pattern := range yearPatterns {
+		if year := pattern.FindStringSubmatch(page); year != nil {
+			d.Year, _ = strconv.Atoi(year[1])
+			break
+		}
+	}
+	if example := examplePattern.FindStringSubmatch(articles[0][1]); example != nil {
+		d.Example = strings.TrimRight(text(example[1]), "\n")
+	}
+	for i, article := range articles {
+		answers := answerPattern.FindAllStringSubmatch(article[1], -1)
+		if len(answers) == 0 {
+			continue
+		}
+		last := answers[len(answers)-1]
+		d.Answers[i+1] = text(last[1] + last[2])
+	}
+	d.Examples = ExtractExamples(page)
+	return d, nil
+}
+
+// text removes markup from a piece of HTML.
+func text(s string) string {
+	return html.UnescapeString(tagPattern.ReplaceAllString(s, ""))
+}
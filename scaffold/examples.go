@@ -0,0 +1,97 @@
+package scaffold
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/rubinda/aoc"
+)
+
+var (
+	// markdownToken matches fenced code blocks, emphasized code (e.g. **`15`**) and the heading of the second part.
+	markdownToken = regexp.MustCompile("(?s)```[a-z]*\\n(.*?)```|\\*+`([^`\\n]+)`\\*+|--- Part Two ---")
+	// htmlToken matches the same in a puzzle page.
+	htmlToken = regexp.MustCompile(`(?s)<pre><code>(.*?)</code></pre>|<code><em>([^<]*)</em></code>|<em><code>([^<]*)</code></em>|--- Part Two ---`)
+)
+
+// ExtractExamples finds the examples in a puzzle description, either a saved page (HTML) or a readme (Markdown).
+// An example is a code block followed by emphasized code, the last of which before the next code block
+// is taken as the answer. Code blocks without an answer (e.g. intermediate states) are skipped.
+// Answers in the second part that follow no code block of their own belong to the first example.
+func ExtractExamples(description string) []aoc.Example {
+	token, isHTML := markdownToken, strings.Contains(description, "<article")
+	if isHTML {
+		token = htmlToken
+	}
+
+	examples := make([]aoc.Example, 0)
+	part, input, answer, first := 1, "", "", ""
+	flush := func() {
+		if input != "" && answer != "" {
+			examples = MergeExamples("", examples, []aoc.Example{{Input: input, Answers: map[int]string{part: answer}}})
+		}
+		input, answer = "", ""
+	}
+	for _, m := range token.FindAllStringSubmatch(description, -1) {
+		switch {
+		case m[0] == "--- Part Two ---":
+			flush()
+			part, input = 2, first
+		case m[1] != "":
+			flush()
+			input = m[1]
+			if isHTML {
+				input = text(input)
+			}
+			input = strings.TrimRight(input, "\n")
+			// Benchmark results in readmes aren't examples
+			if strings.Contains(input, "ns/op") {
+				input = ""
+			}
+			if first == "" {
+				first = input
+			}
+		case input != "":
+			answer = m[2]
+			if isHTML {
+				answer = text(m[2] + m[3])
+			}
+		}
+	}
+	flush()
+	return examples
+}
+
+// MergeExamples adds the found examples to the known ones. Answers of an example with the same input as a known one
+// are added to it, unless the part is already answered. New examples are named after their position.
+// Inputs of known examples stored in files are read from dir.
+func MergeExamples(dir string, known, found []aoc.Example) []aoc.Example {
+	texts := make([]string, len(known))
+	for i, e := range known {
+		texts[i], _ = e.Text(dir)
+	}
+	for _, e := range found {
+		i := 0
+		for i < len(known) && texts[i] != e.Input {
+			i++
+		}
+		if i == len(known) {
+			name := "example"
+			if i > 0 {
+				name = fmt.Sprintf("example%d", i+1)
+			}
+			known = append(known, aoc.Example{Name: name, Input: e.Input, Answers: make(map[int]string)})
+			texts = append(texts, e.Input)
+		}
+		if known[i].Answers == nil {
+			known[i].Answers = make(map[int]string)
+		}
+		for part, answer := range e.Answers {
+			if _, ok := known[i].Answers[part]; !ok {
+				known[i].Answers[part] = answer
+			}
+		}
+	}
+	return known
+}
@@ -0,0 +1,230 @@
+// Package scaffold creates the files of a new day from templates, following the layout of the existing days:
+// main.go registering the solver, main_test.go checking the example answers, example.in and readme.md.
+// Days are grouped in year packages (YEAR/year.go) that import all days of the year.
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/rubinda/aoc"
+)
+
+// Module is the import path of this repository.
+const Module = "github.com/rubinda/aoc"
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+var templates = template.Must(template.New("").Funcs(template.FuncMap{
+	"parts": func(n int) []int {
+		parts := make([]int, n)
+		for i := range parts {
+			parts[i] = i + 1
+		}
+		return parts
+	},
+}).ParseFS(templateFS, "templates/*.tmpl"))
+
+// files maps the generated files to their templates. Files without a template hold the example.
+var files = map[string]string{
+	"main.go":        "main.go.tmpl",
+	"main_test.go":   "main_test.go.tmpl",
+	"readme.md":      "readme.md.tmpl",
+	"example.in":     "",
+	aoc.ExamplesFile: "",
+}
+
+// ErrExists is returned when a generated file would overwrite an existing one.
+var ErrExists = errors.New("file already exists")
+
+// Day describes the day to create.
+type Day struct {
+	Year  int
+	Day   int
+	Title string
+	// Example is the example input from the puzzle description.
+	Example string
+	// Answers holds the answers to the example by challenge part. Missing answers are left at the zero value.
+	Answers map[int]string
+	// Examples are all examples of the description with answers, they are stored in examples.json.
+	Examples []aoc.Example
+}
+
+// Parts returns the number of challenge parts. The last day of an event only has one.
+func (d Day) Parts() int {
+	if d.Day == 25 {
+		return 1
+	}
+	return 2
+}
+
+// Type returns the Go type of the answers: int unless one of the known answers isn't a number.
+func (d Day) Type() string {
+	for _, answer := range d.Answers {
+		if _, err := strconv.Atoi(answer); err != nil {
+			return "string"
+		}
+	}
+	return "int"
+}
+
+// Zero returns the zero value of the answer type as Go source.
+func (d Day) Zero() string {
+	if d.Type() == "string" {
+		return `""`
+	}
+	return "0"
+}
+
+// Expected returns the answers as Go literals for every part.
+func (d Day) Expected() map[int]string {
+	expected := make(map[int]string, d.Parts())
+	for part := 1; part <= d.Parts(); part++ {
+		answer, ok := d.Answers[part]
+		switch {
+		case !ok:
+			expected[part] = d.Zero()
+		case d.Type() == "string":
+			expected[part] = strconv.Quote(answer)
+		default:
+			expected[part] = answer
+		}
+	}
+	return expected
+}
+
+// examples returns the examples of the day, starting with the one in example.in.
+func (d Day) examples() []aoc.Example {
+	answers := make(map[int]string, len(d.Answers))
+	for part, answer := range d.Answers {
+		answers[part] = answer
+	}
+	examples := MergeExamples("", []aoc.Example{{Name: "example", Input: d.Example, Answers: answers}}, d.Examples)
+	examples[0].File, examples[0].Input = "example.in", ""
+	return examples
+}
+
+// Dir returns the package directory of a day in the repository at root.
+func Dir(root string, year, day int) string {
+	return filepath.Join(root, strconv.Itoa(year), strconv.Itoa(day))
+}
+
+// Create writes the files of the day into its package directory and returns their paths.
+// Existing files are only overwritten with force, otherwise nothing is written.
+func Create(root string, d Day, force bool) ([]string, error) {
+	if d.Year < 2015 || d.Day < 1 || d.Day > 25 {
+		return nil, fmt.Errorf("invalid puzzle %d/%d", d.Year, d.Day)
+	}
+	dir := Dir(root, d.Year, d.Day)
+	contents := make(map[string][]byte, len(files))
+	for name, tmpl := range files {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil && !force {
+			return nil, fmt.Errorf("%s: %w", path, ErrExists)
+		}
+		switch {
+		case name == aoc.ExamplesFile:
+			data, err := json.MarshalIndent(d.examples(), "", "  ")
+			if err != nil {
+				return nil, err
+			}
+			contents[path] = append(data, '\n')
+			continue
+		case tmpl == "":
+			contents[path] = []byte(d.Example)
+			continue
+		}
+		var buf bytes.Buffer
+		if err := templates.ExecuteTemplate(&buf, tmpl, d); err != nil {
+			return nil, err
+		}
+		data := buf.Bytes()
+		if strings.HasSuffix(name, ".go") {
+			var err error
+			if data, err = format.Source(data); err != nil {
+				return nil, fmt.Errorf("formatting %s: %w", name, err)
+			}
+		}
+		contents[path] = data
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	paths := make([]string, 0, len(contents))
+	for path, data := range contents {
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// Register adds the blank import of a day to its year package (YEAR/year.go) in the repository at root.
+// A missing year package is created and imported by the runner (cmd/aoc/days.go).
+// Returns the changed files, none if the day was already registered.
+func Register(root string, year, day int) ([]string, error) {
+	yearFile := filepath.Join(root, strconv.Itoa(year), "year.go")
+	changed := make([]string, 0, 2)
+	if _, err := os.Stat(yearFile); errors.Is(err, os.ErrNotExist) {
+		var buf bytes.Buffer
+		if err := templates.ExecuteTemplate(&buf, "year.go.tmpl", Day{Year: year}); err != nil {
+			return nil, err
+		}
+		if err := os.MkdirAll(filepath.Dir(yearFile), 0o755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(yearFile, buf.Bytes(), 0o644); err != nil {
+			return nil, err
+		}
+		days := filepath.Join(root, "cmd", "aoc", "days.go")
+		if _, err := addImport(days, fmt.Sprintf("%s/%d", Module, year)); err != nil {
+			return nil, err
+		}
+		changed = append(changed, days)
+	}
+	added, err := addImport(yearFile, fmt.Sprintf("%s/%d/%d", Module, year, day))
+	if err != nil {
+		return nil, err
+	}
+	if added || len(changed) > 0 {
+		changed = append(changed, yearFile)
+	}
+	return changed, nil
+}
+
+// addImport adds a blank import to the import block of the Go file at path.
+// Returns false if the package was already imported.
+func addImport(path, importPath string) (bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	spec := strconv.Quote(importPath)
+	if bytes.Contains(data, []byte(spec)) {
+		return false, nil
+	}
+	block := []byte("import (\n")
+	i := bytes.Index(data, block)
+	if i < 0 {
+		return false, fmt.Errorf("%s: no import block", path)
+	}
+	i += len(block)
+	data = append(data[:i:i], append([]byte("\t_ "+spec+"\n"), data[i:]...)...)
+	// gofmt sorts the imports of the block
+	if data, err = format.Source(data); err != nil {
+		return false, fmt.Errorf("formatting %s: %w", path, err)
+	}
+	return true, os.WriteFile(path, data, 0o644)
+}
@@ -0,0 +1,154 @@
+package scaffold
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rubinda/aoc"
+)
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestParseDescription(t *testing.T) {
+	d, err := ParseDescription(readFile(t, filepath.Join("testdata", "day2.html")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The description matches the existing day
+	if example := readFile(t, filepath.Join("..", "2022", "2", "example.in")); d.Example != example {
+		t.Errorf("Wrong example! Expected: %q, actual: %q", example, d.Example)
+	}
+	if d.Year != 2022 || d.Day != 2 || d.Title != "Rock Paper Scissors" || d.Answers[1] != "15" || d.Answers[2] != "12" {
+		t.Errorf("Wrong description: %+v", d)
+	}
+
+	// Without a title the year comes from a link to the puzzle, without either it is unknown
+	article := `<article class="day-desc"><h2>--- Day 1: Title ---</h2></article>`
+	for page, expected := range map[string]int{
+		article + `<a href="/2021/day/1/input">get your puzzle input</a>`: 2021,
+		article: 0,
+	} {
+		if d, err := ParseDescription(page); err != nil || d.Year != expected {
+			t.Errorf("Wrong year! Expected: %v, actual: %v (%v)", expected, d.Year, err)
+		}
+	}
+
+	if _, err := ParseDescription("<html></html>"); !errors.Is(err, ErrNoDescription) {
+		t.Errorf("Wrong error! Expected: %v, actual: %v", ErrNoDescription, err)
+	}
+}
+
+func TestExtractExamples(t *testing.T) {
+	tests := []struct {
+		file     string
+		expected []aoc.Example
+	}{
+		{"day2.html", []aoc.Example{
+			{Name: "example", Input: "A Y\nB X\nC Z", Answers: map[int]string{1: "15", 2: "12"}},
+		}},
+		// The third block has no answer, the benchmark isn't an example
+		{"readme.md", []aoc.Example{
+			{Name: "example", Input: "mjqjpqmgbljsphdztnvjfqwrcgsmlb", Answers: map[int]string{1: "7", 2: "19"}},
+			{Name: "example2", Input: "bvwbjplbgvbhsrlpgdmjqwftvncz", Answers: map[int]string{1: "5"}},
+		}},
+	}
+	for _, test := range tests {
+		actual := ExtractExamples(readFile(t, filepath.Join("testdata", test.file)))
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("Wrong examples in %s! Expected: %+v, actual: %+v", test.file, test.expected, actual)
+		}
+	}
+}
+
+func TestCreate(t *testing.T) {
+	root := t.TempDir()
+	d := Day{Year: 2023, Day: 2, Title: `Cube "Conundrum"`, Example: "Game 1", Answers: map[int]string{1: "8"}}
+	paths, err := Create(root, d, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != 5 {
+		t.Errorf("Expected 5 files, got %v", paths)
+	}
+	dir := Dir(root, 2023, 2)
+	if example := readFile(t, filepath.Join(dir, "example.in")); example != d.Example {
+		t.Errorf("Wrong example! Expected: %q, actual: %q", d.Example, example)
+	}
+	if main := readFile(t, filepath.Join(dir, "main.go")); !strings.Contains(main, "package day2\n") || !strings.Contains(main, `Title:   "Cube \"Conundrum\"",`) {
+		t.Errorf("Wrong main.go:\n%s", main)
+	}
+	if test := readFile(t, filepath.Join(dir, "main_test.go")); !strings.Contains(test, "const expected1 = 8\nconst expected2 = 0\n") {
+		t.Errorf("Wrong main_test.go:\n%s", test)
+	}
+
+	examples, err := aoc.LoadExamples(filepath.Join(dir, aoc.ExamplesFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(examples) != 1 || examples[0].File != "example.in" || examples[0].Answers[1] != "8" {
+		t.Errorf("Wrong examples: %+v", examples)
+	}
+
+	if _, err := Create(root, d, false); !errors.Is(err, ErrExists) {
+		t.Errorf("Wrong error! Expected: %v, actual: %v", ErrExists, err)
+	}
+	// The last day has a single part, non numeric answers are strings
+	if _, err := Create(root, Day{Year: 2023, Day: 25, Answers: map[int]string{1: "2=-1=0"}}, false); err != nil {
+		t.Fatal(err)
+	}
+	test := readFile(t, filepath.Join(Dir(root, 2023, 25), "main_test.go"))
+	if !strings.Contains(test, `const expected1 = "2=-1=0"`) || strings.Contains(test, "expected2") {
+		t.Errorf("Wrong main_test.go:\n%s", test)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	root := t.TempDir()
+	for _, path := range []string{filepath.Join("cmd", "aoc", "days.go"), filepath.Join("2022", "year.go")} {
+		if err := os.MkdirAll(filepath.Join(root, filepath.Dir(path)), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(root, path), []byte(readFile(t, filepath.Join("..", path))), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	days := filepath.Join(root, "cmd", "aoc", "days.go")
+	tests := []struct {
+		year, day int
+		changed   []string
+	}{
+		// A new year is created and imported by the runner
+		{2023, 1, []string{days, filepath.Join(root, "2023", "year.go")}},
+		{2023, 1, []string{}},
+		{2022, 26, []string{filepath.Join(root, "2022", "year.go")}},
+	}
+	for _, test := range tests {
+		changed, err := Register(root, test.year, test.day)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Join(changed, ",") != strings.Join(test.changed, ",") {
+			t.Errorf("Registering %d/%d: expected changes %v, got %v", test.year, test.day, test.changed, changed)
+		}
+	}
+	if year := readFile(t, filepath.Join(root, "2023", "year.go")); !strings.Contains(year, "package year2023\n") || !strings.Contains(year, "\t_ \"github.com/rubinda/aoc/2023/1\"\n") {
+		t.Errorf("Wrong year package:\n%s", year)
+	}
+	if imports := readFile(t, days); !strings.Contains(imports, "\t_ \"github.com/rubinda/aoc/2022\"\n\t_ \"github.com/rubinda/aoc/2023\"\n)") {
+		t.Errorf("Year not imported in order:\n%s", imports)
+	}
+	if year := readFile(t, filepath.Join(root, "2022", "year.go")); !strings.Contains(year, "\t_ \"github.com/rubinda/aoc/2022/25\"\n\t_ \"github.com/rubinda/aoc/2022/26\"\n") {
+		t.Errorf("Day not imported in order:\n%s", year)
+	}
+}
@@ -0,0 +1,198 @@
+// Package server serves the registered solvers over HTTP, so other tools can solve puzzles without building
+// or running the days themselves:
+//
+//	GET  /health                      {"status": "ok"}
+//	GET  /days                        the registered puzzles (year, day, title, parts and implementations)
+//	POST /solve/YEAR/DAY/PART?impl=X  solves the puzzle input in the body, responds with an aoc.Result
+//
+// Errors are JSON objects with an error message, e.g. {"error": "2022/26: puzzle not found"}.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rubinda/aoc"
+)
+
+const (
+	// DefaultMaxInput is the most bytes of puzzle input a request can hold. Personal inputs are a few dozen
+	// kilobytes, generated ones can be many times bigger.
+	DefaultMaxInput = 4 << 20
+	// DefaultTimeout is how long solving a single request can take.
+	DefaultTimeout = 30 * time.Second
+)
+
+// Server is an http.Handler solving puzzles with the solvers of the aoc registry.
+type Server struct {
+	// MaxInput is the most bytes of puzzle input accepted, bigger requests fail with 413.
+	MaxInput int64
+	// Timeout limits solving a request (0 means no limit), slower solvers fail with 504. The response doesn't wait
+	// for the solver, which keeps running until it notices the deadline in its main loop.
+	Timeout time.Duration
+
+	mux *http.ServeMux
+}
+
+// New returns a server with the default limits.
+func New() *Server {
+	s := &Server{MaxInput: DefaultMaxInput, Timeout: DefaultTimeout, mux: http.NewServeMux()}
+	s.mux.HandleFunc("/health", s.health)
+	s.mux.HandleFunc("/days", s.days)
+	s.mux.HandleFunc("/solve/", s.solve)
+	return s
+}
+
+// ServeHTTP routes the request to its endpoint.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Day describes a registered puzzle in the /days listing.
+type Day struct {
+	Year            int      `json:"year"`
+	Day             int      `json:"day"`
+	Title           string   `json:"title"`
+	Parts           int      `json:"parts"`
+	Implementations []string `json:"implementations"`
+}
+
+// health reports that the server is up.
+func (s *Server) health(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// days lists the registered puzzles sorted by year and day.
+func (s *Server) days(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+	puzzles := aoc.Puzzles()
+	days := make([]Day, len(puzzles))
+	for i, p := range puzzles {
+		days[i] = Day{Year: p.Year, Day: p.Day, Title: p.Title, Parts: p.Parts, Implementations: p.ImplementationNames()}
+	}
+	writeJSON(w, http.StatusOK, days)
+}
+
+// solve solves the part of the puzzle in the path (/solve/YEAR/DAY/PART) on the input in the body.
+func (s *Server) solve(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodPost) {
+		return
+	}
+	year, day, part, err := parsePath(strings.TrimPrefix(r.URL.Path, "/solve/"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	puzzle, err := aoc.Lookup(year, day)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	if part < 1 || part > puzzle.Parts {
+		writeError(w, http.StatusNotFound, fmt.Errorf("%v: %w %d", puzzle.Key(), aoc.ErrUnknownPart, part))
+		return
+	}
+	if impl := r.URL.Query().Get("impl"); impl != "" {
+		if puzzle, err = puzzle.Implementation(impl); err != nil {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+	}
+
+	input, err := aoc.ReadInput(http.MaxBytesReader(w, r.Body, s.MaxInput))
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		writeError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("puzzle input is larger than %d bytes", s.MaxInput))
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx := r.Context()
+	if s.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.Timeout)
+		defer cancel()
+	}
+	start := time.Now()
+	type solved struct {
+		answer any
+		err    error
+	}
+	// Solving in the background lets the deadline end the request even if the solver doesn't check ctx
+	done := make(chan solved, 1)
+	go func() {
+		answer, err := puzzle.Solve(ctx, part, strings.NewReader(input))
+		done <- solved{answer, err}
+	}()
+	var answer any
+	select {
+	case out := <-done:
+		answer, err = out.answer, out.err
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	result := aoc.Result{Year: year, Day: day, Part: part, Answer: answer, Duration: time.Since(start), Input: aoc.InputHash(input)}
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		writeError(w, http.StatusGatewayTimeout, fmt.Errorf("%v part %d: no answer within %v", puzzle.Key(), part, s.Timeout))
+	case errors.Is(err, aoc.ErrPanic):
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("%v part %d: %w", puzzle.Key(), part, err))
+	case err != nil:
+		// Anything else the solver rejects is a problem with the input
+		writeError(w, http.StatusUnprocessableEntity, fmt.Errorf("%v part %d: %w", puzzle.Key(), part, err))
+	default:
+		writeJSON(w, http.StatusOK, result)
+	}
+}
+
+// parsePath converts the YEAR/DAY/PART of a solve path to integers.
+func parsePath(path string) (year, day, part int, err error) {
+	fields := strings.Split(path, "/")
+	if len(fields) != 3 {
+		return 0, 0, 0, fmt.Errorf("expected /solve/YEAR/DAY/PART, got /solve/%s", path)
+	}
+	numbers := make([]int, len(fields))
+	for i, field := range fields {
+		if numbers[i], err = strconv.Atoi(field); err != nil {
+			return 0, 0, 0, fmt.Errorf("%q in /solve/%s is not a number", field, path)
+		}
+	}
+	return numbers[0], numbers[1], numbers[2], nil
+}
+
+// allowMethod returns true if the request uses given method, otherwise it responds with 405.
+func allowMethod(w http.ResponseWriter, r *http.Request, method string) bool {
+	if r.Method == method {
+		return true
+	}
+	w.Header().Set("Allow", method)
+	writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed, use %s", r.Method, method))
+	return false
+}
+
+// writeError responds with given status and the error as {"error": "..."}.
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+// writeJSON responds with given status and v encoded as JSON.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	// The status is already sent, a failed write has nowhere to be reported
+	json.NewEncoder(w).Encode(v)
+}
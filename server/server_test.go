@@ -0,0 +1,131 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rubinda/aoc"
+	_ "github.com/rubinda/aoc/2022"
+)
+
+// request sends a request to the server and returns the response status with its decoded JSON body.
+func request(t *testing.T, s *Server, method, path, body string, v any) int {
+	t.Helper()
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Wrong content type! Expected: %v, actual: %v", "application/json", ct)
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), v); err != nil {
+		t.Fatalf("Invalid JSON %q: %v", w.Body.String(), err)
+	}
+	return w.Code
+}
+
+func TestSolve(t *testing.T) {
+	puzzle, _ := aoc.Lookup(2022, 1)
+	var result aoc.Result
+	if code := request(t, New(), http.MethodPost, "/solve/2022/1/2", puzzle.Example, &result); code != http.StatusOK {
+		t.Fatalf("Wrong status! Expected: %v, actual: %v", http.StatusOK, code)
+	}
+	expected := aoc.Result{Year: 2022, Day: 1, Part: 2, Answer: 45000, Duration: result.Duration, Input: aoc.InputHash(puzzle.Example)}
+	if result != expected {
+		t.Errorf("Wrong result! Expected: %v, actual: %v", expected, result)
+	}
+
+	puzzle, _ = aoc.Lookup(2022, 15)
+	if code := request(t, New(), http.MethodPost, "/solve/2022/15/1?impl=scan", puzzle.Example, &result); code != http.StatusOK || result.Answer != 26 {
+		t.Errorf("Wrong result of the scan implementation (status %d): %v", code, result)
+	}
+}
+
+func TestSolveErrors(t *testing.T) {
+	example := "1000\n2000\n\n3000"
+	tests := []struct {
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{http.MethodGet, "/solve/2022/1/1", example, http.StatusMethodNotAllowed},
+		{http.MethodPost, "/solve/2022/1", example, http.StatusNotFound},
+		{http.MethodPost, "/solve/2022/x/1", example, http.StatusNotFound},
+		{http.MethodPost, "/solve/2022/26/1", example, http.StatusNotFound},
+		{http.MethodPost, "/solve/2022/1/3", example, http.StatusNotFound},
+		{http.MethodPost, "/solve/2022/1/1?impl=nope", example, http.StatusNotFound},
+		{http.MethodPost, "/solve/2022/1/1", "1000\nlots", http.StatusUnprocessableEntity},
+		{http.MethodPost, "/solve/2022/1/1", strings.Repeat("1\n", 100), http.StatusRequestEntityTooLarge},
+	}
+	s := New()
+	s.MaxInput = 100
+	for _, tt := range tests {
+		var body map[string]string
+		if status := request(t, s, tt.method, tt.path, tt.body, &body); status != tt.status {
+			t.Errorf("%s %s: wrong status! Expected: %v, actual: %v", tt.method, tt.path, tt.status, status)
+		}
+		if body["error"] == "" {
+			t.Errorf("%s %s: missing error message", tt.method, tt.path)
+		}
+	}
+}
+
+func TestSolveTimeout(t *testing.T) {
+	puzzle, _ := aoc.Lookup(2022, 24)
+	s := New()
+	s.Timeout = time.Nanosecond
+	var body map[string]string
+	if status := request(t, s, http.MethodPost, "/solve/2022/24/2", puzzle.Example, &body); status != http.StatusGatewayTimeout {
+		t.Errorf("Wrong status! Expected: %v, actual: %v (%v)", http.StatusGatewayTimeout, status, body)
+	}
+}
+
+// stuck is released when the tests are done, the stuck puzzle's solver ignores its context until then.
+var stuck = make(chan struct{})
+
+func init() {
+	aoc.Register(aoc.Puzzle{
+		Year:  9999,
+		Day:   1,
+		Title: "Stuck",
+		Parts: 1,
+		Solver: aoc.SolverFunc(func(ctx context.Context, part int, input string) (any, error) {
+			<-stuck
+			return 0, nil
+		}),
+	})
+}
+
+func TestSolveTimeoutIgnored(t *testing.T) {
+	defer close(stuck)
+	s := New()
+	s.Timeout = 10 * time.Millisecond
+	var body map[string]string
+	if status := request(t, s, http.MethodPost, "/solve/9999/1/1", "input", &body); status != http.StatusGatewayTimeout {
+		t.Errorf("Wrong status! Expected: %v, actual: %v (%v)", http.StatusGatewayTimeout, status, body)
+	}
+}
+
+func TestDays(t *testing.T) {
+	var days []Day
+	if status := request(t, New(), http.MethodGet, "/days", "", &days); status != http.StatusOK {
+		t.Fatalf("Wrong status! Expected: %v, actual: %v", http.StatusOK, status)
+	}
+	if len(days) != len(aoc.Puzzles()) {
+		t.Fatalf("Wrong number of days! Expected: %v, actual: %v", len(aoc.Puzzles()), len(days))
+	}
+	if d := days[14]; d.Year != 2022 || d.Day != 15 || d.Parts != 2 || len(d.Implementations) != 1 || d.Implementations[0] != "scan" {
+		t.Errorf("Wrong day: %+v", d)
+	}
+}
+
+func TestHealth(t *testing.T) {
+	var body map[string]string
+	if status := request(t, New(), http.MethodGet, "/health", "", &body); status != http.StatusOK || body["status"] != "ok" {
+		t.Errorf("Unhealthy (status %d): %v", status, body)
+	}
+}
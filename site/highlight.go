@@ -0,0 +1,49 @@
+package site
+
+import (
+	"go/scanner"
+	"go/token"
+	"html"
+	"strings"
+)
+
+// HighlightGo returns Go source as HTML with keywords, strings, numbers and comments in spans of the classes
+// keyword, string, number and comment. Everything else is escaped text, so the source keeps its layout.
+func HighlightGo(src string) string {
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(src))
+	var s scanner.Scanner
+	// Errors don't matter, whatever doesn't scan stays plain text
+	s.Init(file, []byte(src), nil, scanner.ScanComments)
+
+	var out strings.Builder
+	last := 0
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		class, text := "", lit
+		switch {
+		case tok.IsKeyword():
+			class, text = "keyword", tok.String()
+		case tok == token.STRING || tok == token.CHAR:
+			class = "string"
+		case tok == token.INT || tok == token.FLOAT || tok == token.IMAG:
+			class = "number"
+		case tok == token.COMMENT:
+			class = "comment"
+		}
+		start := file.Offset(pos)
+		end := start + len(text)
+		// The scanner drops carriage returns from literals, such tokens stay plain text
+		if class == "" || start < last || end > len(src) || src[start:end] != text {
+			continue
+		}
+		out.WriteString(html.EscapeString(src[last:start]))
+		out.WriteString(`<span class="` + class + `">` + html.EscapeString(text) + "</span>")
+		last = end
+	}
+	out.WriteString(html.EscapeString(src[last:]))
+	return out.String()
+}
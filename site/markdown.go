@@ -0,0 +1,93 @@
+package site
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	headingPattern = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*$`)
+	listPattern    = regexp.MustCompile(`^\s*[-*+]\s+(.*)$`)
+	fencePattern   = regexp.MustCompile("^```\\s*([a-z]*)")
+	// inline patterns work on escaped text, code spans are taken out before the others apply.
+	codePattern   = regexp.MustCompile("`([^`]+)`")
+	linkPattern   = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	strongPattern = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+	emPattern     = regexp.MustCompile(`\*([^*\s][^*]*)\*`)
+)
+
+// Markdown converts the Markdown the days' readmes use into HTML: headings, paragraphs, lists, fenced code blocks,
+// code spans, links and emphasis. Everything else is kept as text.
+func Markdown(text string) string {
+	var out strings.Builder
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	paragraph := make([]string, 0)
+	inList := false
+	flush := func() {
+		if len(paragraph) > 0 {
+			out.WriteString("<p>" + inline(strings.Join(paragraph, " ")) + "</p>\n")
+			paragraph = paragraph[:0]
+		}
+		if inList {
+			out.WriteString("</ul>\n")
+			inList = false
+		}
+	}
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if m := fencePattern.FindStringSubmatch(line); m != nil {
+			flush()
+			code := make([]string, 0)
+			for i++; i < len(lines) && !strings.HasPrefix(lines[i], "```"); i++ {
+				code = append(code, lines[i])
+			}
+			class := ""
+			if m[1] != "" {
+				class = ` class="language-` + m[1] + `"`
+			}
+			out.WriteString("<pre><code" + class + ">" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>\n")
+			continue
+		}
+		if m := headingPattern.FindStringSubmatch(line); m != nil {
+			flush()
+			level := string(rune('0' + len(m[1])))
+			out.WriteString("<h" + level + ">" + inline(m[2]) + "</h" + level + ">\n")
+			continue
+		}
+		if m := listPattern.FindStringSubmatch(line); m != nil {
+			if !inList {
+				flush()
+				out.WriteString("<ul>\n")
+				inList = true
+			}
+			out.WriteString("<li>" + inline(m[1]) + "</li>\n")
+			continue
+		}
+		if strings.TrimSpace(line) == "" {
+			flush()
+			continue
+		}
+		if inList {
+			flush()
+		}
+		paragraph = append(paragraph, strings.TrimSpace(line))
+	}
+	flush()
+	return out.String()
+}
+
+// inline escapes a line of text and converts its code spans, links and emphasis.
+func inline(text string) string {
+	text = html.EscapeString(text)
+	// Code spans are replaced by placeholders, so the other patterns don't apply inside them
+	spans := codePattern.FindAllStringSubmatch(text, -1)
+	text = codePattern.ReplaceAllString(text, "\x00")
+	text = linkPattern.ReplaceAllString(text, `<a href="$2">$1</a>`)
+	text = strongPattern.ReplaceAllString(text, "<strong>$1</strong>")
+	text = emPattern.ReplaceAllString(text, "<em>$1</em>")
+	for _, span := range spans {
+		text = strings.Replace(text, "\x00", "<code>"+span[1]+"</code>", 1)
+	}
+	return text
+}
@@ -0,0 +1,291 @@
+// Package site renders a static HTML site of the solutions: an index of the days and a page per day with its
+// readme, the highlighted solution, the examples with their answers, known answers, benchmark results and
+// visualizations. The site needs no network connection to build or to browse (it works from the file system).
+package site
+
+import (
+	"embed"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rubinda/aoc"
+	"github.com/rubinda/aoc/bench"
+	"github.com/rubinda/aoc/scaffold"
+	"golang.org/x/exp/slices"
+)
+
+// VisualizationExts are the extensions of files in a day's folders shown as its visualizations.
+var VisualizationExts = []string{".gif", ".png", ".svg"}
+
+//go:embed templates/*.tmpl templates/style.css
+var templateFS embed.FS
+
+var templates = template.Must(template.New("").Funcs(template.FuncMap{
+	"duration": func(ns int64) string {
+		return time.Duration(ns).Round(time.Microsecond).String()
+	},
+}).ParseFS(templateFS, "templates/*.tmpl"))
+
+// Config describes what goes on the site.
+type Config struct {
+	// Root is the repository root holding the days' folders.
+	Root string
+	// Puzzles are the days that get a page.
+	Puzzles []aoc.Puzzle
+	// Answers holds known answers, the ones of inputs other than the examples are shown (nil for none).
+	Answers *aoc.Answers
+	// Baseline holds benchmark results, e.g. saved by the last aoc bench (nil for none).
+	Baseline *bench.Baseline
+	// VizDir holds the animations written by aoc viz in YEAR/DAY subdirectories (empty for none). Visualizations
+	// in a day's own folder are shown as well.
+	VizDir string
+}
+
+// source is a highlighted source file of a day.
+type source struct {
+	Name string
+	Code template.HTML
+}
+
+// answer is the answer to a part for an input.
+type answer struct {
+	Input  string
+	Part   int
+	Answer string
+}
+
+// example is an example input with the answers the puzzle description gives.
+type example struct {
+	Name    string
+	Input   string
+	Answers []answer
+}
+
+// benchmark is a benchmark result with a readable name of its input.
+type benchmark struct {
+	bench.Result
+	InputName string
+}
+
+// dayPage holds everything shown on the page of a day.
+type dayPage struct {
+	Puzzle         aoc.Puzzle
+	URL            string
+	Readme         template.HTML
+	Sources        []source
+	Examples       []example
+	Answers        []answer
+	Benchmarks     []benchmark
+	Visualizations []string
+	Baseline       *bench.Baseline
+	// visualizationPaths are the files of the visualizations.
+	visualizationPaths []string
+}
+
+// PartBenchmarks returns a benchmark per benchmarked part for the index, personal inputs before examples.
+func (d dayPage) PartBenchmarks() []benchmark {
+	byPart := make(map[int]benchmark)
+	parts := make([]int, 0)
+	for _, b := range d.Benchmarks {
+		known, ok := byPart[b.Part]
+		if !ok {
+			parts = append(parts, b.Part)
+		}
+		if !ok || (d.isExample(known.InputName) && !d.isExample(b.InputName)) {
+			byPart[b.Part] = b
+		}
+	}
+	benchmarks := make([]benchmark, len(parts))
+	for i, part := range parts {
+		benchmarks[i] = byPart[part]
+	}
+	return benchmarks
+}
+
+// isExample returns true if an input of given name is one of the day's examples.
+func (d dayPage) isExample(inputName string) bool {
+	for _, e := range d.Examples {
+		if e.Name == inputName {
+			return true
+		}
+	}
+	return false
+}
+
+// indexPage holds the list of days with their benchmarks.
+type indexPage struct {
+	Days     []dayPage
+	Baseline *bench.Baseline
+}
+
+// Build writes the site into dir: index.html, style.css and YEAR/DAY/index.html with the visualizations of every day.
+func Build(dir string, cfg Config) error {
+	index := indexPage{Days: make([]dayPage, 0, len(cfg.Puzzles)), Baseline: cfg.Baseline}
+	for _, p := range cfg.Puzzles {
+		page, err := newDayPage(p, cfg)
+		if err != nil {
+			return fmt.Errorf("%v: %w", p.Key(), err)
+		}
+		dayDir := filepath.Join(dir, strconv.Itoa(p.Year), strconv.Itoa(p.Day))
+		if err := os.MkdirAll(dayDir, 0o755); err != nil {
+			return err
+		}
+		if err := render(filepath.Join(dayDir, "index.html"), "day.html.tmpl", page); err != nil {
+			return err
+		}
+		for i, name := range page.Visualizations {
+			if err := copyFile(page.visualizationPaths[i], filepath.Join(dayDir, name)); err != nil {
+				return err
+			}
+		}
+		index.Days = append(index.Days, page)
+	}
+	if err := render(filepath.Join(dir, "index.html"), "index.html.tmpl", index); err != nil {
+		return err
+	}
+	style, err := templateFS.ReadFile("templates/style.css")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, "style.css"), style, 0o644)
+}
+
+// newDayPage gathers the page of a day from its folder and the config.
+func newDayPage(p aoc.Puzzle, cfg Config) (dayPage, error) {
+	dir := scaffold.Dir(cfg.Root, p.Year, p.Day)
+	page := dayPage{Puzzle: p, URL: fmt.Sprintf("https://adventofcode.com/%d/day/%d", p.Year, p.Day), Baseline: cfg.Baseline}
+	readme, err := os.ReadFile(filepath.Join(dir, "readme.md"))
+	if err != nil && !os.IsNotExist(err) {
+		return page, err
+	}
+	page.Readme = template.HTML(Markdown(string(readme)))
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return page, err
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		switch {
+		case entry.IsDir():
+		case strings.HasSuffix(name, ".go") && !strings.HasSuffix(name, "_test.go"):
+			code, err := os.ReadFile(filepath.Join(dir, name))
+			if err != nil {
+				return page, err
+			}
+			page.Sources = append(page.Sources, source{Name: name, Code: template.HTML(HighlightGo(string(code)))})
+		case isVisualization(name):
+			page.addVisualization(dir, name)
+		}
+	}
+	if cfg.VizDir != "" {
+		vizDir := scaffold.Dir(cfg.VizDir, p.Year, p.Day)
+		entries, err := os.ReadDir(vizDir)
+		if err != nil && !os.IsNotExist(err) {
+			return page, err
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() && isVisualization(entry.Name()) {
+				page.addVisualization(vizDir, entry.Name())
+			}
+		}
+	}
+
+	// Inputs are named after the examples, other inputs by their hash
+	inputNames := make(map[string]string)
+	examples, err := aoc.LoadExamples(filepath.Join(dir, aoc.ExamplesFile))
+	if err != nil {
+		return page, err
+	}
+	for _, e := range examples {
+		text, err := e.Text(dir)
+		if err != nil {
+			return page, err
+		}
+		inputNames[aoc.InputHash(text)] = e.Name
+		shown := example{Name: e.Name, Input: text}
+		for part := 1; part <= p.Parts; part++ {
+			if a, ok := e.Answers[part]; ok {
+				shown.Answers = append(shown.Answers, answer{Input: e.Name, Part: part, Answer: a})
+			}
+		}
+		page.Examples = append(page.Examples, shown)
+	}
+	inputName := func(hash string) string {
+		if name, ok := inputNames[hash]; ok {
+			return name
+		}
+		return fmt.Sprintf("input %.12s", hash)
+	}
+	if cfg.Answers != nil {
+		for _, a := range cfg.Answers.Answers {
+			if a.Year == p.Year && a.Day == p.Day {
+				if _, isExample := inputNames[a.Input]; !isExample {
+					page.Answers = append(page.Answers, answer{Input: inputName(a.Input), Part: a.Part, Answer: a.Answer})
+				}
+			}
+		}
+	}
+	if cfg.Baseline != nil {
+		for _, r := range cfg.Baseline.Results {
+			if r.Year == p.Year && r.Day == p.Day {
+				page.Benchmarks = append(page.Benchmarks, benchmark{Result: r, InputName: inputName(r.Input)})
+			}
+		}
+		sort.SliceStable(page.Benchmarks, func(i, j int) bool {
+			return page.Benchmarks[i].Part < page.Benchmarks[j].Part
+		})
+	}
+	return page, nil
+}
+
+// addVisualization adds the file of given name in dir to the visualizations, unless one of the name is shown already.
+func (d *dayPage) addVisualization(dir, name string) {
+	if !slices.Contains(d.Visualizations, name) {
+		d.Visualizations = append(d.Visualizations, name)
+		d.visualizationPaths = append(d.visualizationPaths, filepath.Join(dir, name))
+	}
+}
+
+// isVisualization returns true if the file is shown as a visualization.
+func isVisualization(name string) bool {
+	return slices.Contains(VisualizationExts, strings.ToLower(filepath.Ext(name)))
+}
+
+// render executes the named template with data into a file.
+func render(path, name string, data any) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := templates.ExecuteTemplate(f, name, data); err != nil {
+		f.Close()
+		return fmt.Errorf("rendering %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// copyFile copies the file at src to dst.
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
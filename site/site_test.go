@@ -0,0 +1,73 @@
+package site
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rubinda/aoc"
+	_ "github.com/rubinda/aoc/2022"
+	"github.com/rubinda/aoc/bench"
+)
+
+func TestMarkdown(t *testing.T) {
+	text := "# Day 1\n\nSome `a < b` and **bold** and *em* with [link](https://example.com).\n\n- one\n- two\n\n```go\nx := 1 < 2\n```\n"
+	expected := "<h1>Day 1</h1>\n" +
+		"<p>Some <code>a &lt; b</code> and <strong>bold</strong> and <em>em</em> with <a href=\"https://example.com\">link</a>.</p>\n" +
+		"<ul>\n<li>one</li>\n<li>two</li>\n</ul>\n" +
+		"<pre><code class=\"language-go\">x := 1 &lt; 2</code></pre>\n"
+	if actual := Markdown(text); actual != expected {
+		t.Errorf("Wrong result! Expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestHighlightGo(t *testing.T) {
+	src := "func f() int {\n\t// one < two\n\treturn 1 // \"x\"\n}\n"
+	expected := "<span class=\"keyword\">func</span> f() int {\n" +
+		"\t<span class=\"comment\">// one &lt; two</span>\n" +
+		"\t<span class=\"keyword\">return</span> <span class=\"number\">1</span> <span class=\"comment\">// &#34;x&#34;</span>\n}\n"
+	if actual := HighlightGo(src); actual != expected {
+		t.Errorf("Wrong result! Expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestBuild(t *testing.T) {
+	p, err := aoc.Lookup(2022, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	baseline := &bench.Baseline{Results: []bench.Result{{Year: 2022, Day: 1, Part: 1, Input: aoc.InputHash(p.ExampleInput(1)), Runs: 10, NsPerOp: 1500000}}}
+	// An animation written by aoc viz
+	vizDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(vizDir, "2022", "1"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(vizDir, "2022", "1", "part1.gif"), []byte("GIF89a"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	if err := Build(dir, Config{Root: "..", Puzzles: []aoc.Puzzle{p}, Baseline: baseline, VizDir: vizDir}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string][]string{
+		"index.html": {`href="2022/1/index.html"`, "Calorie Counting", "part 1: 1.5ms"},
+		"2022/1/index.html": {
+			"https://adventofcode.com/2022/day/1", `<span class="keyword">package</span>`, "24000", "45000", "1.5ms", "part1.gif",
+		},
+		"2022/1/part1.gif": {"GIF89a"},
+		"style.css":        {".keyword"},
+	}
+	for name, contents := range expected {
+		page, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, content := range contents {
+			if !strings.Contains(string(page), content) {
+				t.Errorf("Wrong result! Expected %v in %v", content, name)
+			}
+		}
+	}
+}